@@ -20,8 +20,11 @@ type SDK struct {
 
 // New creates a new Abhi SDK instance
 func New(config *client.Config) *SDK {
-	c := client.New(config)
-	
+	return newSDK(client.New(config))
+}
+
+// newSDK creates the SDK services around an already constructed client
+func newSDK(c *client.Client) *SDK {
 	return &SDK{
 		client:       c,
 		Employee:     services.NewEmployeeService(c),
@@ -33,12 +36,28 @@ func New(config *client.Config) *SDK {
 	}
 }
 
+// NewWithError creates a new Abhi SDK instance, returning an error if config is unusable
+func NewWithError(config *client.Config) (*SDK, error) {
+	c, err := client.NewWithError(config)
+	if err != nil {
+		return nil, err
+	}
+	return newSDK(c), nil
+}
+
 // NewWithCredentials creates a new Abhi SDK instance with credentials
 func NewWithCredentials(baseURL, username, password string) *SDK {
 	config := client.NewConfig(baseURL, username, password)
 	return New(config)
 }
 
+// NewWithAPIKey creates a new Abhi SDK instance that authenticates with a static API key
+// instead of logging in
+func NewWithAPIKey(baseURL, apiKey string) *SDK {
+	config := client.NewConfigWithAPIKey(baseURL, apiKey)
+	return New(config)
+}
+
 // NewForUAT creates a new SDK instance configured for UAT environment
 func NewForUAT(username, password string) *SDK {
 	config := client.DefaultConfig()
@@ -63,6 +82,60 @@ func (s *SDK) SetRetryPolicy(maxRetries int, retryDelay int) *SDK {
 	return s
 }
 
+// SetRetryPolicyWithJitter configures retry behavior with randomized backoff to avoid synchronized retries
+func (s *SDK) SetRetryPolicyWithJitter(maxRetries int, retryDelay int) *SDK {
+	s.client.SetRetryPolicyWithJitter(maxRetries, time.Duration(retryDelay)*time.Second)
+	return s
+}
+
+// SetRetryConfig configures retry behavior, including which additional status codes and methods are retried
+func (s *SDK) SetRetryConfig(config client.RetryConfig) *SDK {
+	s.client.SetRetryConfig(config)
+	return s
+}
+
+// SetLogger logs API requests and responses with credentials masked, for debugging failed calls
+func (s *SDK) SetLogger(logger client.Logger) *SDK {
+	s.client.SetLogger(logger)
+	return s
+}
+
+// SetUserAgent sets the User-Agent sent on every request so Abhi can attribute traffic to the integrator
+func (s *SDK) SetUserAgent(userAgent string) *SDK {
+	s.client.SetUserAgent(userAgent)
+	return s
+}
+
+// SetDefaultOrganizationID scopes every API request to a sub-organization
+func (s *SDK) SetDefaultOrganizationID(organizationID string) *SDK {
+	s.client.SetDefaultOrganizationID(organizationID)
+	return s
+}
+
+// SetEventSink publishes lifecycle events, such as created transactions, to sink
+func (s *SDK) SetEventSink(sink client.EventSink) *SDK {
+	s.client.SetEventSink(sink)
+	return s
+}
+
+// SetAuthToken authenticates with a token minted outside the SDK instead of logging in
+func (s *SDK) SetAuthToken(token string, expiresAt time.Time) *SDK {
+	s.client.SetAuthToken(token, expiresAt)
+	return s
+}
+
+// SetAuthTokenRefresher sets the callback used to renew a token supplied by SetAuthToken
+func (s *SDK) SetAuthTokenRefresher(refresher client.TokenRefresher) *SDK {
+	s.client.SetAuthTokenRefresher(refresher)
+	return s
+}
+
+// SetTokenExpiryBuffer sets how long before expiry the auth token is refreshed (default 5 minutes)
+func (s *SDK) SetTokenExpiryBuffer(buffer time.Duration) *SDK {
+	s.client.SetTokenExpiryBuffer(buffer)
+	return s
+}
+
 // GetClient returns the underlying HTTP client for advanced usage
 func (s *SDK) GetClient() *client.Client {
 	return s.client
@@ -97,9 +170,10 @@ func (s *SDK) EnableCredentialEncryption(encryptionPassword string) *SDK {
 	return s
 }
 
-// EnableRequestSigning enables request signing for additional security
-func (s *SDK) EnableRequestSigning(signingSecret string) *SDK {
-	s.client.EnableRequestSigning(signingSecret)
+// EnableRequestSigning enables request signing for additional security. The signature covers
+// signedHeaders in order, or client.DefaultSignedHeaders if none are given.
+func (s *SDK) EnableRequestSigning(signingSecret string, signedHeaders ...string) *SDK {
+	s.client.EnableRequestSigning(signingSecret, signedHeaders...)
 	return s
 }
 
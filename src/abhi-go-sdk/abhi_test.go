@@ -1,6 +1,11 @@
 package abhi
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"abhi-go-sdk/client"
@@ -127,4 +132,122 @@ func BenchmarkSetRetryPolicy(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		sdk.SetRetryPolicy(3, 2)
 	}
-}
\ No newline at end of file
+}
+
+func TestSetUserAgent(t *testing.T) {
+	userAgents := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents[r.URL.Path] = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			w.Write([]byte(`{"statusCode":200,"data":{"token":"test-token"}}`))
+			return
+		}
+		w.Write([]byte(`{"statusCode":200,"data":{"id":"emp-1"}}`))
+	}))
+	defer server.Close()
+
+	sdk := NewWithCredentials(server.URL, "test", "pass").SetUserAgent("acme-payroll/2.1")
+
+	if _, err := sdk.Employee.GetByID(context.Background(), "emp-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if userAgents["/auth/login"] != "acme-payroll/2.1" {
+		t.Errorf("Expected User-Agent on login, got %q", userAgents["/auth/login"])
+	}
+	if userAgents["/employees/emp-1"] != "acme-payroll/2.1" {
+		t.Errorf("Expected User-Agent on requests, got %q", userAgents["/employees/emp-1"])
+	}
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"data":{"token":"test-token"}}`))
+	}))
+	defer server.Close()
+
+	sdk := NewWithCredentials(server.URL, "test", "pass")
+	if _, err := sdk.Employee.GetByID(context.Background(), "emp-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, userAgent := range userAgents {
+		if userAgent != client.DefaultUserAgent {
+			t.Errorf("Expected default User-Agent %q, got %q", client.DefaultUserAgent, userAgent)
+		}
+	}
+}
+
+func TestNewWithAPIKey(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/auth/login" {
+			t.Error("Expected no login with an API key")
+		}
+		if key := r.Header.Get(client.APIKeyHeader); key != "integrator-key" {
+			t.Errorf("Expected the API key header, got %q", key)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Expected no Authorization header, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"data":{"id":"emp-1"}}`))
+	}))
+	defer server.Close()
+
+	sdk := NewWithAPIKey(server.URL, "integrator-key")
+	for i := 0; i < 2; i++ {
+		if _, err := sdk.Employee.GetByID(context.Background(), "emp-1"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if len(paths) != 2 {
+		t.Errorf("Expected only the two API calls, got %v", paths)
+	}
+}
+
+// countingLogger counts the request and response lines logged by the SDK
+type countingLogger struct {
+	mutex     sync.Mutex
+	requests  int
+	responses int
+}
+
+func (l *countingLogger) Printf(format string, v ...interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	switch {
+	case strings.HasPrefix(format, "-->"):
+		l.requests++
+	case strings.HasPrefix(format, "<--"):
+		l.responses++
+	}
+}
+
+func TestNewWithErrorWrapsTransportOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"data":{"id":"emp-1"}}`))
+	}))
+	defer server.Close()
+
+	logger := &countingLogger{}
+	sdk, err := NewWithError(client.NewConfigWithAPIKey(server.URL, "integrator-key").SetLogger(logger))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := sdk.Employee.GetByID(context.Background(), "emp-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if logger.requests != 1 || logger.responses != 1 {
+		t.Errorf("Expected one request and one response line per call, got %d and %d", logger.requests, logger.responses)
+	}
+}
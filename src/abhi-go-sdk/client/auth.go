@@ -23,8 +23,19 @@ type AuthManager struct {
 	httpClient   *http.Client
 	refreshing   bool
 	refreshMutex sync.Mutex
+
+	refreshTokenValue string
+	user              *models.AuthUser
+
+	external       bool           // The token was supplied by SetToken, so never log in with credentials
+	tokenRefresher TokenRefresher // Obtains a new external token once the current one expires
+	clearedToken   string         // Token discarded by ClearToken, never reloaded from the token store
 }
 
+// TokenRefresher obtains a new access token and its expiry from outside the SDK, e.g. from a
+// sidecar or a separate auth service
+type TokenRefresher func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
 // NewAuthManager creates a new authentication manager
 func NewAuthManager(config *Config) *AuthManager {
 	return &AuthManager{
@@ -35,6 +46,10 @@ func NewAuthManager(config *Config) *AuthManager {
 
 // GetToken returns a valid JWT token, refreshing if necessary
 func (a *AuthManager) GetToken(ctx context.Context) (string, error) {
+	if a.config != nil && a.config.APIKey != "" {
+		return "", fmt.Errorf("no token is used with API key authentication")
+	}
+
 	a.mutex.RLock()
 	if a.isTokenValid() {
 		token := a.token
@@ -52,69 +67,213 @@ func (a *AuthManager) isTokenValid() bool {
 		return false
 	}
 
-	// Check if token expires in the next 5 minutes (buffer time)
-	return time.Now().Add(5 * time.Minute).Before(a.expiresAt)
+	// Treat the token as expired slightly early so it doesn't lapse mid-request
+	return time.Now().Add(a.config.tokenExpiryBuffer()).Before(a.expiresAt)
+}
+
+// issuedToken is a token returned by renewToken
+type issuedToken struct {
+	token     string
+	expiresAt time.Time
+	refreshed bool // False when the current token was still usable
 }
 
-// refreshToken obtains a new JWT token
+// refreshToken obtains a new JWT token, saves it to the token store and reports it to
+// Config.OnTokenRefreshed. The callback runs after the locks are released so it can safely call
+// back into the SDK.
 func (a *AuthManager) refreshToken(ctx context.Context) (string, error) {
-	a.refreshMutex.Lock()
-	defer a.refreshMutex.Unlock()
+	issued, err := a.renewToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !issued.refreshed {
+		return issued.token, nil
+	}
 
-	// Double-check if another goroutine already refreshed the token
 	a.mutex.RLock()
+	external := a.external
+	a.mutex.RUnlock()
+
+	// Tokens supplied by SetToken are managed by whoever supplied them
+	if store := a.config.TokenStore; store != nil && !external {
+		if err := store.Save(issued.token, issued.expiresAt); err != nil && a.config.Logger != nil {
+			a.config.Logger.Printf("failed to save token to token store: %v", err)
+		}
+	}
+
+	if a.config.OnTokenRefreshed != nil {
+		a.config.OnTokenRefreshed(issued.token, issued.expiresAt)
+	}
+	return issued.token, nil
+}
+
+// loadStoredToken adopts the token in the token store if it is still valid and wasn't rejected
+// by the API. The caller must hold the write lock.
+func (a *AuthManager) loadStoredToken() bool {
+	if a.config.TokenStore == nil || a.external {
+		return false
+	}
+
+	token, expiresAt, ok := a.config.TokenStore.Load()
+	if !ok || token == "" || token == a.token || token == a.clearedToken {
+		return false
+	}
+
+	previousToken, previousExpiry := a.token, a.expiresAt
+	a.token, a.expiresAt = token, expiresAt
 	if a.isTokenValid() {
-		token := a.token
-		a.mutex.RUnlock()
-		return token, nil
+		return true
+	}
+	a.token, a.expiresAt = previousToken, previousExpiry
+	return false
+}
+
+// renewToken obtains a new JWT token, exchanging the refresh token from the last login when
+// there is one and falling back to a full login with the configured credentials
+func (a *AuthManager) renewToken(ctx context.Context) (*issuedToken, error) {
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
+
+	// Double-check if another goroutine already refreshed the token, and reuse a token saved by
+	// an earlier SDK instance instead of logging in
+	a.mutex.Lock()
+	if a.isTokenValid() || a.loadStoredToken() {
+		issued := &issuedToken{token: a.token, expiresAt: a.expiresAt}
+		a.mutex.Unlock()
+		return issued, nil
+	}
+	refreshToken := a.refreshTokenValue
+	external := a.external
+	a.mutex.Unlock()
+
+	if external {
+		return a.refreshExternalToken(ctx)
+	}
+
+	// Exchange the refresh token so credentials aren't re-sent
+	if refreshToken != "" {
+		issued, err := a.requestToken(ctx, "/auth/refresh", models.RefreshTokenRequest{RefreshToken: refreshToken}, "token refresh")
+		if err == nil {
+			return issued, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		// The refresh token was rejected, so don't offer it again
+		a.mutex.Lock()
+		if a.refreshTokenValue == refreshToken {
+			a.refreshTokenValue = ""
+		}
+		a.mutex.Unlock()
 	}
-	a.mutex.RUnlock()
 
 	// Perform login to get new token
 	loginReq := models.LoginRequest{
 		Username: a.config.Username,
 		Password: a.config.Password,
 	}
+	return a.requestToken(ctx, "/auth/login", loginReq, "login")
+}
+
+// refreshExternalToken renews a token supplied by SetToken through the token refresher. Without
+// a refresher the token is used until it expires and then reported as expired, since the SDK
+// has no credentials of its own to fall back on.
+func (a *AuthManager) refreshExternalToken(ctx context.Context) (*issuedToken, error) {
+	a.mutex.RLock()
+	token, expiresAt, refresher := a.token, a.expiresAt, a.tokenRefresher
+	a.mutex.RUnlock()
+
+	if refresher == nil {
+		if token != "" && time.Now().Before(expiresAt) {
+			return &issuedToken{token: token, expiresAt: expiresAt}, nil
+		}
+		return nil, fmt.Errorf("externally supplied token expired and no token refresher is set")
+	}
 
-	reqBody, err := json.Marshal(loginReq)
+	token, expiresAt, err := refresher(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to marshal login request")
+		return nil, errors.Wrap(err, "failed to refresh externally supplied token")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("token refresher returned an empty token")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", a.config.BaseURL+"/auth/login", bytes.NewBuffer(reqBody))
+	a.mutex.Lock()
+	a.token = token
+	a.expiresAt = expiresAt
+	a.mutex.Unlock()
+
+	return &issuedToken{token: token, expiresAt: expiresAt, refreshed: true}, nil
+}
+
+// SetToken makes the manager use a token obtained outside the SDK until expiresAt, bypassing the
+// username and password login. Once it expires, the token refresher is asked for a new one.
+func (a *AuthManager) SetToken(token string, expiresAt time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.token = token
+	a.expiresAt = expiresAt
+	a.external = true
+	a.refreshTokenValue = ""
+	a.user = nil
+}
+
+// SetTokenRefresher sets the callback that renews tokens supplied by SetToken
+func (a *AuthManager) SetTokenRefresher(refresher TokenRefresher) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.tokenRefresher = refresher
+}
+
+// requestToken posts body to an endpoint that issues tokens and stores the token, refresh token and
+// user it returns. A refresh token or user missing from the response keeps the current one.
+func (a *AuthManager) requestToken(ctx context.Context, endpoint string, body interface{}, operation string) (*issuedToken, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s request", operation)
+	}
+
+	requestURL, err := joinURL(a.config.BaseURL, endpoint)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to create login request")
+		return nil, errors.Wrapf(err, "failed to build %s URL", operation)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", requestURL, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s request", operation)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", a.config.userAgent())
 
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to perform login request")
+		return nil, errors.Wrapf(err, "failed to perform %s request", operation)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		var errorResp models.ErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil {
-			return "", fmt.Errorf("login failed: %s", errorResp.Message)
+			return nil, fmt.Errorf("%s failed: %s", operation, errorResp.Message)
 		}
-		return "", fmt.Errorf("login failed with status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("%s failed with status code: %d", operation, resp.StatusCode)
 	}
 
 	var apiResp models.APIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return "", errors.Wrap(err, "failed to decode login response")
+		return nil, errors.Wrapf(err, "failed to decode %s response", operation)
 	}
 
 	loginData, ok := apiResp.Data.(map[string]interface{})
 	if !ok {
-		return "", errors.New("invalid login response data format")
+		return nil, fmt.Errorf("invalid %s response data format", operation)
 	}
 
 	token, ok := loginData["token"].(string)
 	if !ok {
-		return "", errors.New("token not found in login response")
+		return nil, fmt.Errorf("token not found in %s response", operation)
 	}
 
 	// Parse JWT to get expiration time
@@ -124,12 +283,44 @@ func (a *AuthManager) refreshToken(ctx context.Context) (string, error) {
 		expiresAt = time.Now().Add(23 * time.Hour)
 	}
 
+	// Capture the refresh token and user returned alongside the token
+	var authResp models.AuthResponse
+	if data, err := json.Marshal(loginData); err == nil {
+		json.Unmarshal(data, &authResp)
+	}
+
 	a.mutex.Lock()
 	a.token = token
 	a.expiresAt = expiresAt
+	if authResp.RefreshToken != "" {
+		a.refreshTokenValue = authResp.RefreshToken
+	}
+	if _, ok := loginData["user"].(map[string]interface{}); ok {
+		a.user = &authResp.User
+	}
 	a.mutex.Unlock()
 
-	return token, nil
+	return &issuedToken{token: token, expiresAt: expiresAt, refreshed: true}, nil
+}
+
+// CurrentUser returns the user returned by the last login, or nil if none was returned
+func (a *AuthManager) CurrentUser() *models.AuthUser {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if a.user == nil {
+		return nil
+	}
+	user := *a.user
+	user.Permissions = append([]string(nil), a.user.Permissions...)
+	return &user
+}
+
+// RefreshToken returns the refresh token returned by the last login, if any
+func (a *AuthManager) RefreshToken() string {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.refreshTokenValue
 }
 
 // parseTokenExpiration extracts the expiration time from JWT token
@@ -156,6 +347,11 @@ func (a *AuthManager) parseTokenExpiration(tokenString string) (time.Time, error
 func (a *AuthManager) ClearToken() {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
+	if a.token != "" {
+		a.clearedToken = a.token
+	}
 	a.token = ""
 	a.expiresAt = time.Time{}
+	a.refreshTokenValue = ""
+	a.user = nil
 }
\ No newline at end of file
@@ -226,6 +226,33 @@ func TestIsTokenValid(t *testing.T) {
 	}
 }
 
+func TestIsTokenValidWithExpiryBuffer(t *testing.T) {
+	config := &Config{
+		Username:          "test",
+		Password:          "pass",
+		HTTPClient:        &http.Client{Timeout: 30 * time.Second},
+		TokenExpiryBuffer: time.Minute,
+	}
+	authManager := NewAuthManager(config)
+	authManager.token = "some-token"
+
+	authManager.expiresAt = time.Now().Add(2 * time.Minute)
+	if !authManager.isTokenValid() {
+		t.Error("Expected token expiring after the 1 minute buffer to be valid")
+	}
+
+	authManager.expiresAt = time.Now().Add(30 * time.Second)
+	if authManager.isTokenValid() {
+		t.Error("Expected token expiring within the 1 minute buffer to be invalid")
+	}
+
+	// A negative buffer uses the token until it expires
+	config.TokenExpiryBuffer = -1
+	if !authManager.isTokenValid() {
+		t.Error("Expected unexpired token to be valid without a buffer")
+	}
+}
+
 func TestParseTokenExpiration(t *testing.T) {
 	authManager := &AuthManager{}
 
@@ -321,6 +348,318 @@ func TestRefreshTokenInvalidResponse(t *testing.T) {
 	}
 }
 
+func TestLoginCapturesRefreshTokenAndUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := models.APIResponse{
+			StatusCode: 200,
+			Message:    "Success",
+			Data: map[string]interface{}{
+				"token":        createTestJWT(time.Now().Add(time.Hour)),
+				"refreshToken": "refresh-123",
+				"user": map[string]interface{}{
+					"id":             "user-1",
+					"username":       "test",
+					"email":          "test@example.com",
+					"role":           "admin",
+					"organizationId": "org-1",
+					"permissions":    []string{"employees:read"},
+					"isActive":       true,
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		BaseURL:    server.URL,
+		Username:   "test",
+		Password:   "pass",
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	authManager := NewAuthManager(config)
+	if authManager.CurrentUser() != nil {
+		t.Error("Expected no user before login")
+	}
+
+	if _, err := authManager.GetToken(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if authManager.RefreshToken() != "refresh-123" {
+		t.Errorf("Expected refresh token refresh-123, got %q", authManager.RefreshToken())
+	}
+
+	user := authManager.CurrentUser()
+	if user == nil {
+		t.Fatal("Expected user to be captured")
+	}
+	if user.ID != "user-1" || user.Role != "admin" || user.OrganizationID != "org-1" || !user.IsActive {
+		t.Errorf("Unexpected user: %+v", user)
+	}
+
+	// The returned user is a copy
+	user.Permissions[0] = "tampered"
+	if authManager.CurrentUser().Permissions[0] != "employees:read" {
+		t.Error("Expected CurrentUser to return a copy")
+	}
+
+	authManager.ClearToken()
+	if authManager.CurrentUser() != nil || authManager.RefreshToken() != "" {
+		t.Error("Expected ClearToken to clear the user and refresh token")
+	}
+}
+
+func TestLoginWithoutUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.APIResponse{
+			StatusCode: 200,
+			Data:       map[string]interface{}{"token": createTestJWT(time.Now().Add(time.Hour))},
+		})
+	}))
+	defer server.Close()
+
+	authManager := NewAuthManager(&Config{
+		BaseURL:    server.URL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	})
+
+	if _, err := authManager.GetToken(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if authManager.CurrentUser() != nil {
+		t.Error("Expected no user when the login response doesn't include one")
+	}
+}
+
+func TestExpiredTokenUsesRefreshToken(t *testing.T) {
+	var paths []string
+	var refreshReq models.RefreshTokenRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		data := map[string]interface{}{}
+		switch r.URL.Path {
+		case "/auth/login":
+			data["token"] = createTestJWT(time.Now().Add(-time.Hour))
+			data["refreshToken"] = "refresh-1"
+		case "/auth/refresh":
+			json.NewDecoder(r.Body).Decode(&refreshReq)
+			data["token"] = createTestJWT(time.Now().Add(time.Hour))
+			data["refreshToken"] = "refresh-2"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: data})
+	}))
+	defer server.Close()
+
+	authManager := NewAuthManager(&Config{
+		BaseURL:    server.URL,
+		Username:   "test",
+		Password:   "pass",
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	})
+	ctx := context.Background()
+
+	if _, err := authManager.GetToken(ctx); err != nil {
+		t.Fatalf("Expected no error on login, got %v", err)
+	}
+	if _, err := authManager.GetToken(ctx); err != nil {
+		t.Fatalf("Expected no error on refresh, got %v", err)
+	}
+
+	if len(paths) != 2 || paths[0] != "/auth/login" || paths[1] != "/auth/refresh" {
+		t.Fatalf("Expected a login then a refresh, got %v", paths)
+	}
+	if refreshReq.RefreshToken != "refresh-1" {
+		t.Errorf("Expected the login's refresh token to be sent, got %q", refreshReq.RefreshToken)
+	}
+	if authManager.RefreshToken() != "refresh-2" {
+		t.Errorf("Expected the rotated refresh token to be stored, got %q", authManager.RefreshToken())
+	}
+}
+
+func TestFailedRefreshFallsBackToLogin(t *testing.T) {
+	var paths []string
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/refresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(models.ErrorResponse{StatusCode: 401, Message: "Refresh token expired"})
+			return
+		}
+
+		logins++
+		expiry := time.Now().Add(time.Hour)
+		if logins == 1 {
+			expiry = time.Now().Add(-time.Hour)
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{
+			"token":        createTestJWT(expiry),
+			"refreshToken": "refresh-1",
+		}})
+	}))
+	defer server.Close()
+
+	authManager := NewAuthManager(&Config{
+		BaseURL:    server.URL,
+		Username:   "test",
+		Password:   "pass",
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	})
+	ctx := context.Background()
+
+	if _, err := authManager.GetToken(ctx); err != nil {
+		t.Fatalf("Expected no error on login, got %v", err)
+	}
+	token, err := authManager.GetToken(ctx)
+	if err != nil {
+		t.Fatalf("Expected the fallback login to succeed, got %v", err)
+	}
+	if !authManager.isTokenValid() || token == "" {
+		t.Error("Expected a valid token after the fallback login")
+	}
+
+	expected := []string{"/auth/login", "/auth/refresh", "/auth/login"}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Request %d: expected %s, got %s", i+1, expected[i], paths[i])
+		}
+	}
+}
+
+func TestSetAuthTokenBypassesLogin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			t.Error("Expected no login with an injected token")
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer sidecar-token" {
+			t.Errorf("Expected the injected token, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"data":{}}`))
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+	// Within the expiry buffer, but still usable as there is no way to renew it
+	client.SetAuthToken("sidecar-token", time.Now().Add(2*time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if err := client.GET(context.Background(), "/employees", nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+}
+
+func TestSetAuthTokenAfterExpiry(t *testing.T) {
+	var logins int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			logins++
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer renewed-token" {
+			t.Errorf("Expected the renewed token, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"data":{}}`))
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+	client.SetAuthToken("sidecar-token", time.Now().Add(-time.Second))
+
+	if err := client.GET(context.Background(), "/employees", nil); err == nil {
+		t.Fatal("Expected an error once the injected token expired")
+	}
+	if logins != 0 {
+		t.Errorf("Expected no password login, got %d", logins)
+	}
+
+	var refreshes int
+	client.SetAuthTokenRefresher(func(ctx context.Context) (string, time.Time, error) {
+		refreshes++
+		return "renewed-token", time.Now().Add(time.Hour), nil
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := client.GET(context.Background(), "/employees", nil); err != nil {
+			t.Fatalf("Expected the refresher to renew the token, got %v", err)
+		}
+	}
+	if refreshes != 1 || logins != 0 {
+		t.Errorf("Expected 1 refresh and no logins, got %d and %d", refreshes, logins)
+	}
+}
+
+func TestOnTokenRefreshed(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	var issued []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := createTestJWT(expiry.Add(time.Duration(len(issued)) * time.Second))
+		issued = append(issued, token)
+		json.NewEncoder(w).Encode(models.APIResponse{
+			StatusCode: 200,
+			Data:       map[string]interface{}{"token": token},
+		})
+	}))
+	defer server.Close()
+
+	type refresh struct {
+		token     string
+		expiresAt time.Time
+	}
+	var refreshes []refresh
+
+	config := NewConfig(server.URL, "test", "pass")
+	authManager := NewAuthManager(config)
+	config.OnTokenRefreshed = func(token string, expiresAt time.Time) {
+		refreshes = append(refreshes, refresh{token, expiresAt})
+		// Calling back into the SDK must not deadlock
+		if current, err := authManager.GetToken(context.Background()); err != nil || current != token {
+			t.Errorf("Expected GetToken to return the refreshed token, got %q, %v", current, err)
+		}
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := authManager.GetToken(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if len(refreshes) != 1 || refreshes[0].token != issued[0] || !refreshes[0].expiresAt.Equal(expiry) {
+		t.Fatalf("Expected one callback with the first token, got %+v", refreshes)
+	}
+
+	authManager.ClearToken()
+	if _, err := authManager.GetToken(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(refreshes) != 2 || refreshes[1].token != issued[1] || !refreshes[1].expiresAt.Equal(expiry.Add(time.Second)) {
+		t.Errorf("Expected a second callback with the new token, got %+v", refreshes)
+	}
+}
+
+func TestGetTokenWithAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request, got %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	authManager := NewAuthManager(NewConfigWithAPIKey(server.URL, "integrator-key"))
+	if _, err := authManager.GetToken(context.Background()); err == nil {
+		t.Error("Expected GetToken to be unavailable with API key authentication")
+	}
+}
+
 // Helper function to create test JWT tokens
 func createTestJWT(expiry time.Time) string {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
@@ -2,12 +2,17 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"abhi-go-sdk/errors"
@@ -25,20 +30,50 @@ type Client struct {
 	rateLimiter       *RateLimiter
 	credentialManager *CredentialManager
 	requestSigner     *RequestSigner
+	codec             Codec
+	latencyTracker    *LatencyTracker
+	baseTransport     http.RoundTripper
+	retryConfig       *RetryConfig
 }
 
-// New creates a new Abhi API client
+// NewWithError creates a new Abhi API client after validating config with Config.Validate
+func NewWithError(config *Config) (*Client, error) {
+	if config == nil {
+		return nil, &errors.ValidationError{
+			Field:   "config",
+			Message: "configuration is required",
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return New(config), nil
+}
+
+// New creates a new Abhi API client. A trailing slash on the base URL is trimmed, but the
+// configuration is otherwise not checked; use NewWithError to reject an unusable one up front.
 func New(config *Config) *Client {
 	if config == nil {
 		config = DefaultConfig()
 	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
 
 	client := &Client{
 		config:      config,
 		authManager: NewAuthManager(config),
 		httpClient:  config.HTTPClient,
-		validator:   validator.New(),
+		validator:   newValidator(),
 		rateLimiter: NewRateLimiter(config.RateLimit),
+		codec:       config.Codec,
+	}
+
+	if client.codec == nil {
+		client.codec = JSONCodec{}
+	}
+
+	// Rename aliased response keys if configured
+	if len(config.FieldAliases) > 0 {
+		client.codec = NewAliasCodec(client.codec, config.FieldAliases)
 	}
 
 	// Initialize security features
@@ -53,7 +88,7 @@ func New(config *Config) *Client {
 
 		// Initialize request signer if enabled
 		if config.Security.EnableRequestSigning && config.Security.SigningSecret != "" {
-			client.requestSigner = NewRequestSigner(config.Security.SigningSecret)
+			client.requestSigner = newConfiguredRequestSigner(config.Security)
 		}
 	}
 
@@ -64,112 +99,370 @@ func New(config *Config) *Client {
 			transport = http.DefaultTransport
 		}
 
-		// Wrap with request signing if enabled
-		if client.requestSigner != nil {
-			transport = &signingTransport{
+		// Enforce the TLS policy, refusing to send anything if it's insecure
+		if tlsConfig, err := config.TLSConfig(); err != nil {
+			transport = &errTransport{err: err}
+		} else {
+			transport = applyTLSConfig(transport, tlsConfig)
+		}
+
+		// Record response latencies if enabled
+		if config.LatencySampleSize > 0 {
+			client.latencyTracker = NewLatencyTracker(config.LatencySampleSize)
+			transport = &latencyTransport{
 				transport: transport,
-				signer:    client.requestSigner,
+				tracker:   client.latencyTracker,
+				basePath:  basePath(config.BaseURL),
 			}
 		}
 
-		// Wrap with rate limiting if enabled
-		if client.rateLimiter != nil {
-			transport = &rateLimitTransport{
-				transport:   transport,
-				rateLimiter: client.rateLimiter,
-			}
+		// Bound the number of requests in flight if enabled
+		if config.MaxConcurrentRequests > 0 {
+			transport = newConcurrencyTransport(transport, config.MaxConcurrentRequests)
 		}
+		client.baseTransport = transport
 
-		client.httpClient.Transport = transport
+		// Wrap with logging, signing and rate limiting
+		client.updateTransportChain()
 	}
 
 	return client
 }
 
-// makeRequest performs an HTTP request with authentication
+// makeRequest performs an HTTP request with authentication. API, transport, validation and login
+// failures are returned as *errors.APIError, *errors.NetworkError, *errors.ValidationError and
+// *errors.AuthenticationError respectively, and failures encoding, building, intercepting or
+// decoding the call as *errors.SDKError, so errors.As recovers them through any %w wrapping.
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
-	// Get valid JWT token
+	return c.makeRequestWithHeaders(ctx, method, endpoint, nil, body, result)
+}
+
+// makeRequestWithHeaders performs an HTTP request with authentication and additional headers
+func (c *Client) makeRequestWithHeaders(ctx context.Context, method, endpoint string, headers http.Header, body interface{}, result interface{}) error {
+	// WithTimeout keeps the parent's deadline if it is sooner
+	if c.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.RequestTimeout)
+		defer cancel()
+	}
+
+	path, _, _ := strings.Cut(endpoint, "?")
+	ctx, endSpan := c.startSpan(ctx, method, path)
+
+	start := time.Now()
+	status, err := c.doRequest(ctx, method, endpoint, headers, body, result)
+
+	// A 403 caused by a server-side scope change is fixed by re-authenticating, so retry once on a fresh token
+	if apiErr, ok := err.(*errors.APIError); ok && apiErr.IsTokenScopeExpired() {
+		c.authManager.ClearToken()
+		status, err = c.doRequest(ctx, method, endpoint, headers, body, result)
+	}
+
+	c.config.metrics().ObserveRequest(method, path, status, time.Since(start))
+	endSpan(status, err)
+
+	return err
+}
+
+// MethodOverrideHeader carries the real method of a request tunneled through POST
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
+// overridableMethods are the methods tunneled through POST when Config.MethodOverride is set
+var overridableMethods = map[string]bool{
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// DoRaw performs an authenticated request and returns the response and its raw body without
+// unwrapping the API envelope, so headers such as X-Request-Id can be read. Error statuses are
+// returned as responses rather than errors. The response body has already been read and closed.
+func (c *Client) DoRaw(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, []byte, error) {
+	return c.doRawRequest(ctx, method, endpoint, nil, body)
+}
+
+// authHeader returns the header that authenticates a request: the configured API key, or else
+// a bearer token obtained by logging in
+func (c *Client) authHeader(ctx context.Context) (name, value string, err error) {
+	if c.config.APIKey != "" {
+		return APIKeyHeader, c.config.APIKey, nil
+	}
+
 	token, err := c.authManager.GetToken(ctx)
 	if err != nil {
-		return &errors.AuthenticationError{
+		return "", "", &errors.AuthenticationError{
 			Message: "Failed to obtain authentication token",
 			Err:     err,
 		}
 	}
+	return "Authorization", "Bearer " + token, nil
+}
+
+// doRawRequest performs a single authenticated HTTP request attempt, returning the response and its body
+func (c *Client) doRawRequest(ctx context.Context, method, endpoint string, headers http.Header, body interface{}) (*http.Response, []byte, error) {
+	// Get valid credentials
+	authName, authValue, err := c.authHeader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Prepare request body
 	var reqBody io.Reader
 	if body != nil {
 		// Validate request body if it has validation tags
-		if err := c.validator.Struct(body); err != nil {
-			return &errors.ValidationError{
-				Field:   "request",
-				Message: err.Error(),
+		if isStructBody(body) {
+			if err := c.validator.Struct(body); err != nil {
+				return nil, nil, &errors.ValidationError{
+					Field:   "request",
+					Message: err.Error(),
+				}
 			}
 		}
 
-		jsonBody, err := json.Marshal(body)
+		encodedBody, err := c.codec.Marshal(body)
 		if err != nil {
-			return pkgerrors.Wrap(err, "failed to marshal request body")
+			return nil, nil, &errors.SDKError{
+				Kind:      errors.KindEncoding,
+				Operation: fmt.Sprintf("encode %s %s request body", method, endpoint),
+				Err:       err,
+			}
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+		reqBody = bytes.NewBuffer(encodedBody)
+	}
+
+	// Tunnel methods blocked by proxies through POST if enabled
+	requestMethod := method
+	if c.config.MethodOverride && overridableMethods[method] {
+		requestMethod = http.MethodPost
 	}
 
 	// Create request
-	fullURL := c.config.BaseURL + endpoint
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+	fullURL, err := joinURL(c.config.BaseURL, endpoint)
+	if err != nil {
+		return nil, nil, &errors.SDKError{
+			Kind:      errors.KindRequest,
+			Operation: fmt.Sprintf("build %s %s request URL", method, endpoint),
+			Err:       err,
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, requestMethod, fullURL, reqBody)
 	if err != nil {
-		return pkgerrors.Wrap(err, "failed to create request")
+		return nil, nil, &errors.SDKError{
+			Kind:      errors.KindRequest,
+			Operation: fmt.Sprintf("create %s %s request", method, endpoint),
+			Err:       err,
+		}
 	}
 
-	// Set headers
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	// Set headers, with per-request headers taking precedence over defaults
+	for key, value := range c.config.DefaultHeaders {
+		if _, ok := headers[http.CanonicalHeaderKey(key)]; !ok {
+			req.Header.Set(key, value)
+		}
+	}
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set(authName, authValue)
+	req.Header.Set("Content-Type", c.codec.ContentType())
+	req.Header.Set("Accept", c.codec.ContentType())
+	req.Header.Set("User-Agent", c.config.userAgent())
+	if organizationID != "" && req.Header.Get(OrganizationIDHeader) == "" {
+		req.Header.Set(OrganizationIDHeader, organizationID)
+	}
+	if requestMethod != method {
+		req.Header.Set(MethodOverrideHeader, method)
+	}
+	if c.config.Compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if c.config.TracerProvider != nil {
+		c.config.TracerProvider.Inject(ctx, req.Header)
+	}
+
+	// Run request interceptors
+	for _, interceptor := range c.config.RequestInterceptors {
+		if err := interceptor(req); err != nil {
+			return nil, nil, &errors.SDKError{
+				Kind:      errors.KindInterceptor,
+				Operation: fmt.Sprintf("intercept %s %s request", method, endpoint),
+				Err:       err,
+			}
+		}
+	}
 
 	// Perform request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return &errors.NetworkError{
+		return nil, nil, &errors.NetworkError{
 			Operation: fmt.Sprintf("%s %s", method, endpoint),
 			Err:       err,
 		}
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	// Read response body, decompressing it if the server gzipped it
+	var respReader io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, nil, &errors.NetworkError{
+				Operation: fmt.Sprintf("decompress %s %s response", method, endpoint),
+				Err:       err,
+			}
+		}
+		defer gzipReader.Close()
+		respReader = gzipReader
+	}
+
+	respBody, err := io.ReadAll(respReader)
+	if err != nil {
+		return nil, nil, &errors.NetworkError{
+			Operation: fmt.Sprintf("read %s %s response", method, endpoint),
+			Err:       err,
+		}
+	}
+
+	// Run response interceptors, each seeing the full body
+	for _, interceptor := range c.config.ResponseInterceptors {
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+		if err := interceptor(resp); err != nil {
+			return nil, nil, &errors.SDKError{
+				Kind:      errors.KindInterceptor,
+				Operation: fmt.Sprintf("intercept %s %s response", method, endpoint),
+				Err:       err,
+			}
+		}
+	}
+
+	return resp, respBody, nil
+}
+
+// maxErrorDetailsLength bounds the details kept from an error body that isn't JSON
+const maxErrorDetailsLength = 512
+
+// htmlTitlePattern extracts the title of an HTML error page
+var htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// nonJSONError builds an API error for an error response whose body isn't JSON, such as an HTML
+// page from a gateway. The details are summarized rather than holding the whole page.
+func nonJSONError(resp *http.Response, body []byte, endpoint string) *errors.APIError {
+	contentType := resp.Header.Get("Content-Type")
+	message := "Non-JSON error response from gateway"
+	if contentType != "" {
+		message += " (Content-Type: " + contentType + ")"
+	}
+
+	apiErr := errors.NewAPIError(resp.StatusCode, message, summarizeErrorBody(body), endpoint)
+	apiErr.ContentType = contentType
+	return apiErr
+}
+
+// setRetryAfter records the delay requested by the server's Retry-After header on a rate-limited
+// or unavailable response, so callers can back off themselves
+func setRetryAfter(apiErr *errors.APIError, resp *http.Response) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return
+	}
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		apiErr.RetryAfter = retryAfter
+	}
+}
+
+// summarizeErrorBody reduces an error body to a short single line, preferring an HTML page's title
+func summarizeErrorBody(body []byte) string {
+	text := string(body)
+	if match := htmlTitlePattern.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	}
+	text = strings.Join(strings.Fields(text), " ")
+
+	if runes := []rune(text); len(runes) > maxErrorDetailsLength {
+		text = fmt.Sprintf("%s... (%d bytes)", string(runes[:maxErrorDetailsLength]), len(body))
+	}
+	return text
+}
+
+// doRequest performs a single authenticated HTTP request attempt, unwrapping the API envelope into
+// result. It returns the HTTP status of the response, or 0 if none was received.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, headers http.Header, body interface{}, result interface{}) (int, error) {
+	resp, respBody, err := c.doRawRequest(ctx, method, endpoint, headers, body)
 	if err != nil {
-		return pkgerrors.Wrap(err, "failed to read response body")
+		return 0, err
 	}
 
 	// Handle error responses
 	if resp.StatusCode >= 400 {
 		var errorResp models.ErrorResponse
-		if err := json.Unmarshal(respBody, &errorResp); err == nil {
-			return errors.NewAPIError(errorResp.StatusCode, errorResp.Message, errorResp.Details, endpoint)
+		if err := c.codec.Unmarshal(respBody, &errorResp); err == nil {
+			statusCode := errorResp.StatusCode
+			if statusCode == 0 {
+				statusCode = resp.StatusCode
+			}
+			apiErr := errors.NewAPIError(statusCode, errorResp.Message, errorResp.Details, endpoint)
+			apiErr.Data = errorResp.Data
+			apiErr.ValidationErrors = errorResp.ValidationErrors
+			// A numeric code only repeats the status, so a symbolic error string identifies the error better
+			apiErr.Code = string(errorResp.Code)
+			if apiErr.Code == "" || (errorResp.Code.IsNumeric() && errorResp.Error != "") {
+				apiErr.Code = errorResp.Error
+			}
+			setRetryAfter(apiErr, resp)
+			return resp.StatusCode, apiErr
 		}
-		return errors.NewAPIError(resp.StatusCode, "Unknown error", string(respBody), endpoint)
+		apiErr := nonJSONError(resp, respBody, endpoint)
+		setRetryAfter(apiErr, resp)
+		return resp.StatusCode, apiErr
 	}
 
 	// Parse successful response
 	if result != nil {
 		var apiResp models.APIResponse
-		if err := json.Unmarshal(respBody, &apiResp); err != nil {
-			return pkgerrors.Wrap(err, "failed to parse API response")
+		if err := c.codec.Unmarshal(respBody, &apiResp); err != nil {
+			return resp.StatusCode, &errors.SDKError{
+				Kind:      errors.KindDecoding,
+				Operation: fmt.Sprintf("parse %s %s response", method, endpoint),
+				Err:       err,
+			}
 		}
 
 		// Marshal and unmarshal data to convert to target type
-		dataJSON, err := json.Marshal(apiResp.Data)
+		data, err := c.codec.Marshal(apiResp.Data)
 		if err != nil {
-			return pkgerrors.Wrap(err, "failed to marshal response data")
+			return resp.StatusCode, &errors.SDKError{
+				Kind:      errors.KindDecoding,
+				Operation: fmt.Sprintf("re-encode %s %s response data", method, endpoint),
+				Err:       err,
+			}
 		}
 
-		if err := json.Unmarshal(dataJSON, result); err != nil {
-			return pkgerrors.Wrap(err, "failed to unmarshal response data")
+		if err := c.codec.Unmarshal(data, result); err != nil {
+			return resp.StatusCode, &errors.SDKError{
+				Kind:      errors.KindDecoding,
+				Operation: fmt.Sprintf("decode %s %s response data", method, endpoint),
+				Err:       err,
+			}
 		}
 	}
 
+	return resp.StatusCode, nil
+}
+
+// ValidateStruct checks v against its validation tags without sending a request
+func (c *Client) ValidateStruct(v interface{}) error {
+	if err := c.validator.Struct(v); err != nil {
+		return &errors.ValidationError{
+			Field:   "request",
+			Message: err.Error(),
+		}
+	}
 	return nil
 }
 
@@ -196,28 +489,94 @@ func (c *Client) POST(ctx context.Context, endpoint string, body interface{}, re
 	return c.makeRequest(ctx, "POST", endpoint, body, result)
 }
 
+// POSTIdempotent performs a POST request carrying an Idempotency-Key header so the
+// server can safely de-duplicate repeated submissions
+func (c *Client) POSTIdempotent(ctx context.Context, endpoint string, body interface{}, result interface{}, key string) error {
+	headers := http.Header{}
+	if key != "" {
+		headers.Set("Idempotency-Key", key)
+	}
+	return c.makeRequestWithHeaders(ctx, "POST", endpoint, headers, body, result)
+}
+
 // PUT performs a PUT request
 func (c *Client) PUT(ctx context.Context, endpoint string, body interface{}, result interface{}) error {
 	return c.makeRequest(ctx, "PUT", endpoint, body, result)
 }
 
+// PATCH performs a PATCH request
+func (c *Client) PATCH(ctx context.Context, endpoint string, body interface{}, result interface{}) error {
+	return c.makeRequest(ctx, "PATCH", endpoint, body, result)
+}
+
 // DELETE performs a DELETE request
 func (c *Client) DELETE(ctx context.Context, endpoint string, result interface{}) error {
 	return c.makeRequest(ctx, "DELETE", endpoint, nil, result)
 }
 
+// Currency returns the configured currency, or nil if amounts are sent unrounded
+func (c *Client) Currency() *models.Currency {
+	return c.config.Currency
+}
+
 // SetRetryPolicy sets a retry policy for the HTTP client
 func (c *Client) SetRetryPolicy(maxRetries int, retryDelay time.Duration) {
-	originalTransport := c.httpClient.Transport
-	if originalTransport == nil {
-		originalTransport = http.DefaultTransport
-	}
+	c.SetRetryConfig(RetryConfig{
+		MaxRetries: maxRetries,
+		RetryDelay: retryDelay,
+	})
+}
+
+// SetRetryPolicyWithJitter sets a retry policy whose backoff is randomized uniformly between
+// zero and retryDelay * 2^attempt, so that many clients don't retry in lockstep
+func (c *Client) SetRetryPolicyWithJitter(maxRetries int, retryDelay time.Duration) {
+	c.SetRetryConfig(RetryConfig{
+		MaxRetries: maxRetries,
+		RetryDelay: retryDelay,
+		Jitter:     true,
+	})
+}
 
-	c.httpClient.Transport = &retryTransport{
-		transport:  originalTransport,
-		maxRetries: maxRetries,
-		retryDelay: retryDelay,
+// SetRetryConfig wraps the HTTP client's transport with retries following the given configuration
+func (c *Client) SetRetryConfig(config RetryConfig) {
+	c.retryConfig = &config
+	c.updateTransportChain()
+}
+
+// newRetryTransport wraps transport with retries following the given configuration
+func newRetryTransport(transport http.RoundTripper, config RetryConfig) *retryTransport {
+	rt := &retryTransport{
+		transport:  transport,
+		maxRetries: config.MaxRetries,
+		retryDelay: config.RetryDelay,
+		jitter:     config.Jitter,
+
+		perAttemptTimeout: config.PerAttemptTimeout,
+		maxRetryAfter:     config.MaxRetryAfter,
+	}
+	if rt.transport == nil {
+		rt.transport = http.DefaultTransport
+	}
+	if config.Jitter {
+		rt.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if len(config.RetryableStatusCodes) > 0 {
+		rt.retryableStatusCodes = make(map[int]bool, len(config.RetryableStatusCodes))
+		for _, code := range config.RetryableStatusCodes {
+			rt.retryableStatusCodes[code] = true
+		}
 	}
+	if len(config.Methods) > 0 {
+		rt.methods = make(map[string]bool, len(DefaultRetryMethods)+len(config.Methods))
+		for method, retry := range DefaultRetryMethods {
+			rt.methods[method] = retry
+		}
+		for method, retry := range config.Methods {
+			rt.methods[strings.ToUpper(method)] = retry
+		}
+	}
+
+	return rt
 }
 
 // retryTransport implements automatic retry logic
@@ -225,24 +584,96 @@ type retryTransport struct {
 	transport  http.RoundTripper
 	maxRetries int
 	retryDelay time.Duration
+	jitter     bool       // Randomize each backoff within [0, retryDelay * 2^attempt]
+	rng        *rand.Rand // Source of jitter, guarded by rngMutex
+	rngMutex   sync.Mutex
+
+	retryableStatusCodes map[int]bool    // Status codes retried in addition to 5xx
+	perAttemptTimeout    time.Duration   // Deadline for each attempt, bounded by the request's own deadline
+	methods              map[string]bool // Methods allowed to retry; nil means DefaultRetryMethods
+	maxRetryAfter        time.Duration   // Cap on a server-requested delay; zero means DefaultMaxRetryAfter
+}
+
+// shouldRetryStatus returns true if a response with the given status code should be retried
+func (rt *retryTransport) shouldRetryStatus(statusCode int) bool {
+	return statusCode >= 500 || rt.retryableStatusCodes[statusCode]
+}
+
+// shouldRetryMethod returns true if requests with the given method may be retried
+func (rt *retryTransport) shouldRetryMethod(method string) bool {
+	if rt.methods == nil {
+		return DefaultRetryMethods[method]
+	}
+	return rt.methods[method]
+}
+
+// backoff returns the delay before the retry following the given attempt
+func (rt *retryTransport) backoff(attempt int) time.Duration {
+	delay := rt.retryDelay * time.Duration(1<<uint(attempt))
+	if !rt.jitter || delay <= 0 {
+		return delay
+	}
+
+	rt.rngMutex.Lock()
+	defer rt.rngMutex.Unlock()
+
+	if rt.rng == nil {
+		rt.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return time.Duration(rt.rng.Int63n(int64(delay) + 1))
+}
+
+// retryAfterCap returns the longest Retry-After delay to honor
+func (rt *retryTransport) retryAfterCap() time.Duration {
+	if rt.maxRetryAfter > 0 {
+		return rt.maxRetryAfter
+	}
+	return DefaultMaxRetryAfter
 }
 
 func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Tunneled requests are judged by the method the server will act on
+	if !rt.shouldRetryMethod(effectiveMethod(req)) {
+		return rt.roundTripAttempt(req)
+	}
+
 	var resp *http.Response
 	var err error
 
+	// Buffer the body once so every attempt can resend it in full
+	var bodyBytes []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		bodyBytes, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to buffer request body: %w", err)
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+		}
+	}
+
 	for i := 0; i <= rt.maxRetries; i++ {
-		// Clone request body for retries
-		var bodyBytes []byte
-		if req.Body != nil {
-			bodyBytes, _ = io.ReadAll(req.Body)
-			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		if bodyBytes != nil {
+			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		}
+
+		resp, err = rt.roundTripAttempt(req)
+
+		// Retrying won't free up the rate limiter any sooner
+		if pkgerrors.Is(err, errors.ErrRateLimitWaitExceeded) {
+			return nil, err
 		}
 
-		resp, err = rt.transport.RoundTrip(req)
+		// Honor the server's Retry-After on rate limiting and unavailability
+		var retryAfter time.Duration
+		var hasRetryAfter bool
+		if err == nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+			retryAfter, hasRetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
 
-		// Don't retry on success or client errors (4xx)
-		if err == nil && resp.StatusCode < 500 {
+		// Don't retry on success or client errors (4xx), including 429, unless opted in
+		if err == nil && !rt.shouldRetryStatus(resp.StatusCode) {
 			return resp, nil
 		}
 
@@ -251,25 +682,111 @@ func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			break
 		}
 
-		// Reset request body for retry
-		if bodyBytes != nil {
-			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		if resp != nil {
+			resp.Body.Close()
 		}
 
-		// Wait before retry with exponential backoff
-		time.Sleep(rt.retryDelay * time.Duration(1<<uint(i)))
+		// Wait before retry as requested by the server, within reason, or with exponential backoff
+		delay := rt.backoff(i)
+		if hasRetryAfter {
+			delay = retryAfter
+			if maxDelay := rt.retryAfterCap(); delay > maxDelay {
+				delay = maxDelay
+			}
+		}
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
 	}
 
 	return resp, err
 }
 
+// roundTripAttempt performs a single attempt, abandoning it if the response headers don't arrive
+// within the per-attempt timeout. The timeout stops once they do, so long-lived bodies such as
+// event streams aren't cut off. As the attempt's context derives from the request's, it never
+// outlives the overall deadline.
+func (rt *retryTransport) roundTripAttempt(req *http.Request) (*http.Response, error) {
+	if rt.perAttemptTimeout <= 0 {
+		return rt.transport.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(rt.perAttemptTimeout, cancel)
+	resp, err := rt.transport.RoundTrip(req.WithContext(ctx))
+	timedOut := !timer.Stop()
+	if err != nil {
+		cancel()
+		if timedOut && req.Context().Err() == nil {
+			return nil, fmt.Errorf("attempt timed out after %s: %w", rt.perAttemptTimeout, context.DeadlineExceeded)
+		}
+		return nil, err
+	}
+
+	// Keep the attempt's context alive until the body has been read
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases a per-attempt context when the response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// sleepContext waits for the given duration, returning early with the context's error if it's done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter parses a Retry-After header in either delta-seconds or HTTP-date form
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
 // SetRateLimit configures rate limiting for the HTTP client
 func (c *Client) SetRateLimit(requestsPerSecond float64, burstSize int) {
-	rateLimitConfig := &RateLimitConfig{
-		RequestsPerSecond: requestsPerSecond,
-		BurstSize:         burstSize,
-		Enabled:           true,
+	rateLimitConfig := &RateLimitConfig{}
+	if c.config.RateLimit != nil {
+		*rateLimitConfig = *c.config.RateLimit // Keep other settings such as MaxWait
 	}
+	rateLimitConfig.RequestsPerSecond = requestsPerSecond
+	rateLimitConfig.BurstSize = burstSize
+	rateLimitConfig.Enabled = true
 	
 	c.rateLimiter = NewRateLimiter(rateLimitConfig)
 	c.config.RateLimit = rateLimitConfig
@@ -342,7 +859,11 @@ func (c *Client) GetRateLimiterStatus() map[string]interface{} {
 		status["burstSize"] = c.config.RateLimit.BurstSize
 
 		if c.rateLimiter != nil {
+			stats := c.rateLimiter.Stats()
 			status["availableTokens"] = c.rateLimiter.GetAvailableTokens()
+			status["totalAllowed"] = stats.TotalAllowed
+			status["totalWaited"] = stats.TotalWaited
+			status["totalTimedOut"] = stats.TotalTimedOut
 		}
 	}
 
@@ -364,21 +885,28 @@ func (c *Client) EnableCredentialEncryption(encryptionPassword string) {
 	)
 }
 
-// EnableRequestSigning enables request signing for the client
-func (c *Client) EnableRequestSigning(signingSecret string) {
+// EnableRequestSigning enables request signing for the client. The signature covers
+// signedHeaders in order, or DefaultSignedHeaders if none are given.
+func (c *Client) EnableRequestSigning(signingSecret string, signedHeaders ...string) {
 	if c.config.Security == nil {
 		c.config.Security = &SecurityConfig{}
 	}
 	
 	c.config.Security.EnableRequestSigning = true
 	c.config.Security.SigningSecret = signingSecret
+	c.config.Security.SignedHeaders = signedHeaders
 	
-	c.requestSigner = NewRequestSigner(signingSecret)
+	c.requestSigner = newConfiguredRequestSigner(c.config.Security)
 	
 	// Update transport chain
 	c.updateTransportChain()
 }
 
+// newConfiguredRequestSigner creates a request signer with the security config's secret and options
+func newConfiguredRequestSigner(security *SecurityConfig) *RequestSigner {
+	return NewRequestSigner(security.SigningSecret, security.SignedHeaders...).SetTimestampWindow(security.SignatureWindow)
+}
+
 // DisableRequestSigning disables request signing
 func (c *Client) DisableRequestSigning() {
 	if c.config.Security != nil {
@@ -392,7 +920,15 @@ func (c *Client) DisableRequestSigning() {
 
 // updateTransportChain rebuilds the HTTP transport chain with current settings
 func (c *Client) updateTransportChain() {
-	transport := http.DefaultTransport
+	transport := c.baseTransport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// Log the request as sent, after signing, if enabled
+	if c.config.Logger != nil {
+		transport = NewLoggingTransport(transport, c.config.Logger)
+	}
 	
 	// Wrap with request signing if enabled
 	if c.requestSigner != nil {
@@ -409,10 +945,107 @@ func (c *Client) updateTransportChain() {
 			rateLimiter: c.rateLimiter,
 		}
 	}
+
+	// Retry outermost so each attempt is rate limited, signed and logged
+	if c.retryConfig != nil {
+		transport = newRetryTransport(transport, *c.retryConfig)
+	}
 	
 	c.httpClient.Transport = transport
 }
 
+// SetLogger logs requests and responses to logger with credentials masked, or disables logging if nil
+func (c *Client) SetLogger(logger Logger) {
+	c.config.Logger = logger
+	c.updateTransportChain()
+}
+
+// SetUserAgent sets the User-Agent sent on every request, including login
+func (c *Client) SetUserAgent(userAgent string) {
+	c.config.UserAgent = userAgent
+}
+
+// SetEventSink sets the sink that receives lifecycle events after successful operations
+func (c *Client) SetEventSink(sink EventSink) {
+	c.config.EventSink = sink
+}
+
+// SetAuthToken makes the client authenticate with a token minted outside the SDK, e.g. by a
+// sidecar, instead of logging in with the configured username and password. Requests fail once
+// the token expires unless SetAuthTokenRefresher supplies a way to renew it.
+func (c *Client) SetAuthToken(token string, expiresAt time.Time) {
+	c.authManager.SetToken(token, expiresAt)
+}
+
+// SetAuthTokenRefresher sets the callback used to renew a token supplied by SetAuthToken
+func (c *Client) SetAuthTokenRefresher(refresher TokenRefresher) {
+	c.authManager.SetTokenRefresher(refresher)
+}
+
+// SetTokenExpiryBuffer sets how long before expiry the auth token is refreshed
+func (c *Client) SetTokenExpiryBuffer(buffer time.Duration) {
+	c.config.TokenExpiryBuffer = buffer
+}
+
+// CurrentUser returns the user returned at login without a separate /auth/me call, or nil if
+// not logged in yet or the login response didn't include the user
+func (c *Client) CurrentUser() *models.AuthUser {
+	return c.authManager.CurrentUser()
+}
+
+// LatencyStats returns latency percentiles per endpoint group, or nil if latency tracking is disabled
+func (c *Client) LatencyStats() map[string]LatencyStat {
+	if c.latencyTracker == nil {
+		return nil
+	}
+	return c.latencyTracker.Stats()
+}
+
+// ResetLatencyStats discards all recorded latencies
+func (c *Client) ResetLatencyStats() {
+	if c.latencyTracker != nil {
+		c.latencyTracker.Reset()
+	}
+}
+
+// joinURL appends endpoint, which may carry a query string, to the base URL. Exactly one slash
+// separates the base path from the endpoint path, so the base path is preserved whether or not
+// either side has a slash at the join. Path escaping in both is kept as written.
+func joinURL(baseURL, endpoint string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	joined := *base
+	joined.Path = strings.TrimRight(base.Path, "/") + "/" + strings.TrimLeft(ref.Path, "/")
+	if base.RawPath != "" || ref.RawPath != "" {
+		joined.RawPath = strings.TrimRight(base.EscapedPath(), "/") + "/" + strings.TrimLeft(ref.EscapedPath(), "/")
+	}
+
+	switch {
+	case base.RawQuery == "":
+		joined.RawQuery = ref.RawQuery
+	case ref.RawQuery != "":
+		joined.RawQuery = base.RawQuery + "&" + ref.RawQuery
+	}
+
+	return joined.String(), nil
+}
+
+// basePath returns the path component of the base URL
+func basePath(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
 // StoreSecureCredentials encrypts and stores credentials if encryption is enabled
 func (c *Client) StoreSecureCredentials(key, username, password string) error {
 	if c.credentialManager == nil {
@@ -2,10 +2,18 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	stderrors "errors"
+	"io"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -51,6 +59,59 @@ func TestNewWithNilConfig(t *testing.T) {
 	}
 }
 
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		field  string
+	}{
+		{"empty base URL", NewConfig("", "user", "pass"), "baseURL"},
+		{"relative base URL", NewConfig("api.abhi.ae/open-api", "user", "pass"), "baseURL"},
+		{"unsupported scheme", NewConfig("ftp://api.abhi.ae", "user", "pass"), "baseURL"},
+		{"missing credentials", NewConfig("https://api.abhi.ae", "", ""), "credentials"},
+		{"missing password", NewConfig("https://api.abhi.ae", "user", ""), "credentials"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			var validationErr *errors.ValidationError
+			if !stderrors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("Expected a %s ValidationError, got %v", tt.field, err)
+			}
+
+			if _, err := NewWithError(tt.config); !stderrors.As(err, &validationErr) {
+				t.Errorf("Expected NewWithError to reject the config, got %v", err)
+			}
+		})
+	}
+
+	if err := NewConfigWithAPIKey("https://api.abhi.ae", "key").Validate(); err != nil {
+		t.Errorf("Expected an API key to satisfy the credentials check, got %v", err)
+	}
+}
+
+func TestConfigValidateTrimsTrailingSlash(t *testing.T) {
+	config := NewConfig("https://api-uat-v2.abhi.ae/uat-open-api/", "user", "pass")
+	client, err := NewWithError(config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.BaseURL != "https://api-uat-v2.abhi.ae/uat-open-api" {
+		t.Errorf("Expected the trailing slash to be trimmed, got %q", config.BaseURL)
+	}
+	if client == nil {
+		t.Fatal("Expected a client")
+	}
+
+	// New trims the slash too, without rejecting anything
+	config = &Config{BaseURL: "https://api.abhi.ae/"}
+	New(config)
+	if config.BaseURL != "https://api.abhi.ae" {
+		t.Errorf("Expected New to trim the trailing slash, got %q", config.BaseURL)
+	}
+}
+
 func TestMakeRequestSuccess(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -137,6 +198,87 @@ func TestMakeRequestValidationError(t *testing.T) {
 	}
 }
 
+func TestMakeRequestHTMLErrorBody(t *testing.T) {
+	page := "<html><head><title>502 Bad Gateway</title></head><body>" +
+		strings.Repeat("<p>upstream connect error or disconnect/reset before headers</p>\n", 200) +
+		"</body></html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+	err := client.GET(context.Background(), "/employees", nil)
+
+	apiErr, ok := err.(*errors.APIError)
+	if !ok {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected status 502, got %d", apiErr.StatusCode)
+	}
+	if apiErr.ContentType != "text/html; charset=utf-8" || !strings.Contains(apiErr.Message, "text/html") {
+		t.Errorf("Expected the content type in the error, got %q / %q", apiErr.ContentType, apiErr.Message)
+	}
+	if !strings.Contains(apiErr.Message, "Non-JSON error response from gateway") {
+		t.Errorf("Expected a gateway error message, got %q", apiErr.Message)
+	}
+	if apiErr.Details != "502 Bad Gateway" {
+		t.Errorf("Expected the page title as details, got %q", apiErr.Details)
+	}
+}
+
+func TestSummarizeErrorBodyTruncates(t *testing.T) {
+	body := []byte(strings.Repeat("gateway timeout ", 100))
+	summary := summarizeErrorBody(body)
+	if len(summary) > maxErrorDetailsLength+32 {
+		t.Errorf("Expected the summary to be truncated, got %d characters", len(summary))
+	}
+	if !strings.HasSuffix(summary, "... (1600 bytes)") {
+		t.Errorf("Expected the summary to note the original size, got %q", summary)
+	}
+}
+
+func TestEmiratesIDValidationTag(t *testing.T) {
+	validate := newValidator()
+
+	valid := models.EmployeeLoginRequest{Username: "ali", Password: "secret", EmiratesID: "784-1990-1234567-6"}
+	if err := validate.Struct(valid); err != nil {
+		t.Errorf("Expected a valid Emirates ID to pass, got %v", err)
+	}
+
+	for _, id := range []string{"784-1990-1234567-1", "784-1990-123456-6", "784199012345676"} {
+		invalid := valid
+		invalid.EmiratesID = id
+		if err := validate.Struct(invalid); err == nil || !strings.Contains(err.Error(), "'emiratesid' tag") {
+			t.Errorf("%q: expected the emiratesid tag to fail, got %v", id, err)
+		}
+	}
+}
+
+func TestUAEPhoneValidationTag(t *testing.T) {
+	validate := newValidator()
+
+	for _, phone := range []string{"", "+971501234567", "0501234567"} {
+		if err := validate.Struct(models.MFASetupRequest{Method: "sms", Phone: phone}); err != nil {
+			t.Errorf("%q: expected no error, got %v", phone, err)
+		}
+	}
+	for _, phone := range []string{"+9715012345", "+971401234567", "phone"} {
+		err := validate.Struct(models.CreateOrganizationRequest{Phone: phone})
+		if err == nil || !strings.Contains(err.Error(), "'uaephone' tag") {
+			t.Errorf("%q: expected the uaephone tag to fail, got %v", phone, err)
+		}
+	}
+}
+
 func TestMakeRequestAPIError(t *testing.T) {
 	// Create a test server that returns error
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -187,6 +329,185 @@ func TestMakeRequestAPIError(t *testing.T) {
 	}
 }
 
+func TestMakeRequestDocumentedErrorBody(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode string
+	}{
+		{"numeric code", `{"data":{},"message":"Insufficient balance for this advance","status":"Bad Request","code":400}`, "400"},
+		{"numeric code with error", `{"data":{},"message":"Insufficient balance","status":"Bad Request","code":400,"error":"INSUFFICIENT_BALANCE"}`, "INSUFFICIENT_BALANCE"},
+		{"string code", `{"message":"Insufficient balance","code":"INSUFFICIENT_BALANCE"}`, "INSUFFICIENT_BALANCE"},
+		{"null code", `{"message":"Insufficient balance","code":null,"error":"INSUFFICIENT_BALANCE"}`, "INSUFFICIENT_BALANCE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			config := NewConfig(server.URL, "", "")
+			config.APIKey = "test-key"
+			client := New(config)
+
+			err := client.makeRequest(context.Background(), "POST", "/transactions/employee", nil, nil)
+			apiErr, ok := err.(*errors.APIError)
+			if !ok {
+				t.Fatalf("Expected APIError, got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != 400 {
+				t.Errorf("Expected status code 400, got %d", apiErr.StatusCode)
+			}
+			if !strings.HasPrefix(apiErr.Message, "Insufficient balance") {
+				t.Errorf("Expected the API message to be kept, got %q", apiErr.Message)
+			}
+			if apiErr.Code != tt.expectedCode {
+				t.Errorf("Expected code %q, got %q", tt.expectedCode, apiErr.Code)
+			}
+			if !apiErr.IsInsufficientBalance() {
+				t.Error("Expected the error to be recognized as insufficient balance")
+			}
+		})
+	}
+}
+
+func TestMakeRequestErrorBodyWithNonObjectData(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expectedValue interface{}
+	}{
+		{"string data", `{"message":"Employee not eligible","code":400,"data":"probation period"}`, "probation period"},
+		{"array data", `{"message":"Employee not eligible","code":400,"data":["probation period"]}`, []interface{}{"probation period"}},
+		{"number data", `{"message":"Employee not eligible","code":400,"data":3}`, float64(3)},
+		{"empty string data", `{"message":"Employee not eligible","code":400,"data":""}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			config := NewConfig(server.URL, "", "")
+			config.APIKey = "test-key"
+			client := New(config)
+
+			err := client.makeRequest(context.Background(), "POST", "/transactions/employee", nil, nil)
+			apiErr, ok := err.(*errors.APIError)
+			if !ok {
+				t.Fatalf("Expected APIError, got %T: %v", err, err)
+			}
+			if apiErr.Message != "Employee not eligible" {
+				t.Errorf("Expected the API message to be kept, got %q", apiErr.Message)
+			}
+			if !reflect.DeepEqual(apiErr.Data["value"], tt.expectedValue) {
+				t.Errorf("Expected data value %#v, got %#v", tt.expectedValue, apiErr.Data["value"])
+			}
+		})
+	}
+}
+
+func TestMakeRequestRateLimitedRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(models.ErrorResponse{StatusCode: 429, Message: "Too Many Requests"})
+	}))
+	defer server.Close()
+
+	// No retries, so the 429 is returned rather than waited out
+	config := &Config{
+		BaseURL:    server.URL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Timeout:    30 * time.Second,
+	}
+	client := New(config)
+	client.authManager = &AuthManager{
+		config:     config,
+		token:      "test-token",
+		expiresAt:  time.Now().Add(time.Hour),
+		httpClient: config.HTTPClient,
+	}
+
+	err := client.makeRequest(context.Background(), "GET", "/test", nil, nil)
+
+	apiErr, ok := err.(*errors.APIError)
+	if !ok {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	if !apiErr.IsRateLimited() {
+		t.Errorf("Expected a rate-limit error, got status %d", apiErr.StatusCode)
+	}
+	if apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("Expected RetryAfter of 30s, got %v", apiErr.RetryAfter)
+	}
+}
+
+func TestMakeRequestFieldValidationErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"statusCode": 400,
+			"message": "Validation failed",
+			"validationErrors": [
+				{"field": "email", "message": "must be a valid email", "value": "not-an-email"},
+				{"field": "netSalary", "message": "must be positive", "value": -500},
+				{"field": "bankId", "message": "is required"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		BaseURL:    server.URL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Timeout:    30 * time.Second,
+	}
+	client := New(config)
+	client.authManager = &AuthManager{
+		config:     config,
+		token:      "test-token",
+		expiresAt:  time.Now().Add(time.Hour),
+		httpClient: config.HTTPClient,
+	}
+
+	err := client.makeRequest(context.Background(), "POST", "/employees", nil, nil)
+
+	apiErr, ok := err.(*errors.APIError)
+	if !ok {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+
+	expected := []errors.ValidationError{
+		{Field: "email", Message: "must be a valid email", Value: "not-an-email"},
+		{Field: "netSalary", Message: "must be positive", Value: "-500"},
+		{Field: "bankId", Message: "is required"},
+	}
+	if len(apiErr.ValidationErrors) != len(expected) {
+		t.Fatalf("Expected %d field errors, got %+v", len(expected), apiErr.ValidationErrors)
+	}
+	for i, want := range expected {
+		if apiErr.ValidationErrors[i] != want {
+			t.Errorf("Field error %d: expected %+v, got %+v", i, want, apiErr.ValidationErrors[i])
+		}
+	}
+
+	fields := apiErr.FieldErrors()
+	if len(fields) != 3 || fields["netSalary"][0] != "must be positive" {
+		t.Errorf("Unexpected field errors: %v", fields)
+	}
+}
+
 func TestHTTPMethods(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := models.APIResponse{
@@ -389,3 +710,987 @@ func TestRetryTransportWithBodyRetries(t *testing.T) {
 	}
 }
 
+func TestRetryTransportResendsLargeBody(t *testing.T) {
+	payload := bytes.Repeat([]byte("abcdefghij"), 100*1024+1) // Just over 1MB
+	attempts := 0
+	var lastBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		lastBody, _ = io.ReadAll(r.Body)
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := &retryTransport{
+		transport:  http.DefaultTransport,
+		maxRetries: 3,
+		retryDelay: time.Millisecond,
+	}
+
+	// A streaming body without GetBody can only be read once
+	req, _ := http.NewRequest("POST", server.URL, io.NopCloser(bytes.NewReader(payload)))
+	req.ContentLength = int64(len(payload))
+	resp, err := transport.RoundTrip(req)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if !bytes.Equal(lastBody, payload) {
+		t.Errorf("Expected the full %d byte body on the final attempt, got %d bytes", len(payload), len(lastBody))
+	}
+	if req.GetBody == nil {
+		t.Error("Expected GetBody to be set")
+	}
+}
+
+func TestRetryTransportHonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := &retryTransport{
+		transport:  http.DefaultTransport,
+		maxRetries: 3,
+		retryDelay: 10 * time.Millisecond,
+	}
+
+	start := time.Now()
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+	if elapsed < 1900*time.Millisecond {
+		t.Errorf("Expected retry to wait ~2s as requested by Retry-After, waited %v", elapsed)
+	}
+}
+
+func TestRetryTransportRetriesRateLimitedOnlyWhenOptedIn(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 3, RetryDelay: 10 * time.Millisecond})
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || attempts != 1 {
+		t.Errorf("Expected the 429 to be returned without a retry by default, got status %d after %d attempts", resp.StatusCode, attempts)
+	}
+
+	attempts = 0
+	transport = newRetryTransport(http.DefaultTransport, RetryConfig{
+		MaxRetries:           3,
+		RetryDelay:           10 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests},
+	})
+
+	req, _ = http.NewRequest("GET", server.URL, nil)
+	resp, err = transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("Expected a successful retry after an opted-in 429, got status %d after %d attempts", resp.StatusCode, attempts)
+	}
+}
+
+func TestRetryTransportClampsRetryAfter(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, RetryConfig{
+		MaxRetries:    3,
+		RetryDelay:    10 * time.Millisecond,
+		MaxRetryAfter: 50 * time.Millisecond,
+	})
+
+	// Fail rather than wait out the hour if the delay isn't clamped
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("Expected a successful retry, got status %d after %d attempts", resp.StatusCode, attempts)
+	}
+	if elapsed < 50*time.Millisecond {
+		t.Errorf("Expected the retry to wait the clamped delay, waited %v", elapsed)
+	}
+
+	if maxDelay := newRetryTransport(nil, RetryConfig{}).retryAfterCap(); maxDelay != DefaultMaxRetryAfter {
+		t.Errorf("Expected the default cap of %v, got %v", DefaultMaxRetryAfter, maxDelay)
+	}
+}
+
+func TestRetryTransportBackoffRespectsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	transport := &retryTransport{
+		transport:  http.DefaultTransport,
+		maxRetries: 3,
+		retryDelay: 2 * time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context deadline exceeded, got %v", err)
+	}
+	if resp != nil {
+		t.Errorf("Expected no response, got status %d", resp.StatusCode)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected RoundTrip to return promptly after the deadline, took %v", elapsed)
+	}
+}
+
+func TestRetryTransportJitteredBackoff(t *testing.T) {
+	newTransport := func() *retryTransport {
+		return &retryTransport{
+			retryDelay: 100 * time.Millisecond,
+			jitter:     true,
+			rng:        rand.New(rand.NewSource(42)),
+		}
+	}
+
+	transport := newTransport()
+	replay := newTransport()
+
+	distinct := make(map[time.Duration]bool)
+	for attempt := 0; attempt < 4; attempt++ {
+		ceiling := 100 * time.Millisecond * time.Duration(1<<uint(attempt))
+		for i := 0; i < 50; i++ {
+			delay := transport.backoff(attempt)
+			if delay < 0 || delay > ceiling {
+				t.Errorf("Attempt %d: delay %v outside [0, %v]", attempt, delay, ceiling)
+			}
+			if again := replay.backoff(attempt); again != delay {
+				t.Errorf("Expected the same seed to produce the same delays, got %v and %v", delay, again)
+			}
+			distinct[delay] = true
+		}
+	}
+
+	if len(distinct) < 100 {
+		t.Errorf("Expected jittered delays to vary, got %d distinct values", len(distinct))
+	}
+}
+
+func TestRetryTransportBackoffWithoutJitter(t *testing.T) {
+	transport := &retryTransport{retryDelay: 100 * time.Millisecond}
+
+	for attempt, expected := range []time.Duration{100, 200, 400, 800} {
+		if delay := transport.backoff(attempt); delay != expected*time.Millisecond {
+			t.Errorf("Attempt %d: expected %v, got %v", attempt, expected*time.Millisecond, delay)
+		}
+	}
+}
+
+func TestRetryTransportRetryableStatusCodes(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		expectedAttempts int
+		expectedStatus   int
+	}{
+		{"408 retried", http.StatusRequestTimeout, 2, http.StatusOK},
+		{"429 retried", http.StatusTooManyRequests, 2, http.StatusOK},
+		{"400 fails fast", http.StatusBadRequest, 1, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts == 1 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := New(&Config{BaseURL: server.URL, HTTPClient: &http.Client{}})
+			client.SetRetryConfig(RetryConfig{
+				MaxRetries:           3,
+				RetryDelay:           time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusRequestTimeout},
+			})
+
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			resp, err := client.httpClient.Transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportDefaultDoesNotRetryClientErrors(t *testing.T) {
+	transport := &retryTransport{}
+	for _, status := range []int{http.StatusBadRequest, http.StatusRequestTimeout, http.StatusTooManyRequests} {
+		if transport.shouldRetryStatus(status) {
+			t.Errorf("Expected %d not to be retried by default", status)
+		}
+	}
+	if !transport.shouldRetryStatus(http.StatusBadGateway) {
+		t.Error("Expected 5xx to be retried by default")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"delta seconds", "30", 30 * time.Second, true},
+		{"HTTP date", now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{"past HTTP date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"empty", "", 0, false},
+		{"negative", "-5", 0, false},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value, now)
+			if wait != tt.expected || ok != tt.ok {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, wait, ok)
+			}
+		})
+	}
+}
+
+// base64JSONCodec is a trivial codec that wraps JSON in base64 encoding
+type base64JSONCodec struct{}
+
+func (base64JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(data)), nil
+}
+
+func (base64JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, v)
+}
+
+func (base64JSONCodec) ContentType() string {
+	return "application/x-base64-json"
+}
+
+func TestCustomCodecRoundTrip(t *testing.T) {
+	codec := base64JSONCodec{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != codec.ContentType() {
+			t.Errorf("Expected Content-Type %s, got %s", codec.ContentType(), r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get("Accept") != codec.ContentType() {
+			t.Errorf("Expected Accept %s, got %s", codec.ContentType(), r.Header.Get("Accept"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var received map[string]string
+		if err := codec.Unmarshal(body, &received); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+
+		response := models.APIResponse{
+			StatusCode: 200,
+			Message:    "Success",
+			Data:       map[string]string{"echo": received["data"]},
+		}
+		encoded, _ := codec.Marshal(response)
+		w.Header().Set("Content-Type", codec.ContentType())
+		w.Write(encoded)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		BaseURL:    server.URL,
+		Username:   "test",
+		Password:   "pass",
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Timeout:    30 * time.Second,
+		Codec:      codec,
+	}
+	client := New(config)
+	client.authManager = &AuthManager{
+		config:     config,
+		token:      "test-token",
+		expiresAt:  time.Now().Add(time.Hour),
+		httpClient: config.HTTPClient,
+	}
+
+	type TestBody struct {
+		Data string `json:"data"`
+	}
+
+	var result map[string]string
+	err := client.POST(context.Background(), "/test", TestBody{Data: "round-trip"}, &result)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result["echo"] != "round-trip" {
+		t.Errorf("Expected echoed value 'round-trip', got %q", result["echo"])
+	}
+}
+
+func TestMakeRequestRetriesOnTokenScopeExpired(t *testing.T) {
+	loginCount := 0
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/auth/login" {
+			loginCount++
+			json.NewEncoder(w).Encode(models.APIResponse{
+				StatusCode: 200,
+				Data:       map[string]interface{}{"token": createTestJWT(time.Now().Add(time.Hour))},
+			})
+			return
+		}
+
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(models.ErrorResponse{
+				StatusCode: 403,
+				Message:    "Token scope has changed",
+				Code:       "TOKEN_SCOPE_EXPIRED",
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.APIResponse{
+			StatusCode: 200,
+			Data:       map[string]string{"test": "value"},
+		})
+	}))
+	defer server.Close()
+
+	config := &Config{
+		BaseURL:    server.URL,
+		Username:   "test",
+		Password:   "pass",
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Timeout:    30 * time.Second,
+	}
+	client := New(config)
+
+	var result map[string]string
+	err := client.GET(context.Background(), "/test", &result)
+	if err != nil {
+		t.Fatalf("Expected no error after token refresh, got %v", err)
+	}
+	if result["test"] != "value" {
+		t.Error("Expected result to contain test data")
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+	if loginCount != 2 {
+		t.Errorf("Expected token to be refreshed (2 logins), got %d logins", loginCount)
+	}
+}
+
+func TestMakeRequestPermissionDeniedIsTerminal(t *testing.T) {
+	loginCount := 0
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/auth/login" {
+			loginCount++
+			json.NewEncoder(w).Encode(models.APIResponse{
+				StatusCode: 200,
+				Data:       map[string]interface{}{"token": createTestJWT(time.Now().Add(time.Hour))},
+			})
+			return
+		}
+
+		attempts++
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(models.ErrorResponse{
+			StatusCode: 403,
+			Message:    "Forbidden",
+			Error:      "PERMISSION_DENIED",
+		})
+	}))
+	defer server.Close()
+
+	config := &Config{
+		BaseURL:    server.URL,
+		Username:   "test",
+		Password:   "pass",
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Timeout:    30 * time.Second,
+	}
+	client := New(config)
+
+	err := client.GET(context.Background(), "/test", nil)
+	if err == nil {
+		t.Fatal("Expected permission denied error")
+	}
+
+	apiErr, ok := err.(*errors.APIError)
+	if !ok {
+		t.Fatalf("Expected APIError type, got %T", err)
+	}
+	if !apiErr.IsForbidden() || apiErr.IsTokenScopeExpired() {
+		t.Errorf("Expected a terminal 403, got %+v", apiErr)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+	if loginCount != 1 {
+		t.Errorf("Expected 1 login, got %d", loginCount)
+	}
+}
+
+func TestDoRawReturnsHeadersAndBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-123")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode":404,"message":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{"statusCode":200,"data":{"id":"emp-1"},"extra":"envelope"}`))
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+
+	resp, body, err := client.DoRaw(context.Background(), http.MethodGet, "/employees/emp-1", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Request-Id") != "req-123" {
+		t.Errorf("Expected status 200 with request ID, got %d %q", resp.StatusCode, resp.Header.Get("X-Request-Id"))
+	}
+	if string(body) != `{"statusCode":200,"data":{"id":"emp-1"},"extra":"envelope"}` {
+		t.Errorf("Expected the raw envelope, got %s", body)
+	}
+
+	resp, body, err = client.DoRaw(context.Background(), http.MethodGet, "/missing", nil)
+	if err != nil {
+		t.Fatalf("Expected error statuses to be returned without error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || len(body) == 0 {
+		t.Errorf("Expected the 404 response and body, got %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestGzipResponseDecompression(t *testing.T) {
+	var acceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		json.NewEncoder(gz).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"test": "value"}})
+		gz.Close()
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass").EnableCompression())
+
+	var result map[string]string
+	if err := client.GET(context.Background(), "/employees", &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if acceptEncoding != "gzip" {
+		t.Errorf("Expected Accept-Encoding gzip, got %q", acceptEncoding)
+	}
+	if result["test"] != "value" {
+		t.Errorf("Expected decoded result, got %v", result)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	var tenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		tenant = r.Header.Get("X-Tenant")
+		w.Header().Set("X-Request-Id", "req-1")
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"test": "value"}})
+	}))
+	defer server.Close()
+
+	var requestIDs []string
+	config := NewConfig(server.URL, "test", "pass").
+		AddRequestInterceptor(func(req *http.Request) error {
+			req.Header.Set("X-Tenant", "acme")
+			return nil
+		}).
+		AddResponseInterceptor(func(resp *http.Response) error {
+			requestIDs = append(requestIDs, resp.Header.Get("X-Request-Id"))
+			return nil
+		})
+	client := New(config)
+
+	var result map[string]string
+	if err := client.GET(context.Background(), "/employees", &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if tenant != "acme" {
+		t.Errorf("Expected X-Tenant acme, got %q", tenant)
+	}
+	if len(requestIDs) != 1 || requestIDs[0] != "req-1" {
+		t.Errorf("Expected the response interceptor to see the request ID, got %v", requestIDs)
+	}
+	if result["test"] != "value" {
+		t.Errorf("Expected result to be decoded after interceptors, got %v", result)
+	}
+
+	auditErr := stderrors.New("audit log unavailable")
+	config.AddResponseInterceptor(func(resp *http.Response) error {
+		return auditErr
+	})
+	if err := client.GET(context.Background(), "/employees", &result); !stderrors.Is(err, auditErr) {
+		t.Errorf("Expected the interceptor error to abort the call, got %v", err)
+	}
+}
+
+func TestDefaultHeaders(t *testing.T) {
+	var received []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		received = append(received, r.Header.Clone())
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200})
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "test", "pass").
+		SetDefaultHeader("X-Api-Version", "2").
+		SetDefaultHeader("X-Partner-Id", "acme").
+		SetDefaultHeader("Authorization", "Basic overridden").
+		SetDefaultHeader("Content-Type", "text/plain")
+	client := New(config)
+
+	if err := client.GET(context.Background(), "/employees", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	body := struct {
+		Name string `json:"name"`
+	}{Name: "Ali"}
+	if err := client.POST(context.Background(), "/employees", body, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(received))
+	}
+	for i, header := range received {
+		if header.Get("X-Api-Version") != "2" || header.Get("X-Partner-Id") != "acme" {
+			t.Errorf("Request %d: expected default headers, got %v", i+1, header)
+		}
+		if header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Request %d: expected SDK Authorization header, got %q", i+1, header.Get("Authorization"))
+		}
+		if header.Get("Content-Type") != "application/json" {
+			t.Errorf("Request %d: expected SDK Content-Type header, got %q", i+1, header.Get("Content-Type"))
+		}
+	}
+}
+
+func TestFieldAliasesDecodeAlternateKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		w.Write([]byte(`{"statusCode":200,"data":{"total":2,"results":[
+			{"id":"e1","employee_code":"E001","first_name":"Ali","net_salary":"8000"},
+			{"id":"e2","employeeCode":"E002","employee_code":"ignored","first_name":"Sara"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "test", "pass").
+		SetFieldAlias("employee_code", "employeeCode").
+		SetFieldAlias("first_name", "firstName").
+		SetFieldAlias("net_salary", "netSalary")
+	client := New(config)
+
+	var result models.EmployeeListResponse
+	if err := client.GET(context.Background(), "/employees", &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Results) != 2 {
+		t.Fatalf("Expected 2 employees, got %d", len(result.Results))
+	}
+	first := result.Results[0]
+	if first.EmployeeCode != "E001" || first.FirstName != "Ali" || first.NetSalary != 8000 {
+		t.Errorf("Expected aliased keys to decode, got %+v", first)
+	}
+	if result.Results[1].EmployeeCode != "E002" {
+		t.Errorf("Expected the standard key to win over its alias, got %q", result.Results[1].EmployeeCode)
+	}
+}
+
+func TestPerAttemptTimeoutRetriesHungAttempt(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// Hang until the client abandons the attempt
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"test": "value"}})
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+	client.SetRetryConfig(RetryConfig{MaxRetries: 2, RetryDelay: time.Millisecond, PerAttemptTimeout: 100 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	var result map[string]string
+	if err := client.GET(ctx, "/employees", &result); err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the hung attempt to be abandoned quickly, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&attempts) != 2 || result["test"] != "value" {
+		t.Errorf("Expected 2 attempts and a decoded result, got %d attempts and %v", attempts, result)
+	}
+}
+
+func TestPerAttemptTimeoutBoundedByOverallDeadline(t *testing.T) {
+	var hung int32
+	transport := &retryTransport{
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&hung, 1)
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}),
+		maxRetries:        3,
+		retryDelay:        time.Millisecond,
+		perAttemptTimeout: time.Minute,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+
+	start := time.Now()
+	if _, err := transport.RoundTrip(req); !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the overall deadline to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the attempt to end at the overall deadline, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&hung) != 1 {
+		t.Errorf("Expected no retries past the overall deadline, got %d attempts", hung)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportMethods(t *testing.T) {
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.Method+" "+r.Header.Get(MethodOverrideHeader)]++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	send := func(rt *retryTransport, method, override string) {
+		req, _ := http.NewRequest(method, server.URL, nil)
+		if override != "" {
+			req.Header.Set(MethodOverrideHeader, override)
+		}
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	defaults := newRetryTransport(http.DefaultTransport, RetryConfig{MaxRetries: 2, RetryDelay: time.Millisecond})
+	send(defaults, http.MethodGet, "")
+	send(defaults, http.MethodDelete, "")
+	send(defaults, http.MethodPost, http.MethodDelete)
+
+	if attempts["GET "] != 3 {
+		t.Errorf("Expected GET to be retried, got %d attempts", attempts["GET "])
+	}
+	if attempts["DELETE "] != 1 {
+		t.Errorf("Expected DELETE not to be retried by default, got %d attempts", attempts["DELETE "])
+	}
+	if attempts["POST DELETE"] != 1 {
+		t.Errorf("Expected a tunneled DELETE not to be retried, got %d attempts", attempts["POST DELETE"])
+	}
+
+	for key := range attempts {
+		delete(attempts, key)
+	}
+	custom := newRetryTransport(http.DefaultTransport, RetryConfig{
+		MaxRetries: 2,
+		RetryDelay: time.Millisecond,
+		Methods:    map[string]bool{"delete": true, http.MethodGet: false},
+	})
+	send(custom, http.MethodGet, "")
+	send(custom, http.MethodDelete, "")
+
+	if attempts["GET "] != 1 {
+		t.Errorf("Expected GET to be opted out, got %d attempts", attempts["GET "])
+	}
+	if attempts["DELETE "] != 3 {
+		t.Errorf("Expected DELETE to be opted in, got %d attempts", attempts["DELETE "])
+	}
+}
+
+func TestRequestTimeoutBoundsEachCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]string{}})
+	}))
+	defer server.Close()
+
+	config := &Config{
+		BaseURL:        server.URL,
+		HTTPClient:     &http.Client{Timeout: 30 * time.Second},
+		Timeout:        30 * time.Second,
+		RequestTimeout: 50 * time.Millisecond,
+	}
+	client := New(config)
+	client.authManager = &AuthManager{
+		config:     config,
+		token:      "test-token",
+		expiresAt:  time.Now().Add(time.Hour),
+		httpClient: config.HTTPClient,
+	}
+
+	start := time.Now()
+	err := client.GET(context.Background(), "/slow", nil)
+	if !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the per-request timeout to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the call to end at the request timeout, took %v", elapsed)
+	}
+
+	// The timeout applies per call, so consecutive fast calls each get the full budget
+	for i := 0; i < 3; i++ {
+		time.Sleep(20 * time.Millisecond)
+		if err := client.GET(context.Background(), "/fast", nil); err != nil {
+			t.Fatalf("Call %d: expected no error, got %v", i+1, err)
+		}
+	}
+}
+
+func TestRequestTimeoutKeepsSoonerCallerDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	config := &Config{
+		BaseURL:        server.URL,
+		HTTPClient:     &http.Client{Timeout: 30 * time.Second},
+		Timeout:        30 * time.Second,
+		RequestTimeout: time.Minute,
+	}
+	client := New(config)
+	client.authManager = &AuthManager{
+		config:     config,
+		token:      "test-token",
+		expiresAt:  time.Now().Add(time.Hour),
+		httpClient: config.HTTPClient,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := client.GET(ctx, "/slow", nil); !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the caller's deadline to be exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the caller's sooner deadline to win, took %v", elapsed)
+	}
+}
+
+func TestJoinURL(t *testing.T) {
+	tests := []struct {
+		base, endpoint, expected string
+	}{
+		{"https://api-uat-v2.abhi.ae/uat-open-api", "/employees", "https://api-uat-v2.abhi.ae/uat-open-api/employees"},
+		{"https://api-uat-v2.abhi.ae/uat-open-api/", "/employees", "https://api-uat-v2.abhi.ae/uat-open-api/employees"},
+		{"https://api-uat-v2.abhi.ae/uat-open-api", "employees", "https://api-uat-v2.abhi.ae/uat-open-api/employees"},
+		{"https://api.abhi.ae", "/employees", "https://api.abhi.ae/employees"},
+		{"https://api.abhi.ae/", "/employees", "https://api.abhi.ae/employees"},
+		{"https://api.abhi.ae/v2/open-api", "/employees/emp-1", "https://api.abhi.ae/v2/open-api/employees/emp-1"},
+		{"https://api.abhi.ae/open-api", "/employees?page=2&limit=50", "https://api.abhi.ae/open-api/employees?page=2&limit=50"},
+		{"https://api.abhi.ae/open-api?tenant=t1", "/employees?page=2", "https://api.abhi.ae/open-api/employees?tenant=t1&page=2"},
+		{"https://api.abhi.ae/open-api", "/organizations/org-1/users/a%2Fb/reset-password", "https://api.abhi.ae/open-api/organizations/org-1/users/a%2Fb/reset-password"},
+	}
+
+	for _, tt := range tests {
+		got, err := joinURL(tt.base, tt.endpoint)
+		if err != nil {
+			t.Errorf("joinURL(%q, %q): unexpected error %v", tt.base, tt.endpoint, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("joinURL(%q, %q) = %q, expected %q", tt.base, tt.endpoint, got, tt.expected)
+		}
+	}
+}
+
+func TestRequestsPreserveBasePath(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/auth/login") {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]string{}})
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL+"/uat-open-api/", "test", "pass"))
+	query := map[string][]string{"page": {"2"}}
+	if err := client.GETWithQuery(context.Background(), "/employees", query, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"/uat-open-api/auth/login", "/uat-open-api/employees?page=2"}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected requests to %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Request %d: expected %s, got %s", i+1, expected[i], paths[i])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import "encoding/json"
+
+// Codec handles serialization of request and response bodies
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	ContentType() string
+}
+
+// JSONCodec implements Codec using encoding/json
+type JSONCodec struct{}
+
+// Marshal encodes v as JSON
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data into v
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// ContentType returns the JSON media type
+func (JSONCodec) ContentType() string {
+	return "application/json"
+}
+
+// AliasCodec wraps a codec so alternate field names in responses decode into the standard
+// model fields, e.g. "employee_code" into Employee.EmployeeCode. Keys are renamed at any depth;
+// an alias is ignored where the standard key is also present.
+//
+// Decoding first into a generic value, renaming keys, and re-encoding roughly triples the
+// cost of unmarshaling each response, so only enable it for deployments that need it.
+type AliasCodec struct {
+	Codec   Codec
+	Aliases map[string]string // Alternate key to standard key
+}
+
+// NewAliasCodec creates a codec that renames aliased keys before decoding with codec
+func NewAliasCodec(codec Codec, aliases map[string]string) *AliasCodec {
+	return &AliasCodec{Codec: codec, Aliases: aliases}
+}
+
+// Marshal encodes v with the wrapped codec; requests always use the standard field names
+func (ac *AliasCodec) Marshal(v interface{}) ([]byte, error) {
+	return ac.Codec.Marshal(v)
+}
+
+// Unmarshal renames aliased keys in data, then decodes it into v
+func (ac *AliasCodec) Unmarshal(data []byte, v interface{}) error {
+	if len(ac.Aliases) == 0 {
+		return ac.Codec.Unmarshal(data, v)
+	}
+
+	var generic interface{}
+	if err := ac.Codec.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	renamed, err := ac.Codec.Marshal(ac.rename(generic))
+	if err != nil {
+		return err
+	}
+	return ac.Codec.Unmarshal(renamed, v)
+}
+
+// ContentType returns the wrapped codec's media type
+func (ac *AliasCodec) ContentType() string {
+	return ac.Codec.ContentType()
+}
+
+// rename replaces aliased keys within a decoded value
+func (ac *AliasCodec) rename(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if standard, ok := ac.Aliases[key]; ok {
+				if _, exists := v[standard]; !exists {
+					key = standard
+				}
+			}
+			renamed[key] = ac.rename(field)
+		}
+		return renamed
+	case []interface{}:
+		for i, item := range v {
+			v[i] = ac.rename(item)
+		}
+	}
+	return value
+}
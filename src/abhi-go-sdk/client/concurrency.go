@@ -0,0 +1,65 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// concurrencyTransport wraps an HTTP transport limiting the number of requests in flight.
+// A slot is held until the response body is closed, or released immediately on error. Event
+// streams stay open indefinitely, so their slot is released once the response headers arrive.
+type concurrencyTransport struct {
+	transport http.RoundTripper
+	slots     chan struct{}
+}
+
+// newConcurrencyTransport creates a transport allowing at most maxConcurrent requests in flight
+func newConcurrencyTransport(transport http.RoundTripper, maxConcurrent int) *concurrencyTransport {
+	return &concurrencyTransport{
+		transport: transport,
+		slots:     make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (ct *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Wait for a free slot
+	select {
+	case ct.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := ct.transport.RoundTrip(req)
+	if err != nil {
+		<-ct.slots
+		return nil, err
+	}
+
+	if isEventStream(resp) {
+		<-ct.slots
+		return resp, nil
+	}
+
+	resp.Body = &slotReleasingBody{ReadCloser: resp.Body, release: func() { <-ct.slots }}
+	return resp, nil
+}
+
+// slotReleasingBody releases a concurrency slot when the response body is closed
+type slotReleasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *slotReleasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// isEventStream reports whether resp is a server-sent event stream
+func isEventStream(resp *http.Response) bool {
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+}
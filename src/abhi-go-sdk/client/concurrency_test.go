@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/models"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]string{"token": "test-token"}})
+			return
+		}
+
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&peak)
+			if current <= observed || atomic.CompareAndSwapInt32(&peak, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]string{"test": "value"}})
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "test", "pass").SetMaxConcurrentRequests(3)
+	client := New(config)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var result map[string]string
+			if err := client.GET(context.Background(), "/test", &result); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if observed := atomic.LoadInt32(&peak); observed > 3 {
+		t.Errorf("Expected at most 3 concurrent requests, observed %d", observed)
+	} else if observed < 2 {
+		t.Errorf("Expected requests to run concurrently up to the limit, observed %d", observed)
+	}
+}
+
+func TestMaxConcurrentRequestsRespectsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	transport := newConcurrencyTransport(http.DefaultTransport, 1)
+
+	go func() {
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		transport.RoundTrip(req)
+	}()
+
+	// Wait for the first request to take the only slot
+	for len(transport.slots) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	if _, err := transport.RoundTrip(req); err != context.DeadlineExceeded {
+		t.Errorf("Expected context deadline exceeded while waiting for a slot, got %v", err)
+	}
+}
+
+func TestMaxConcurrentRequestsReleasesEventStreams(t *testing.T) {
+	release := make(chan struct{})
+	server := newStreamTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]string{"test": "value"}})
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "id: 1\ndata: connected\n\n")
+		w.(http.Flusher).Flush()
+
+		// Hold the stream open until the test is done
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	defer server.Close()
+	defer close(release)
+
+	client := New(NewConfig(server.URL, "test", "pass").SetMaxConcurrentRequests(1))
+
+	streamCtx, stopStream := context.WithCancel(context.Background())
+	defer stopStream()
+
+	connected := make(chan struct{})
+	var once sync.Once
+	go client.Stream(streamCtx, "/events", StreamOptions{}, func(event Event) error {
+		once.Do(func() { close(connected) })
+		return nil
+	})
+
+	select {
+	case <-connected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the stream to connect")
+	}
+
+	// The open stream must not hold the only slot
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var result map[string]string
+	if err := client.GET(ctx, "/test", &result); err != nil {
+		t.Fatalf("Expected a request alongside an open stream to succeed, got %v", err)
+	}
+	if result["test"] != "value" {
+		t.Errorf("Expected test=value, got %v", result)
+	}
+}
@@ -1,19 +1,68 @@
 package client
 
 import (
+	"crypto/tls"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
 )
 
+// Version is the SDK version reported in the default User-Agent
+const Version = "1.0.0"
+
+// DefaultUserAgent is sent when Config.UserAgent is empty
+const DefaultUserAgent = "abhi-go-sdk/" + Version
+
+// DefaultTokenExpiryBuffer is how long before expiry a token is refreshed when
+// Config.TokenExpiryBuffer is zero
+const DefaultTokenExpiryBuffer = 5 * time.Minute
+
+// DefaultMaxRetryAfter caps the delay requested by a Retry-After header when
+// RetryConfig.MaxRetryAfter is zero
+const DefaultMaxRetryAfter = 30 * time.Second
+
+// APIKeyHeader carries Config.APIKey on every request
+const APIKeyHeader = "X-Api-Key"
+
 // Config holds the configuration for the Abhi API client
 type Config struct {
-	BaseURL           string
-	Username          string
-	Password          string
-	HTTPClient        *http.Client
-	Timeout           time.Duration
-	RateLimit         *RateLimitConfig
-	Security          *SecurityConfig
+	BaseURL               string
+	Username              string
+	Password              string
+	APIKey                string // When set, sent as X-Api-Key instead of logging in with Username and Password
+	HTTPClient            *http.Client
+	Timeout               time.Duration
+	RequestTimeout        time.Duration // When positive, bounds each API call, including auth and retries, independently of Timeout
+	RateLimit             *RateLimitConfig
+	Security              *SecurityConfig
+	Codec                 Codec
+	Currency              *models.Currency             // When set, outgoing amounts are rounded to this currency's rules
+	MinTLSVersion         uint16                       // Minimum TLS version for API traffic; TLS 1.2 or newer (defaults to TLS 1.2)
+	CipherSuites          []uint16                     // Allowed TLS 1.2 cipher suites; nil uses Go's secure defaults
+	LatencySampleSize     int                          // When positive, latencies of this many recent requests per endpoint group are tracked
+	MaxConcurrentRequests int                          // When positive, requests beyond this many in flight wait for a free slot
+	Logger                Logger                       // When set, requests and responses are logged with credentials masked
+	MethodOverride        bool                         // Tunnel PUT, PATCH and DELETE through POST with X-HTTP-Method-Override
+	Compression           bool                         // Request gzip-encoded responses with Accept-Encoding
+	RequestInterceptors   []func(*http.Request) error  // Run in order before each request is sent; an error aborts the call
+	ResponseInterceptors  []func(*http.Response) error // Run in order after each response is received; an error aborts the call
+	DefaultHeaders        map[string]string            // Sent on every request; never override the SDK's own headers
+	UserAgent             string                       // Identifies the integrator to Abhi (defaults to DefaultUserAgent)
+	FieldAliases          map[string]string            // Alternate response keys to standard keys; opt-in, as it slows decoding
+	DefaultOrganizationID string                       // Sent as X-Organization-ID on every API request; must be a UUID
+	TokenExpiryBuffer     time.Duration                // Refresh tokens this long before they expire (defaults to 5m, negative disables)
+	EventSink             EventSink                    // When set, receives lifecycle events after successful operations
+	TokenStore            TokenStore                   // When set, access tokens are reused across SDK instances and process runs
+	Metrics               Metrics                      // When set, observes the method, endpoint, status and duration of every API call
+	TracerProvider        TracerProvider               // When set, each API call runs in a span whose trace context is sent to the API
+
+	// OnTokenRefreshed, if set, is called with each new token the SDK obtains by login or refresh,
+	// e.g. to share it across processes. It runs outside the SDK's locks, so it may call the SDK.
+	OnTokenRefreshed func(token string, expiresAt time.Time)
 }
 
 // SecurityConfig holds security-related configuration
@@ -23,6 +72,35 @@ type SecurityConfig struct {
 	CredentialStore      CredentialStore
 	EnableRequestSigning bool
 	SigningSecret        string
+	SignatureWindow      time.Duration // Accepted clock difference for signed requests; zero uses DefaultSignatureWindow
+	SignedHeaders        []string      // Headers covered by the signature, in order; empty uses DefaultSignedHeaders
+}
+
+// DefaultRetryMethods lists the HTTP methods retried unless RetryConfig.Methods overrides them.
+// DELETE is excluded because deletes cascade server-side, making a blind repeat risky. Methods
+// not listed are never retried.
+var DefaultRetryMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodPost:    true,
+	http.MethodDelete:  false,
+}
+
+// RetryConfig holds retry configuration. Network errors and 5xx responses are always retried
+// for the methods that allow retries. Rate limited 429 responses are only retried when 429 is
+// listed in RetryableStatusCodes. A Retry-After header on a retried 429 or 503 replaces the
+// backoff, up to MaxRetryAfter.
+type RetryConfig struct {
+	MaxRetries           int
+	RetryDelay           time.Duration   // Base delay, doubled after each attempt
+	Jitter               bool            // Randomize each backoff within [0, RetryDelay * 2^attempt]
+	RetryableStatusCodes []int           // Additional status codes to retry, e.g. 408 or 429
+	PerAttemptTimeout    time.Duration   // Abandon and retry an attempt whose response headers take longer than this; zero disables
+	Methods              map[string]bool // Per-method overrides of DefaultRetryMethods; true opts in, false out
+	MaxRetryAfter        time.Duration   // Longest Retry-After delay honored, longer ones are clamped; zero uses DefaultMaxRetryAfter
 }
 
 // RateLimitConfig holds rate limiting configuration
@@ -30,6 +108,8 @@ type RateLimitConfig struct {
 	RequestsPerSecond float64
 	BurstSize         int
 	Enabled           bool
+	AdaptFromHeaders  bool          // Slow down or pause as the server's X-RateLimit-Remaining/Reset headers require
+	MaxWait           time.Duration // Fail a request rather than wait longer than this for a token; zero waits indefinitely
 }
 
 // DefaultConfig returns a default configuration
@@ -49,6 +129,10 @@ func DefaultConfig() *Config {
 			EncryptCredentials:   false, // Disabled by default
 			EnableRequestSigning: false, // Disabled by default
 		},
+		Codec:             JSONCodec{},
+		MinTLSVersion:     tls.VersionTLS12,
+		UserAgent:         DefaultUserAgent,
+		TokenExpiryBuffer: DefaultTokenExpiryBuffer,
 	}
 }
 
@@ -61,6 +145,61 @@ func NewConfig(baseURL, username, password string) *Config {
 	return config
 }
 
+// NewConfigWithAPIKey creates a new configuration that authenticates with a static API key
+func NewConfigWithAPIKey(baseURL, apiKey string) *Config {
+	config := DefaultConfig()
+	config.BaseURL = baseURL
+	config.APIKey = apiKey
+	return config
+}
+
+// Validate checks that the configuration can be used to call the API, returning an
+// *errors.ValidationError naming the problem. The base URL must be an absolute http or https URL;
+// a trailing slash is trimmed so it doesn't double up when joined with endpoints. Either an API
+// key or a username and password is required, and the TLS settings must be secure.
+func (c *Config) Validate() error {
+	baseURL := strings.TrimRight(strings.TrimSpace(c.BaseURL), "/")
+	if baseURL == "" {
+		return &errors.ValidationError{
+			Field:   "baseURL",
+			Message: "base URL is required",
+		}
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+		return &errors.ValidationError{
+			Field:   "baseURL",
+			Message: "base URL must be an absolute URL such as https://api.abhi.ae/open-api",
+			Value:   c.BaseURL,
+		}
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return &errors.ValidationError{
+			Field:   "baseURL",
+			Message: "base URL must use http or https",
+			Value:   c.BaseURL,
+		}
+	}
+	c.BaseURL = baseURL
+
+	if c.APIKey == "" && (c.Username == "" || c.Password == "") {
+		return &errors.ValidationError{
+			Field:   "credentials",
+			Message: "either an API key or a username and password is required",
+		}
+	}
+
+	if _, err := c.TLSConfig(); err != nil {
+		return &errors.ValidationError{
+			Field:   "tls",
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
 // SetHTTPClient sets a custom HTTP client
 func (c *Config) SetHTTPClient(client *http.Client) *Config {
 	c.HTTPClient = client
@@ -74,13 +213,188 @@ func (c *Config) SetTimeout(timeout time.Duration) *Config {
 	return c
 }
 
+// SetRequestTimeout bounds each API call made through the SDK. Unlike Timeout, which applies to
+// every HTTP round trip, the budget covers the whole call including login and retries, and a
+// sooner deadline on the caller's context still wins.
+func (c *Config) SetRequestTimeout(timeout time.Duration) *Config {
+	c.RequestTimeout = timeout
+	return c
+}
+
+// SetCodec sets the codec used to serialize request and response bodies
+func (c *Config) SetCodec(codec Codec) *Config {
+	c.Codec = codec
+	return c
+}
+
+// SetCurrency sets the currency used to round outgoing amounts
+func (c *Config) SetCurrency(currency models.Currency) *Config {
+	c.Currency = &currency
+	return c
+}
+
+// SetMinTLSVersion sets the minimum TLS version; versions older than TLS 1.2 are rejected
+func (c *Config) SetMinTLSVersion(version uint16) *Config {
+	c.MinTLSVersion = version
+	return c
+}
+
+// SetCipherSuites restricts the TLS 1.2 cipher suites offered to the API; insecure suites are rejected
+func (c *Config) SetCipherSuites(cipherSuites ...uint16) *Config {
+	c.CipherSuites = cipherSuites
+	return c
+}
+
+// EnableLatencyTracking enables latency percentiles over the given number of recent requests
+// per endpoint group, or DefaultLatencySampleSize when zero
+func (c *Config) EnableLatencyTracking(sampleSize int) *Config {
+	if sampleSize <= 0 {
+		sampleSize = DefaultLatencySampleSize
+	}
+	c.LatencySampleSize = sampleSize
+	return c
+}
+
+// SetMaxConcurrentRequests limits the number of requests in flight at once, independent of the rate limit
+func (c *Config) SetMaxConcurrentRequests(maxConcurrent int) *Config {
+	c.MaxConcurrentRequests = maxConcurrent
+	return c
+}
+
+// SetLogger sets the logger used to log requests and responses
+func (c *Config) SetLogger(logger Logger) *Config {
+	c.Logger = logger
+	return c
+}
+
+// SetMethodOverride enables sending PUT, PATCH and DELETE as POST with an X-HTTP-Method-Override
+// header, for networks whose proxies block those methods
+func (c *Config) SetMethodOverride(enabled bool) *Config {
+	c.MethodOverride = enabled
+	return c
+}
+
+// EnableCompression requests gzip-encoded responses to reduce bandwidth on large listings
+func (c *Config) EnableCompression() *Config {
+	c.Compression = true
+	return c
+}
+
+// AddRequestInterceptor adds a hook run before each request is sent, e.g. to inject headers
+func (c *Config) AddRequestInterceptor(interceptor func(*http.Request) error) *Config {
+	c.RequestInterceptors = append(c.RequestInterceptors, interceptor)
+	return c
+}
+
+// AddResponseInterceptor adds a hook run after each response is received, e.g. for audit logging
+func (c *Config) AddResponseInterceptor(interceptor func(*http.Response) error) *Config {
+	c.ResponseInterceptors = append(c.ResponseInterceptors, interceptor)
+	return c
+}
+
+// SetDefaultHeader sets a header sent on every request, such as X-Api-Version
+func (c *Config) SetDefaultHeader(key, value string) *Config {
+	if c.DefaultHeaders == nil {
+		c.DefaultHeaders = make(map[string]string)
+	}
+	c.DefaultHeaders[key] = value
+	return c
+}
+
+// SetDefaultOrganizationID scopes every API request to a sub-organization. Individual calls can
+// override it with WithOrganizationID.
+func (c *Config) SetDefaultOrganizationID(organizationID string) *Config {
+	c.DefaultOrganizationID = organizationID
+	return c
+}
+
+// SetUserAgent sets the User-Agent sent on every request, e.g. "acme-payroll/2.1 abhi-go-sdk/1.0.0"
+func (c *Config) SetUserAgent(userAgent string) *Config {
+	c.UserAgent = userAgent
+	return c
+}
+
+// userAgent returns the configured User-Agent, or DefaultUserAgent if unset
+func (c *Config) userAgent() string {
+	if c.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return c.UserAgent
+}
+
+// SetEventSink sets the sink that receives lifecycle events, such as created transactions and
+// repayments, after the operations succeed
+func (c *Config) SetEventSink(sink EventSink) *Config {
+	c.EventSink = sink
+	return c
+}
+
+// SetMetrics sets the recorder that observes every API call, successful or not
+func (c *Config) SetMetrics(metrics Metrics) *Config {
+	c.Metrics = metrics
+	return c
+}
+
+// SetTracerProvider traces every API call with provider, e.g. an OpenTelemetry provider adapted
+// by the otelabhi module
+func (c *Config) SetTracerProvider(provider TracerProvider) *Config {
+	c.TracerProvider = provider
+	return c
+}
+
+// metrics returns the configured metrics recorder, or NoopMetrics if none is set
+func (c *Config) metrics() Metrics {
+	if c.Metrics == nil {
+		return NoopMetrics{}
+	}
+	return c.Metrics
+}
+
+// SetTokenStore persists access tokens in store so new SDK instances can skip the login, e.g. a
+// FileTokenStore for short-lived CLI invocations
+func (c *Config) SetTokenStore(store TokenStore) *Config {
+	c.TokenStore = store
+	return c
+}
+
+// SetTokenExpiryBuffer sets how long before expiry a token is refreshed. Short-lived tokens need
+// a smaller buffer; clock-skewed environments may need a larger one.
+func (c *Config) SetTokenExpiryBuffer(buffer time.Duration) *Config {
+	c.TokenExpiryBuffer = buffer
+	return c
+}
+
+// tokenExpiryBuffer returns the configured token expiry buffer, or DefaultTokenExpiryBuffer if unset
+func (c *Config) tokenExpiryBuffer() time.Duration {
+	if c == nil || c.TokenExpiryBuffer == 0 {
+		return DefaultTokenExpiryBuffer
+	}
+	if c.TokenExpiryBuffer < 0 {
+		return 0
+	}
+	return c.TokenExpiryBuffer
+}
+
+// SetFieldAlias decodes the response key alias as if it were the standard key, for deployments
+// whose responses use different field names. Aliasing adds a decoding pass to every response.
+func (c *Config) SetFieldAlias(alias, standard string) *Config {
+	if c.FieldAliases == nil {
+		c.FieldAliases = make(map[string]string)
+	}
+	c.FieldAliases[alias] = standard
+	return c
+}
+
 // SetRateLimit sets the rate limiting configuration
 func (c *Config) SetRateLimit(requestsPerSecond float64, burstSize int) *Config {
-	c.RateLimit = &RateLimitConfig{
-		RequestsPerSecond: requestsPerSecond,
-		BurstSize:         burstSize,
-		Enabled:           true,
+	rateLimit := &RateLimitConfig{}
+	if c.RateLimit != nil {
+		*rateLimit = *c.RateLimit // Keep other settings such as MaxWait
 	}
+	rateLimit.RequestsPerSecond = requestsPerSecond
+	rateLimit.BurstSize = burstSize
+	rateLimit.Enabled = true
+	c.RateLimit = rateLimit
 	return c
 }
 
@@ -125,13 +439,24 @@ func (c *Config) SetCredentialStore(store CredentialStore) *Config {
 	return c
 }
 
-// EnableRequestSigning enables request signing for additional security
-func (c *Config) EnableRequestSigning(signingSecret string) *Config {
+// EnableRequestSigning enables request signing for additional security. The signature covers
+// signedHeaders in order, or DefaultSignedHeaders if none are given.
+func (c *Config) EnableRequestSigning(signingSecret string, signedHeaders ...string) *Config {
 	if c.Security == nil {
 		c.Security = &SecurityConfig{}
 	}
 	c.Security.EnableRequestSigning = true
 	c.Security.SigningSecret = signingSecret
+	c.Security.SignedHeaders = signedHeaders
+	return c
+}
+
+// SetSignatureWindow sets how far a signed request's timestamp may be from the server's clock
+func (c *Config) SetSignatureWindow(window time.Duration) *Config {
+	if c.Security == nil {
+		c.Security = &SecurityConfig{}
+	}
+	c.Security.SignatureWindow = window
 	return c
 }
 
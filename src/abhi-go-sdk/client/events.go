@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// EventType identifies a lifecycle event emitted after a successful operation
+type EventType string
+
+// Lifecycle event types
+const (
+	EventTransactionCreated EventType = "transaction.created"
+	EventRepaymentCreated   EventType = "repayment.created"
+)
+
+// LifecycleEvent describes a resource created or changed by a successful SDK operation
+type LifecycleEvent struct {
+	Type       EventType `json:"type"`
+	ResourceID string    `json:"resourceId"`
+	Amount     float64   `json:"amount,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// EventSink receives lifecycle events, e.g. to publish them to a message bus
+type EventSink interface {
+	Emit(ctx context.Context, event LifecycleEvent) error
+}
+
+// EventSinkFunc adapts a function to an EventSink
+type EventSinkFunc func(ctx context.Context, event LifecycleEvent) error
+
+// Emit calls f(ctx, event)
+func (f EventSinkFunc) Emit(ctx context.Context, event LifecycleEvent) error {
+	return f(ctx, event)
+}
+
+// EmitEvent sends an event to the configured event sink, stamping it with the current time if
+// unset. It does nothing without a sink. Sink errors and panics never fail the operation that
+// emitted the event; they are logged when a logger is configured.
+func (c *Client) EmitEvent(ctx context.Context, event LifecycleEvent) {
+	sink := c.config.EventSink
+	if sink == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	defer func() {
+		if r := recover(); r != nil && c.config.Logger != nil {
+			c.config.Logger.Printf("event sink panicked on %s %s: %v", event.Type, event.ResourceID, r)
+		}
+	}()
+
+	if err := sink.Emit(ctx, event); err != nil && c.config.Logger != nil {
+		c.config.Logger.Printf("event sink failed on %s %s: %v", event.Type, event.ResourceID, err)
+	}
+}
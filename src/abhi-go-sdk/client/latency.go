@@ -0,0 +1,138 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencySampleSize is the number of recent requests kept per endpoint group
+const DefaultLatencySampleSize = 1000
+
+// LatencyStat summarizes the recent request latencies of an endpoint group
+type LatencyStat struct {
+	Count int64 // Requests recorded since the last reset
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration // Slowest request among the retained samples
+}
+
+// LatencyTracker records request latencies per endpoint group in bounded rolling windows
+type LatencyTracker struct {
+	sampleSize int
+	groups     map[string]*latencyWindow
+	mutex      sync.Mutex
+}
+
+// latencyWindow is a ring buffer of the most recent latencies of an endpoint group
+type latencyWindow struct {
+	count   int64
+	samples []time.Duration
+	next    int
+}
+
+// NewLatencyTracker creates a latency tracker keeping the given number of recent samples per group
+func NewLatencyTracker(sampleSize int) *LatencyTracker {
+	if sampleSize <= 0 {
+		sampleSize = DefaultLatencySampleSize
+	}
+
+	return &LatencyTracker{
+		sampleSize: sampleSize,
+		groups:     make(map[string]*latencyWindow),
+	}
+}
+
+// Record adds a request latency to the given endpoint group
+func (lt *LatencyTracker) Record(group string, latency time.Duration) {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+
+	window, ok := lt.groups[group]
+	if !ok {
+		window = &latencyWindow{samples: make([]time.Duration, 0, lt.sampleSize)}
+		lt.groups[group] = window
+	}
+
+	window.count++
+	if len(window.samples) < lt.sampleSize {
+		window.samples = append(window.samples, latency)
+		return
+	}
+	window.samples[window.next] = latency
+	window.next = (window.next + 1) % lt.sampleSize
+}
+
+// Stats returns a snapshot of the latency percentiles for each endpoint group
+func (lt *LatencyTracker) Stats() map[string]LatencyStat {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+
+	stats := make(map[string]LatencyStat, len(lt.groups))
+	for group, window := range lt.groups {
+		sorted := make([]time.Duration, len(window.samples))
+		copy(sorted, window.samples)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		stats[group] = LatencyStat{
+			Count: window.count,
+			P50:   percentile(sorted, 50),
+			P90:   percentile(sorted, 90),
+			P99:   percentile(sorted, 99),
+			Max:   percentile(sorted, 100),
+		}
+	}
+
+	return stats
+}
+
+// Reset discards all recorded latencies
+func (lt *LatencyTracker) Reset() {
+	lt.mutex.Lock()
+	defer lt.mutex.Unlock()
+
+	lt.groups = make(map[string]*latencyWindow)
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// endpointGroup returns the first path segment after the base URL's path, e.g. "/employees"
+func endpointGroup(basePath string, u *url.URL) string {
+	path := strings.TrimPrefix(u.Path, strings.TrimSuffix(basePath, "/"))
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		path = path[:i]
+	}
+	return "/" + path
+}
+
+// latencyTransport wraps an HTTP transport recording the latency of each response
+type latencyTransport struct {
+	transport http.RoundTripper
+	tracker   *LatencyTracker
+	basePath  string
+}
+
+func (lt *latencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := lt.transport.RoundTrip(req)
+	if err == nil {
+		lt.tracker.Record(endpointGroup(lt.basePath, req.URL), time.Since(start))
+	}
+	return resp, err
+}
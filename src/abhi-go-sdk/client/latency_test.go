@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/models"
+)
+
+func TestLatencyTrackerPercentiles(t *testing.T) {
+	tracker := NewLatencyTracker(100)
+	for i := 1; i <= 100; i++ {
+		tracker.Record("/employees", time.Duration(i)*time.Millisecond)
+	}
+
+	stat := tracker.Stats()["/employees"]
+	if stat.Count != 100 {
+		t.Errorf("Expected count 100, got %d", stat.Count)
+	}
+	if stat.P50 != 50*time.Millisecond || stat.P90 != 90*time.Millisecond || stat.P99 != 99*time.Millisecond {
+		t.Errorf("Unexpected percentiles: p50=%v p90=%v p99=%v", stat.P50, stat.P90, stat.P99)
+	}
+	if stat.Max != 100*time.Millisecond {
+		t.Errorf("Expected max 100ms, got %v", stat.Max)
+	}
+}
+
+func TestLatencyTrackerKeepsRecentSamples(t *testing.T) {
+	tracker := NewLatencyTracker(10)
+	for i := 0; i < 10; i++ {
+		tracker.Record("/transactions", time.Second)
+	}
+	for i := 0; i < 10; i++ {
+		tracker.Record("/transactions", time.Millisecond)
+	}
+
+	stat := tracker.Stats()["/transactions"]
+	if stat.Count != 20 {
+		t.Errorf("Expected count 20, got %d", stat.Count)
+	}
+	if stat.Max != time.Millisecond {
+		t.Errorf("Expected old samples to be evicted, got max %v", stat.Max)
+	}
+}
+
+func TestLatencyTrackerResetAndConcurrency(t *testing.T) {
+	tracker := NewLatencyTracker(50)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				tracker.Record("/repayments", time.Millisecond)
+				tracker.Stats()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if count := tracker.Stats()["/repayments"].Count; count != 800 {
+		t.Errorf("Expected count 800, got %d", count)
+	}
+
+	tracker.Reset()
+	if stats := tracker.Stats(); len(stats) != 0 {
+		t.Errorf("Expected no stats after reset, got %v", stats)
+	}
+}
+
+func TestClientLatencyStatsByEndpointGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data := map[string]interface{}{"test": "value"}
+		if r.URL.Path == "/open-api/auth/login" {
+			data = map[string]interface{}{"token": "test-token"}
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: data})
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL+"/open-api", "test", "pass").EnableLatencyTracking(0)
+	client := New(config)
+
+	var result map[string]string
+	for _, endpoint := range []string{"/employees", "/employees/e1", "/transactions/employee"} {
+		if err := client.GET(context.Background(), endpoint, &result); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	stats := client.LatencyStats()
+	if stats["/employees"].Count != 2 {
+		t.Errorf("Expected 2 /employees requests, got %d", stats["/employees"].Count)
+	}
+	if stats["/transactions"].Count != 1 {
+		t.Errorf("Expected 1 /transactions request, got %d", stats["/transactions"].Count)
+	}
+	if stats["/auth"].Count != 1 {
+		t.Errorf("Expected 1 /auth request, got %d", stats["/auth"].Count)
+	}
+
+	client.ResetLatencyStats()
+	if stats := client.LatencyStats(); len(stats) != 0 {
+		t.Errorf("Expected no stats after reset, got %v", stats)
+	}
+}
+
+func TestClientLatencyStatsDisabledByDefault(t *testing.T) {
+	client := New(DefaultConfig())
+	if stats := client.LatencyStats(); stats != nil {
+		t.Errorf("Expected nil stats when tracking is disabled, got %v", stats)
+	}
+}
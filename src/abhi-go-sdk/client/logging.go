@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger receives request and response log lines; *log.Logger satisfies it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// redactedValue replaces masked header and body values in logs
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactedHeaders are the headers masked by LoggingTransport unless overridden
+var DefaultRedactedHeaders = []string{"Authorization", "X-Signature", APIKeyHeader}
+
+// DefaultRedactedFields are the JSON body fields masked by LoggingTransport unless overridden,
+// covering credentials, password changes and MFA enrollment secrets
+var DefaultRedactedFields = []string{
+	"password", "currentPassword", "newPassword", "confirmPassword",
+	"token", "accessToken", "refreshToken",
+	"secret", "MFAsecret", "qrCode", "backupCodes",
+}
+
+// LoggingTransport logs each request and response, masking credentials in headers and bodies
+type LoggingTransport struct {
+	Transport       http.RoundTripper
+	Logger          Logger
+	RedactedHeaders []string // Header names to mask, case-insensitive
+	RedactedFields  []string // JSON body field names to mask at any depth, case-insensitive
+}
+
+// NewLoggingTransport creates a logging transport with the default redaction lists
+func NewLoggingTransport(transport http.RoundTripper, logger Logger) *LoggingTransport {
+	return &LoggingTransport{
+		Transport:       transport,
+		Logger:          logger,
+		RedactedHeaders: DefaultRedactedHeaders,
+		RedactedFields:  DefaultRedactedFields,
+	}
+}
+
+func (lt *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := lt.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body for logging: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	lt.Logger.Printf("--> %s %s headers=%s body=%s",
+		req.Method, req.URL.String(), lt.formatHeaders(req.Header), lt.formatBody(reqBody))
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		lt.Logger.Printf("<-- %s %s error=%v (%s)", req.Method, req.URL.String(), err, duration)
+		return nil, err
+	}
+
+	// Event streams never end, so log them without reading the body
+	if isEventStream(resp) {
+		lt.Logger.Printf("<-- %d %s %s (%s) body=<event stream>",
+			resp.StatusCode, req.Method, req.URL.String(), duration)
+		return resp, nil
+	}
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if readErr != nil {
+		lt.Logger.Printf("<-- %d %s %s (%s) body read error=%v",
+			resp.StatusCode, req.Method, req.URL.String(), duration, readErr)
+		return nil, readErr
+	}
+
+	lt.Logger.Printf("<-- %d %s %s (%s) body=%s",
+		resp.StatusCode, req.Method, req.URL.String(), duration, lt.formatBody(respBody))
+
+	return resp, nil
+}
+
+// formatHeaders renders headers sorted by name with redacted values masked
+func (lt *LoggingTransport) formatHeaders(header http.Header) string {
+	masked := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ",")
+		if containsFold(lt.RedactedHeaders, name) {
+			value = redactedValue
+		}
+		masked[name] = value
+	}
+
+	// json.Marshal sorts map keys, giving stable output
+	data, _ := json.Marshal(masked)
+	return string(data)
+}
+
+// formatBody renders a body with redacted fields masked. Bodies that aren't JSON are only
+// summarized by size, since their fields can't be masked reliably.
+func (lt *LoggingTransport) formatBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return "<empty>"
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	data, err := json.Marshal(lt.redact(value))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return string(data)
+}
+
+// redact masks redacted fields within decoded JSON
+func (lt *LoggingTransport) redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if containsFold(lt.RedactedFields, key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = lt.redact(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = lt.redact(item)
+		}
+	}
+	return value
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
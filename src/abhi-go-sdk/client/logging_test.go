@@ -0,0 +1,117 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"abhi-go-sdk/models"
+)
+
+func TestLoggingTransportMasksCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data := map[string]interface{}{"id": "emp-1"}
+		if r.URL.Path == "/open-api/auth/login" {
+			data = map[string]interface{}{"token": "secret-token"}
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: data})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	config := NewConfig(server.URL+"/open-api", "test", "super-secret-password").
+		EnableRequestSigning("signing-secret")
+	client := New(config)
+	client.SetLogger(log.New(&buf, "", 0))
+
+	body := struct {
+		Name string `json:"name"`
+	}{Name: "Ali"}
+
+	var result map[string]string
+	if err := client.POST(context.Background(), "/employees", body, &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"secret-token", "super-secret-password"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be masked, got:\n%s", secret, output)
+		}
+	}
+	if !strings.Contains(output, `"Authorization":"[REDACTED]"`) {
+		t.Errorf("Expected masked Authorization header, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"X-Signature":"[REDACTED]"`) {
+		t.Errorf("Expected masked X-Signature header, got:\n%s", output)
+	}
+	if !strings.Contains(output, "<-- 200 POST "+server.URL+"/open-api/employees") {
+		t.Errorf("Expected status line for the request, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"name":"Ali"`) {
+		t.Errorf("Expected unredacted body fields to be logged, got:\n%s", output)
+	}
+}
+
+func TestLoggingTransportMasksMFASecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{
+			"secret":      "JBSWY3DPEHPK3PXP",
+			"qrCode":      "otpauth://totp/abhi?secret=JBSWY3DPEHPK3PXP",
+			"backupCodes": []string{"backup-1111", "backup-2222"},
+		}})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	config := NewConfig(server.URL, "", "")
+	config.APIKey = "test-key"
+	client := New(config)
+	client.SetLogger(log.New(&buf, "", 0))
+
+	// Field names differ in case between endpoints, so matching ignores it
+	body := map[string]interface{}{
+		"username":        "admin",
+		"MFAsecret":       "mfa-secret-value",
+		"NewPassword":     "new-password-value",
+		"currentpassword": "current-password-value",
+	}
+
+	var result map[string]interface{}
+	if err := client.POST(context.Background(), "/organization/users/reset-password", body, &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"JBSWY3DPEHPK3PXP", "backup-1111", "mfa-secret-value", "new-password-value", "current-password-value"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be masked, got:\n%s", secret, output)
+		}
+	}
+	if !strings.Contains(output, `"MFAsecret":"[REDACTED]"`) {
+		t.Errorf("Expected masked MFAsecret field, got:\n%s", output)
+	}
+	if !strings.Contains(output, `"username":"admin"`) {
+		t.Errorf("Expected unredacted body fields to be logged, got:\n%s", output)
+	}
+}
+
+func TestLoggingTransportLogsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	transport := NewLoggingTransport(&errTransport{err: context.DeadlineExceeded}, log.New(&buf, "", 0))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/employees", nil)
+	if _, err := transport.RoundTrip(req); err != context.DeadlineExceeded {
+		t.Fatalf("Expected the transport error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "<-- GET https://example.com/employees error=context deadline exceeded") {
+		t.Errorf("Expected error line, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics receives an observation for every API call, e.g. to export call volume and latency
+// histograms to Prometheus. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest records a finished call. endpoint is the API path without its query string,
+	// status is the HTTP status of the final response, or 0 if none was received, and dur spans
+	// the whole call including authentication and retries.
+	ObserveRequest(method, endpoint string, status int, dur time.Duration)
+}
+
+// NoopMetrics discards all observations; it is used when Config.Metrics is unset
+type NoopMetrics struct{}
+
+// ObserveRequest does nothing
+func (NoopMetrics) ObserveRequest(method, endpoint string, status int, dur time.Duration) {}
+
+// RequestObservation is a single call recorded by MemoryMetrics
+type RequestObservation struct {
+	Method   string
+	Endpoint string
+	Status   int
+	Duration time.Duration
+}
+
+// MemoryMetrics records observations in memory, e.g. to assert on them in tests
+type MemoryMetrics struct {
+	observations []RequestObservation
+	mutex        sync.Mutex
+}
+
+// NewMemoryMetrics creates a new in-memory metrics recorder
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{}
+}
+
+// ObserveRequest records the observation
+func (mm *MemoryMetrics) ObserveRequest(method, endpoint string, status int, dur time.Duration) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.observations = append(mm.observations, RequestObservation{
+		Method:   method,
+		Endpoint: endpoint,
+		Status:   status,
+		Duration: dur,
+	})
+}
+
+// Observations returns the recorded observations in the order the calls finished
+func (mm *MemoryMetrics) Observations() []RequestObservation {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	return append([]RequestObservation(nil), mm.observations...)
+}
+
+// Reset discards all recorded observations
+func (mm *MemoryMetrics) Reset() {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.observations = nil
+}
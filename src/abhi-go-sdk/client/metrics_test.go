@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/models"
+)
+
+func TestMetricsObserveSuccessAndFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/login":
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+		case "/employees":
+			time.Sleep(10 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 201, Data: map[string]string{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ErrorResponse{StatusCode: 404, Message: "Employee not found"})
+		}
+	}))
+	defer server.Close()
+
+	metrics := NewMemoryMetrics()
+	client := New(NewConfig(server.URL, "test", "pass").SetMetrics(metrics))
+
+	if err := client.GETWithQuery(context.Background(), "/employees", map[string][]string{"page": {"2"}}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.DELETE(context.Background(), "/employees/missing", nil); err == nil {
+		t.Fatal("Expected a not found error")
+	}
+
+	observations := metrics.Observations()
+	if len(observations) != 2 {
+		t.Fatalf("Expected 2 observations, got %+v", observations)
+	}
+
+	success := observations[0]
+	if success.Method != http.MethodGet || success.Endpoint != "/employees" || success.Status != http.StatusCreated {
+		t.Errorf("Unexpected success observation: %+v", success)
+	}
+	if success.Duration < 10*time.Millisecond || success.Duration > 5*time.Second {
+		t.Errorf("Expected a duration covering the server's 10ms delay, got %v", success.Duration)
+	}
+
+	failure := observations[1]
+	if failure.Method != http.MethodDelete || failure.Endpoint != "/employees/missing" || failure.Status != http.StatusNotFound {
+		t.Errorf("Unexpected failure observation: %+v", failure)
+	}
+	if failure.Duration <= 0 || failure.Duration > 5*time.Second {
+		t.Errorf("Expected a plausible duration, got %v", failure.Duration)
+	}
+
+	metrics.Reset()
+	if observations := metrics.Observations(); len(observations) != 0 {
+		t.Errorf("Expected no observations after reset, got %+v", observations)
+	}
+}
+
+func TestMetricsObserveNetworkFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	metrics := NewMemoryMetrics()
+	config := NewConfig(server.URL, "", "").SetMetrics(metrics)
+	config.APIKey = "test-key"
+	client := New(config)
+
+	if err := client.POST(context.Background(), "/transactions/employee", nil, nil); err == nil {
+		t.Fatal("Expected a network error")
+	}
+
+	observations := metrics.Observations()
+	if len(observations) != 1 || observations[0].Status != 0 || observations[0].Endpoint != "/transactions/employee" {
+		t.Errorf("Expected a single observation with status 0, got %+v", observations)
+	}
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+
+	"abhi-go-sdk/errors"
+)
+
+// OrganizationIDHeader scopes a request to a sub-organization
+const OrganizationIDHeader = "X-Organization-ID"
+
+// organizationIDKey is the context key for a per-call organization override
+type organizationIDKey struct{}
+
+// WithOrganizationID returns a context whose requests are scoped to organizationID, overriding
+// Config.DefaultOrganizationID. An empty organizationID sends no organization scope.
+func WithOrganizationID(ctx context.Context, organizationID string) context.Context {
+	return context.WithValue(ctx, organizationIDKey{}, organizationID)
+}
+
+// organizationID returns the organization a request is scoped to: the context override if
+// present, otherwise the configured default. It fails if the ID isn't a UUID.
+func (c *Client) organizationID(ctx context.Context) (string, error) {
+	organizationID := c.config.DefaultOrganizationID
+	if override, ok := ctx.Value(organizationIDKey{}).(string); ok {
+		organizationID = override
+	}
+	if organizationID == "" {
+		return "", nil
+	}
+
+	if err := c.validator.Var(organizationID, "uuid"); err != nil {
+		return "", &errors.ValidationError{
+			Field:   "organizationId",
+			Message: "Organization ID must be a UUID",
+			Value:   organizationID,
+		}
+	}
+	return organizationID, nil
+}
+
+// SetDefaultOrganizationID scopes subsequent requests to organizationID
+func (c *Client) SetDefaultOrganizationID(organizationID string) {
+	c.config.DefaultOrganizationID = organizationID
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestDefaultOrganizationID(t *testing.T) {
+	const defaultOrg = "8f14e45f-ceea-4a67-9d8e-5d6f1c1b2a3c"
+	const otherOrg = "4a2f9c1e-7b3d-4e8a-9f6c-2d1b0a9e8c7f"
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+		received = append(received, r.Header.Get(OrganizationIDHeader))
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200})
+	}))
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass").SetDefaultOrganizationID(defaultOrg))
+
+	body := struct {
+		Name string `json:"name"`
+	}{Name: "Ali"}
+	calls := []func() error{
+		func() error { return client.GET(context.Background(), "/employees", nil) },
+		func() error { return client.POST(context.Background(), "/employees", body, nil) },
+		func() error { return client.GET(WithOrganizationID(context.Background(), otherOrg), "/employees", nil) },
+		func() error { return client.GET(WithOrganizationID(context.Background(), ""), "/employees", nil) },
+	}
+	for i, call := range calls {
+		if err := call(); err != nil {
+			t.Fatalf("Call %d: expected no error, got %v", i+1, err)
+		}
+	}
+
+	expected := []string{defaultOrg, defaultOrg, otherOrg, ""}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %d requests, got %d", len(expected), len(received))
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("Request %d: expected organization %q, got %q", i+1, expected[i], received[i])
+		}
+	}
+
+	err := client.GET(WithOrganizationID(context.Background(), "not-a-uuid"), "/employees", nil)
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Errorf("Expected a validation error for a malformed organization ID, got %v", err)
+	}
+	if len(received) != len(expected) {
+		t.Error("Expected the malformed organization ID to be rejected before sending")
+	}
+}
@@ -2,9 +2,20 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"abhi-go-sdk/errors"
+)
+
+// Rate-limit headers sent by the API with each response
+const (
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
 )
 
 // RateLimiter implements token bucket rate limiting
@@ -14,6 +25,24 @@ type RateLimiter struct {
 	refillRate float64
 	lastRefill time.Time
 	mutex      sync.Mutex
+
+	// Tuning from the server's rate-limit headers, when enabled
+	adaptive    bool
+	baseRate    float64   // The configured refill rate, restored once the server's window resets
+	pacedUntil  time.Time // End of the window the refill rate was lowered for
+	pausedUntil time.Time // No tokens accrue before this, as the server's allowance is used up
+
+	maxWait       time.Duration
+	totalAllowed  atomic.Uint64
+	totalWaited   atomic.Uint64
+	totalTimedOut atomic.Uint64
+}
+
+// RateLimiterStats counts the limiter's decisions since it was created
+type RateLimiterStats struct {
+	TotalAllowed  uint64 // Requests granted a token, immediately or after waiting
+	TotalWaited   uint64 // Requests that had to wait for a token
+	TotalTimedOut uint64 // Requests refused because the wait would exceed MaxWait
 }
 
 // NewRateLimiter creates a new rate limiter with the specified configuration
@@ -27,6 +56,9 @@ func NewRateLimiter(config *RateLimitConfig) *RateLimiter {
 		maxTokens:  float64(config.BurstSize),
 		refillRate: config.RequestsPerSecond,
 		lastRefill: time.Now(),
+		adaptive:   config.AdaptFromHeaders,
+		baseRate:   config.RequestsPerSecond,
+		maxWait:    config.MaxWait,
 	}
 }
 
@@ -39,52 +71,122 @@ func (rl *RateLimiter) Allow() bool {
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
 
-	now := time.Now()
-	elapsed := now.Sub(rl.lastRefill).Seconds()
-	
-	// Refill tokens based on elapsed time
-	rl.tokens += elapsed * rl.refillRate
-	if rl.tokens > rl.maxTokens {
-		rl.tokens = rl.maxTokens
-	}
-	
-	rl.lastRefill = now
+	rl.refill()
 
 	// Check if we have tokens available
 	if rl.tokens >= 1.0 {
 		rl.tokens -= 1.0
+		rl.totalAllowed.Add(1)
 		return true
 	}
 
 	return false
 }
 
-// Wait blocks until a token is available or the context is canceled
+// refill adds the tokens accrued since the last refill; the caller must hold the mutex
+func (rl *RateLimiter) refill() {
+	now := time.Now()
+
+	// Go back to the configured rate once the window the server's headers described is over
+	if !rl.pacedUntil.IsZero() && !now.Before(rl.pacedUntil) {
+		rl.refillRate = rl.baseRate
+		rl.pacedUntil = time.Time{}
+	}
+
+	rl.tokens = rl.tokensAt(now)
+	rl.lastRefill = now
+}
+
+// tokensAt returns the tokens the bucket will hold at now; the caller must hold the mutex
+func (rl *RateLimiter) tokensAt(now time.Time) float64 {
+	// Nothing accrues while paused
+	from := rl.lastRefill
+	if from.Before(rl.pausedUntil) {
+		from = rl.pausedUntil
+	}
+
+	tokens := rl.tokens
+	if now.After(from) {
+		tokens += now.Sub(from).Seconds() * rl.refillRate
+	}
+	if tokens > rl.maxTokens {
+		tokens = rl.maxTokens
+	}
+	return tokens
+}
+
+// Wait blocks until a token is available or the context is canceled. A caller that finds the
+// bucket empty reserves the next token, leaving the bucket in debt, and sleeps once until it's
+// due, so concurrent waiters queue up instead of repeatedly waking to compete for tokens. If
+// MaxWait is set and the token isn't due within it, Wait fails at once with
+// errors.ErrRateLimitWaitExceeded.
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	if rl == nil {
 		return nil // No rate limiting
 	}
 
-	for {
-		if rl.Allow() {
-			return nil
+	rl.mutex.Lock()
+	rl.refill()
+	if rl.tokens >= 1.0 {
+		rl.tokens -= 1.0
+		rl.mutex.Unlock()
+		rl.totalAllowed.Add(1)
+		return nil
+	}
+
+	// No token will ever arrive without a refill rate
+	waitTime := time.Duration(-1)
+	if rl.refillRate > 0 {
+		waitTime = time.Duration((1.0 - rl.tokens) / rl.refillRate * float64(time.Second))
+		if pause := time.Until(rl.pausedUntil); pause > 0 {
+			waitTime += pause
 		}
+	}
 
-		// Calculate wait time until next token is available
-		rl.mutex.Lock()
-		waitTime := time.Duration((1.0 - rl.tokens) / rl.refillRate * float64(time.Second))
+	if rl.maxWait > 0 && (waitTime < 0 || waitTime > rl.maxWait) {
+		rl.mutex.Unlock()
+		rl.totalTimedOut.Add(1)
+		return fmt.Errorf("%w: next token not available within %v", errors.ErrRateLimitWaitExceeded, rl.maxWait)
+	}
+	rl.totalWaited.Add(1)
+
+	if waitTime < 0 {
 		rl.mutex.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	rl.tokens -= 1.0
+	rl.mutex.Unlock()
 
-		if waitTime > time.Millisecond {
-			waitTime = time.Millisecond * 10 // Minimum wait time
-		}
+	timer := time.NewTimer(waitTime)
+	defer timer.Stop()
 
-		select {
-		case <-time.After(waitTime):
-			// Continue to next iteration
-		case <-ctx.Done():
-			return ctx.Err()
+	select {
+	case <-timer.C:
+		rl.totalAllowed.Add(1)
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back to the waiters behind us
+		rl.mutex.Lock()
+		rl.tokens += 1.0
+		if rl.tokens > rl.maxTokens {
+			rl.tokens = rl.maxTokens
 		}
+		rl.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Stats returns the limiter's counters
+func (rl *RateLimiter) Stats() RateLimiterStats {
+	if rl == nil {
+		return RateLimiterStats{}
+	}
+
+	return RateLimiterStats{
+		TotalAllowed:  rl.totalAllowed.Load(),
+		TotalWaited:   rl.totalWaited.Load(),
+		TotalTimedOut: rl.totalTimedOut.Load(),
 	}
 }
 
@@ -97,17 +199,80 @@ func (rl *RateLimiter) GetAvailableTokens() float64 {
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
 
-	now := time.Now()
-	elapsed := now.Sub(rl.lastRefill).Seconds()
-	
-	tokens := rl.tokens + elapsed*rl.refillRate
-	if tokens > rl.maxTokens {
-		tokens = rl.maxTokens
+	tokens := rl.tokensAt(time.Now())
+	// Tokens reserved by waiters leave the bucket in debt, but none are available
+	if tokens < 0 {
+		tokens = 0
 	}
 
 	return tokens
 }
 
+// AdaptFromHeaders tunes the bucket to the allowance reported by the server's rate-limit
+// headers. When none is left it pauses until the reset time. When what's left can't sustain the
+// configured rate until the reset, it spreads the remaining requests evenly over the window, so
+// the client slows down before the server starts rejecting requests.
+func (rl *RateLimiter) AdaptFromHeaders(header http.Header) {
+	if rl == nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(header.Get(RateLimitRemainingHeader))
+	if err != nil {
+		return
+	}
+	resetAt, ok := parseRateLimitReset(header.Get(RateLimitResetHeader), time.Now())
+	if !ok {
+		return
+	}
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.refill()
+
+	if remaining <= 0 {
+		if rl.tokens > 0 {
+			rl.tokens = 0
+		}
+		if resetAt.After(rl.pausedUntil) {
+			rl.pausedUntil = resetAt
+		}
+		return
+	}
+
+	if rl.tokens > float64(remaining) {
+		rl.tokens = float64(remaining)
+	}
+
+	window := time.Until(resetAt).Seconds()
+	if window <= 0 {
+		return
+	}
+	if pacedRate := float64(remaining) / window; pacedRate < rl.baseRate {
+		rl.refillRate = pacedRate
+		rl.pacedUntil = resetAt
+		if rl.tokens > 0 {
+			rl.tokens = 0
+		}
+	}
+}
+
+// parseRateLimitReset parses an X-RateLimit-Reset header, either as a Unix timestamp or as
+// seconds until the reset
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+
+	// Delta-seconds are small; anything from 2001 on is a timestamp
+	if seconds >= 1e9 {
+		return time.Unix(seconds, 0), true
+	}
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
+
 // rateLimitTransport wraps an HTTP transport with rate limiting
 type rateLimitTransport struct {
 	transport   http.RoundTripper
@@ -120,5 +285,9 @@ func (rt *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, erro
 		return nil, err
 	}
 
-	return rt.transport.RoundTrip(req)
-}
\ No newline at end of file
+	resp, err := rt.transport.RoundTrip(req)
+	if err == nil && rt.rateLimiter != nil && rt.rateLimiter.adaptive {
+		rt.rateLimiter.AdaptFromHeaders(resp.Header)
+	}
+	return resp, err
+}
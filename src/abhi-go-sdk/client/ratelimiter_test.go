@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestRateLimiterWaitSleepsUntilTokenAvailable(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 10, BurstSize: 1, Enabled: true})
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected the burst token immediately, got %v", err)
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 90*time.Millisecond || elapsed > 300*time.Millisecond {
+		t.Errorf("Expected to wait ~100ms for the next token, waited %v", elapsed)
+	}
+}
+
+func TestRateLimiterWaitDoesNotPoll(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 20, BurstSize: 1, Enabled: true})
+	limiter.Allow()
+
+	// Each Wait is ~50ms on an empty bucket. A single timer costs a few allocations, where
+	// polling every 10ms would allocate a timer per check.
+	allocs := testing.AllocsPerRun(5, func() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs > 4 {
+		t.Errorf("Expected a single sleep per Wait, got %.0f allocations", allocs)
+	}
+}
+
+func TestRateLimiterWaitQueuesConcurrentWaiters(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 100, BurstSize: 1, Enabled: true})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	// One burst token, then one every 10ms
+	if elapsed < 80*time.Millisecond || elapsed > 400*time.Millisecond {
+		t.Errorf("Expected 10 waiters to be served in ~90ms, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCanceledReturnsToken(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 1, BurstSize: 1, Enabled: true})
+	limiter.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected the context deadline, got %v", err)
+	}
+	if tokens := limiter.GetAvailableTokens(); tokens < 0 || tokens > 0.1 {
+		t.Errorf("Expected the reserved token to be returned, got %.2f tokens", tokens)
+	}
+}
+
+func TestRateLimiterWaitWithoutRefillRate(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 0, BurstSize: 1, Enabled: true})
+	limiter.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected the context deadline, got %v", err)
+	}
+}
+
+func TestRateLimiterMaxWait(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 1, BurstSize: 2, Enabled: true, MaxWait: 50 * time.Millisecond})
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Expected burst token %d, got %v", i, err)
+		}
+	}
+
+	// The next token is a second away
+	start := time.Now()
+	err := limiter.Wait(context.Background())
+	if !stderrors.Is(err, errors.ErrRateLimitWaitExceeded) {
+		t.Fatalf("Expected ErrRateLimitWaitExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("Expected to fail without waiting, took %v", elapsed)
+	}
+
+	stats := limiter.Stats()
+	if stats.TotalAllowed != 2 || stats.TotalWaited != 0 || stats.TotalTimedOut != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestRateLimiterStatsCountWaits(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 100, BurstSize: 1, Enabled: true, MaxWait: time.Second})
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats := limiter.Stats()
+	if stats.TotalAllowed != 3 || stats.TotalWaited != 2 || stats.TotalTimedOut != 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestRateLimiterStatusReportsCounters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "test", "pass")
+	config.RateLimit = &RateLimitConfig{RequestsPerSecond: 0.1, BurstSize: 2, Enabled: true, MaxWait: 10 * time.Millisecond}
+	client := New(config)
+
+	// Login and the first request use the burst; the second request times out
+	if err := client.GET(context.Background(), "/employees", nil); err != nil {
+		t.Fatalf("Expected the first request to succeed, got %v", err)
+	}
+	err := client.GET(context.Background(), "/employees", nil)
+	if !stderrors.Is(err, errors.ErrRateLimitWaitExceeded) {
+		t.Fatalf("Expected ErrRateLimitWaitExceeded, got %v", err)
+	}
+
+	status := client.GetRateLimiterStatus()
+	if status["totalAllowed"] != uint64(2) || status["totalTimedOut"] != uint64(1) || status["totalWaited"] != uint64(0) {
+		t.Errorf("Unexpected status: %v", status)
+	}
+}
+
+// newRateLimitHeaderServer serves requests reporting the given X-RateLimit-Remaining values in
+// turn, with the window resetting a second later, and records when each request arrived
+func newRateLimitHeaderServer(t *testing.T, remaining []int) (*Client, *[]time.Time) {
+	t.Helper()
+
+	var mutex sync.Mutex
+	var arrivals []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+
+		mutex.Lock()
+		n := len(arrivals)
+		arrivals = append(arrivals, time.Now())
+		mutex.Unlock()
+
+		if n < len(remaining) {
+			w.Header().Set(RateLimitRemainingHeader, strconv.Itoa(remaining[n]))
+			w.Header().Set(RateLimitResetHeader, "1")
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200})
+	}))
+	t.Cleanup(server.Close)
+
+	config := NewConfig(server.URL, "test", "pass")
+	config.RateLimit = &RateLimitConfig{RequestsPerSecond: 1000, BurstSize: 100, Enabled: true, AdaptFromHeaders: true}
+	return New(config), &arrivals
+}
+
+func TestRateLimiterAdaptFromHeadersSlowsDown(t *testing.T) {
+	client, arrivals := newRateLimitHeaderServer(t, []int{20, 19, 18, 17, 16})
+
+	for i := 0; i < 5; i++ {
+		if err := client.GET(context.Background(), "/employees", nil); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+
+	// The bucket allows a burst of 100 at 1000/s, but ~20 requests left in a 1s window
+	// spreads them ~50ms apart
+	for i := 1; i < len(*arrivals); i++ {
+		if gap := (*arrivals)[i].Sub((*arrivals)[i-1]); gap < 40*time.Millisecond {
+			t.Errorf("Expected request %d to be paced ~50ms after the previous one, got %v", i, gap)
+		}
+	}
+}
+
+func TestRateLimiterAdaptFromHeadersPausesUntilReset(t *testing.T) {
+	client, arrivals := newRateLimitHeaderServer(t, []int{0})
+
+	for i := 0; i < 2; i++ {
+		if err := client.GET(context.Background(), "/employees", nil); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+
+	if gap := (*arrivals)[1].Sub((*arrivals)[0]); gap < 900*time.Millisecond {
+		t.Errorf("Expected to pause until the reset ~1s later, got %v", gap)
+	}
+}
+
+func TestRateLimiterIgnoresHeadersUnlessEnabled(t *testing.T) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 1000, BurstSize: 10, Enabled: true})
+	transport := &rateLimitTransport{
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set(RateLimitRemainingHeader, "0")
+			header.Set(RateLimitResetHeader, "60")
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+		}),
+		rateLimiter: limiter,
+	}
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if tokens := limiter.GetAvailableTokens(); tokens < 8 {
+		t.Errorf("Expected the headers to be ignored, got %.2f tokens", tokens)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		value    string
+		expected time.Time
+		ok       bool
+	}{
+		{"30", now.Add(30 * time.Second), true},
+		{"1700000060", time.Unix(1700000060, 0), true},
+		{"", time.Time{}, false},
+		{"soon", time.Time{}, false},
+		{"-5", time.Time{}, false},
+	}
+
+	for _, test := range tests {
+		resetAt, ok := parseRateLimitReset(test.value, now)
+		if ok != test.ok || !resetAt.Equal(test.expected) {
+			t.Errorf("%q: expected %v/%v, got %v/%v", test.value, test.expected, test.ok, resetAt, ok)
+		}
+	}
+}
+
+// BenchmarkRateLimiterWaitExhausted measures waiting on a drained bucket; each Wait should
+// cost a single timer wakeup rather than a series of short polls
+func BenchmarkRateLimiterWaitExhausted(b *testing.B) {
+	limiter := NewRateLimiter(&RateLimitConfig{RequestsPerSecond: 1000, BurstSize: 1, Enabled: true})
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
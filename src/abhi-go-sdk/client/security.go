@@ -6,9 +6,15 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
 )
 
 // CredentialEncryption handles encryption and decryption of sensitive credentials
@@ -16,7 +22,22 @@ type CredentialEncryption struct {
 	key []byte
 }
 
-// NewCredentialEncryption creates a new credential encryption instance with a password
+// KDFScrypt names the scrypt key derivation function in SecureCredentials
+const KDFScrypt = "scrypt"
+
+// KDFParams holds the scrypt cost parameters a key was derived with
+type KDFParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// DefaultKDFParams are the scrypt parameters used for newly stored credentials
+var DefaultKDFParams = KDFParams{N: 1 << 15, R: 8, P: 1}
+
+// NewCredentialEncryption creates a new credential encryption instance keyed with the unsalted
+// SHA-256 hash of a password. It remains to read credentials stored before keys were salted;
+// new data should use NewSaltedCredentialEncryption.
 func NewCredentialEncryption(password string) *CredentialEncryption {
 	// Generate key from password using SHA-256
 	hash := sha256.Sum256([]byte(password))
@@ -25,6 +46,16 @@ func NewCredentialEncryption(password string) *CredentialEncryption {
 	}
 }
 
+// NewSaltedCredentialEncryption creates a credential encryption instance whose key is derived
+// from a password and salt with scrypt
+func NewSaltedCredentialEncryption(password string, salt []byte, params KDFParams) (*CredentialEncryption, error) {
+	key, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return &CredentialEncryption{key: key}, nil
+}
+
 // Encrypt encrypts plaintext using AES-GCM
 func (ce *CredentialEncryption) Encrypt(plaintext string) (string, error) {
 	if plaintext == "" {
@@ -100,9 +131,80 @@ func (ce *CredentialEncryption) Decrypt(ciphertext string) (string, error) {
 
 // SecureCredentials holds encrypted credentials
 type SecureCredentials struct {
-	EncryptedUsername string `json:"encrypted_username"`
-	EncryptedPassword string `json:"encrypted_password"`
-	Salt              string `json:"salt"`
+	EncryptedUsername string     `json:"encrypted_username"`
+	EncryptedPassword string     `json:"encrypted_password"`
+	Salt              string     `json:"salt"`
+	KDF               string     `json:"kdf,omitempty"`        // Empty for legacy entries keyed with unsalted SHA-256
+	KDFParams         *KDFParams `json:"kdf_params,omitempty"` // Parameters the key was derived with
+}
+
+// encryptCredentials encrypts a username and password under a key derived from
+// encryptionPassword with a fresh salt
+func encryptCredentials(encryptionPassword, username, password string) (*SecureCredentials, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	params := DefaultKDFParams
+	encryption, err := NewSaltedCredentialEncryption(encryptionPassword, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedUsername, err := encryption.Encrypt(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt username: %w", err)
+	}
+
+	encryptedPassword, err := encryption.Encrypt(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt password: %w", err)
+	}
+
+	return &SecureCredentials{
+		EncryptedUsername: encryptedUsername,
+		EncryptedPassword: encryptedPassword,
+		Salt:              base64.URLEncoding.EncodeToString(salt),
+		KDF:               KDFScrypt,
+		KDFParams:         &params,
+	}, nil
+}
+
+// decryptCredentials decrypts an entry with the key derived from encryptionPassword as the
+// entry records, falling back to the legacy SHA-256 key for entries without a KDF
+func decryptCredentials(encryptionPassword string, credentials *SecureCredentials) (username, password string, err error) {
+	var encryption *CredentialEncryption
+	switch credentials.KDF {
+	case "":
+		encryption = NewCredentialEncryption(encryptionPassword)
+	case KDFScrypt:
+		if credentials.KDFParams == nil {
+			return "", "", errors.New("missing scrypt parameters")
+		}
+		salt, err := base64.URLEncoding.DecodeString(credentials.Salt)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decode salt: %w", err)
+		}
+		encryption, err = NewSaltedCredentialEncryption(encryptionPassword, salt, *credentials.KDFParams)
+		if err != nil {
+			return "", "", err
+		}
+	default:
+		return "", "", fmt.Errorf("unsupported key derivation function %q", credentials.KDF)
+	}
+
+	username, err = encryption.Decrypt(credentials.EncryptedUsername)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt username: %w", err)
+	}
+
+	password, err = encryption.Decrypt(credentials.EncryptedPassword)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt password: %w", err)
+	}
+
+	return username, password, nil
 }
 
 // CredentialStore interface for different storage backends
@@ -113,9 +215,22 @@ type CredentialStore interface {
 	Exists(key string) bool
 }
 
-// MemoryCredentialStore implements in-memory credential storage
+// CredentialLister is implemented by credential stores that can enumerate their keys,
+// which is required to rotate the encryption password
+type CredentialLister interface {
+	Keys() ([]string, error)
+}
+
+// CredentialBatchStorer is implemented by credential stores that can store several entries in a
+// single write, so that RotatePassword replaces every entry at once instead of key by key
+type CredentialBatchStorer interface {
+	StoreAll(entries map[string]*SecureCredentials) error
+}
+
+// MemoryCredentialStore implements in-memory credential storage, safe for concurrent use
 type MemoryCredentialStore struct {
 	store map[string]*SecureCredentials
+	mutex sync.RWMutex
 }
 
 // NewMemoryCredentialStore creates a new in-memory credential store
@@ -133,13 +248,19 @@ func (ms *MemoryCredentialStore) Store(key string, credentials *SecureCredential
 	if credentials == nil {
 		return errors.New("credentials cannot be nil")
 	}
-	
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
 	ms.store[key] = credentials
 	return nil
 }
 
 // Retrieve retrieves credentials from memory
 func (ms *MemoryCredentialStore) Retrieve(key string) (*SecureCredentials, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
 	credentials, exists := ms.store[key]
 	if !exists {
 		return nil, fmt.Errorf("credentials not found for key: %s", key)
@@ -149,79 +270,226 @@ func (ms *MemoryCredentialStore) Retrieve(key string) (*SecureCredentials, error
 
 // Delete removes credentials from memory
 func (ms *MemoryCredentialStore) Delete(key string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
 	delete(ms.store, key)
 	return nil
 }
 
 // Exists checks if credentials exist for the given key
 func (ms *MemoryCredentialStore) Exists(key string) bool {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
 	_, exists := ms.store[key]
 	return exists
 }
 
-// CredentialManager manages secure credential operations
-type CredentialManager struct {
-	encryption *CredentialEncryption
-	store      CredentialStore
+// Keys returns the keys of all stored credentials
+func (ms *MemoryCredentialStore) Keys() ([]string, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	keys := make([]string, 0, len(ms.store))
+	for key := range ms.store {
+		keys = append(keys, key)
+	}
+	return keys, nil
 }
 
-// NewCredentialManager creates a new credential manager
-func NewCredentialManager(encryptionPassword string, store CredentialStore) *CredentialManager {
-	if store == nil {
-		store = NewMemoryCredentialStore()
+// FileCredentialStore persists encrypted credentials as JSON in a file readable only by its
+// owner, so they survive restarts. Access through one store is serialized; the file is replaced
+// atomically on every write, so other processes never read a partial file.
+type FileCredentialStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileCredentialStore creates a credential store backed by the file at path. The file and its
+// parent directories are created on the first write.
+func NewFileCredentialStore(path string) *FileCredentialStore {
+	return &FileCredentialStore{path: path}
+}
+
+// load reads all entries from the file; a missing file holds none. The caller must hold the mutex.
+func (fs *FileCredentialStore) load() (map[string]*SecureCredentials, error) {
+	entries := make(map[string]*SecureCredentials)
+
+	data, err := os.ReadFile(fs.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential file: %w", err)
 	}
 
-	return &CredentialManager{
-		encryption: NewCredentialEncryption(encryptionPassword),
-		store:      store,
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse credential file %s: %w", fs.path, err)
 	}
+	return entries, nil
 }
 
-// StoreCredentials encrypts and stores credentials
-func (cm *CredentialManager) StoreCredentials(key, username, password string) error {
-	encryptedUsername, err := cm.encryption.Encrypt(username)
+// save writes all entries to the file. The caller must hold the mutex.
+func (fs *FileCredentialStore) save(entries map[string]*SecureCredentials) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to encrypt username: %w", err)
+		return fmt.Errorf("failed to encode credentials: %w", err)
 	}
 
-	encryptedPassword, err := cm.encryption.Encrypt(password)
+	if err := os.MkdirAll(filepath.Dir(fs.path), 0700); err != nil {
+		return fmt.Errorf("failed to create credential directory: %w", err)
+	}
+	if err := writeFileAtomic(fs.path, data); err != nil {
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+	return nil
+}
+
+// Store stores credentials in the file
+func (fs *FileCredentialStore) Store(key string, credentials *SecureCredentials) error {
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	if credentials == nil {
+		return errors.New("credentials cannot be nil")
+	}
+
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	entries, err := fs.load()
 	if err != nil {
-		return fmt.Errorf("failed to encrypt password: %w", err)
+		return err
 	}
+	entries[key] = credentials
+	return fs.save(entries)
+}
 
-	// Generate salt for additional security
-	salt := make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
-		return fmt.Errorf("failed to generate salt: %w", err)
+// Retrieve retrieves credentials from the file
+func (fs *FileCredentialStore) Retrieve(key string) (*SecureCredentials, error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	entries, err := fs.load()
+	if err != nil {
+		return nil, err
+	}
+	credentials, exists := entries[key]
+	if !exists {
+		return nil, fmt.Errorf("credentials not found for key: %s", key)
 	}
+	return credentials, nil
+}
 
-	credentials := &SecureCredentials{
-		EncryptedUsername: encryptedUsername,
-		EncryptedPassword: encryptedPassword,
-		Salt:              base64.URLEncoding.EncodeToString(salt),
+// Delete removes credentials from the file
+func (fs *FileCredentialStore) Delete(key string) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	entries, err := fs.load()
+	if err != nil {
+		return err
+	}
+	if _, exists := entries[key]; !exists {
+		return nil
 	}
+	delete(entries, key)
+	return fs.save(entries)
+}
 
-	return cm.store.Store(key, credentials)
+// StoreAll stores several entries with a single atomic write of the file, so either all of them
+// are stored or none are
+func (fs *FileCredentialStore) StoreAll(entries map[string]*SecureCredentials) error {
+	for key, credentials := range entries {
+		if key == "" {
+			return errors.New("key cannot be empty")
+		}
+		if credentials == nil {
+			return fmt.Errorf("credentials cannot be nil for key: %s", key)
+		}
+	}
+
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	stored, err := fs.load()
+	if err != nil {
+		return err
+	}
+	for key, credentials := range entries {
+		stored[key] = credentials
+	}
+	return fs.save(stored)
 }
 
-// RetrieveCredentials retrieves and decrypts credentials
-func (cm *CredentialManager) RetrieveCredentials(key string) (username, password string, err error) {
-	credentials, err := cm.store.Retrieve(key)
+// Exists checks if credentials exist for the given key; an unreadable file holds none
+func (fs *FileCredentialStore) Exists(key string) bool {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	entries, err := fs.load()
 	if err != nil {
-		return "", "", err
+		return false
 	}
+	_, exists := entries[key]
+	return exists
+}
+
+// Keys returns the keys of all stored credentials
+func (fs *FileCredentialStore) Keys() ([]string, error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
 
-	username, err = cm.encryption.Decrypt(credentials.EncryptedUsername)
+	entries, err := fs.load()
 	if err != nil {
-		return "", "", fmt.Errorf("failed to decrypt username: %w", err)
+		return nil, err
+	}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// CredentialManager manages secure credential operations. Each entry is encrypted under a key
+// derived from the password with its own salt.
+type CredentialManager struct {
+	password string
+	store    CredentialStore
+}
+
+// NewCredentialManager creates a new credential manager
+func NewCredentialManager(encryptionPassword string, store CredentialStore) *CredentialManager {
+	if store == nil {
+		store = NewMemoryCredentialStore()
 	}
 
-	password, err = cm.encryption.Decrypt(credentials.EncryptedPassword)
+	return &CredentialManager{
+		password: encryptionPassword,
+		store:    store,
+	}
+}
+
+// StoreCredentials encrypts and stores credentials
+func (cm *CredentialManager) StoreCredentials(key, username, password string) error {
+	credentials, err := encryptCredentials(cm.password, username, password)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to decrypt password: %w", err)
+		return err
 	}
 
-	return username, password, nil
+	return cm.store.Store(key, credentials)
+}
+
+// RetrieveCredentials retrieves and decrypts credentials. Legacy entries keyed with unsalted
+// SHA-256 are still readable; RotatePassword re-encrypts them with salted keys.
+func (cm *CredentialManager) RetrieveCredentials(key string) (username, password string, err error) {
+	credentials, err := cm.store.Retrieve(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	return decryptCredentials(cm.password, credentials)
 }
 
 // DeleteCredentials removes stored credentials
@@ -234,11 +502,81 @@ func (cm *CredentialManager) CredentialsExist(key string) bool {
 	return cm.store.Exists(key)
 }
 
+// RotatePassword re-encrypts every stored entry from oldPassword to newPassword and switches the
+// manager to the new password. Nothing is written unless every entry decrypts with oldPassword.
+// Stores implementing CredentialBatchStorer, such as FileCredentialStore, receive every entry in
+// one write; for others, entries already rewritten are restored if the store fails part-way.
+// Every entry gets a fresh salt, which also upgrades legacy SHA-256 entries; rotating to the same
+// password does only that.
+func (cm *CredentialManager) RotatePassword(oldPassword, newPassword string) error {
+	if newPassword == "" {
+		return errors.New("new password cannot be empty")
+	}
+
+	lister, ok := cm.store.(CredentialLister)
+	if !ok {
+		return fmt.Errorf("credential store %T does not support listing keys", cm.store)
+	}
+
+	keys, err := lister.Keys()
+	if err != nil {
+		return fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	originals := make(map[string]*SecureCredentials, len(keys))
+	rotated := make(map[string]*SecureCredentials, len(keys))
+
+	for _, key := range keys {
+		credentials, err := cm.store.Retrieve(key)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve credentials %s: %w", key, err)
+		}
+
+		username, password, err := decryptCredentials(oldPassword, credentials)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s with old password: %w", key, err)
+		}
+
+		reencrypted, err := encryptCredentials(newPassword, username, password)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt %s: %w", key, err)
+		}
+
+		originals[key] = credentials
+		rotated[key] = reencrypted
+	}
+
+	if batch, ok := cm.store.(CredentialBatchStorer); ok {
+		if err := batch.StoreAll(rotated); err != nil {
+			return fmt.Errorf("failed to store rotated credentials: %w", err)
+		}
+		cm.password = newPassword
+		return nil
+	}
+
+	var written []string
+	for _, key := range keys {
+		if err := cm.store.Store(key, rotated[key]); err != nil {
+			// Roll back so every entry stays readable with the old password
+			for _, restoreKey := range written {
+				cm.store.Store(restoreKey, originals[restoreKey])
+			}
+			return fmt.Errorf("failed to store rotated credentials %s: %w", key, err)
+		}
+		written = append(written, key)
+	}
+
+	cm.password = newPassword
+	return nil
+}
+
 // ClearCredentials securely clears credentials from memory
 func (cm *CredentialManager) ClearCredentials() {
 	// This would clear any in-memory credentials
 	// Implementation depends on the store type
 	if memStore, ok := cm.store.(*MemoryCredentialStore); ok {
+		memStore.mutex.Lock()
+		defer memStore.mutex.Unlock()
 		for key := range memStore.store {
 			delete(memStore.store, key)
 		}
@@ -0,0 +1,391 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// failingCredentialStore wraps a memory store and fails to store a given key
+type failingCredentialStore struct {
+	*MemoryCredentialStore
+	failKey string
+}
+
+func (fs *failingCredentialStore) Store(key string, credentials *SecureCredentials) error {
+	if key == fs.failKey {
+		return errors.New("disk full")
+	}
+	return fs.MemoryCredentialStore.Store(key, credentials)
+}
+
+// seedCredentials stores a few credentials encrypted with the given password
+func seedCredentials(t *testing.T, manager *CredentialManager) map[string][2]string {
+	t.Helper()
+
+	entries := map[string][2]string{
+		"primary":   {"alice", "secret-1"},
+		"secondary": {"bob", "secret-2"},
+		"tertiary":  {"carol", "secret-3"},
+	}
+	for key, creds := range entries {
+		if err := manager.StoreCredentials(key, creds[0], creds[1]); err != nil {
+			t.Fatalf("Failed to store credentials: %v", err)
+		}
+	}
+	return entries
+}
+
+func TestRotatePasswordMemoryStore(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	manager := NewCredentialManager("old-password", store)
+	entries := seedCredentials(t, manager)
+
+	if err := manager.RotatePassword("old-password", "new-password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Both the rotated manager and a fresh one with the new password can read every entry
+	for _, reader := range []*CredentialManager{manager, NewCredentialManager("new-password", store)} {
+		for key, creds := range entries {
+			username, password, err := reader.RetrieveCredentials(key)
+			if err != nil {
+				t.Fatalf("Expected %s to decrypt with the new password, got %v", key, err)
+			}
+			if username != creds[0] || password != creds[1] {
+				t.Errorf("Expected %s to be %v, got %s/%s", key, creds, username, password)
+			}
+		}
+	}
+
+	if _, _, err := NewCredentialManager("old-password", store).RetrieveCredentials("primary"); err == nil {
+		t.Error("Expected the old password to no longer decrypt entries")
+	}
+}
+
+func TestRotatePasswordWrongOldPassword(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	manager := NewCredentialManager("old-password", store)
+	seedCredentials(t, manager)
+
+	if err := manager.RotatePassword("not-the-password", "new-password"); err == nil {
+		t.Fatal("Expected an error for the wrong old password")
+	}
+
+	if _, _, err := manager.RetrieveCredentials("primary"); err != nil {
+		t.Errorf("Expected entries to remain readable with the old password, got %v", err)
+	}
+}
+
+func TestRotatePasswordRollsBackOnStoreFailure(t *testing.T) {
+	store := &failingCredentialStore{MemoryCredentialStore: NewMemoryCredentialStore()}
+	manager := NewCredentialManager("old-password", store)
+	entries := seedCredentials(t, manager)
+	store.failKey = "secondary"
+
+	if err := manager.RotatePassword("old-password", "new-password"); err == nil {
+		t.Fatal("Expected an error when the store fails")
+	}
+
+	for key, creds := range entries {
+		username, password, err := manager.RetrieveCredentials(key)
+		if err != nil || username != creds[0] || password != creds[1] {
+			t.Errorf("Expected %s to remain readable with the old password, got %s/%s (%v)", key, username, password, err)
+		}
+	}
+}
+
+// countingFileStore counts the writes made through a file credential store
+type countingFileStore struct {
+	*FileCredentialStore
+	stores, storeAlls int
+}
+
+func (cs *countingFileStore) Store(key string, credentials *SecureCredentials) error {
+	cs.stores++
+	return cs.FileCredentialStore.Store(key, credentials)
+}
+
+func (cs *countingFileStore) StoreAll(entries map[string]*SecureCredentials) error {
+	cs.storeAlls++
+	return cs.FileCredentialStore.StoreAll(entries)
+}
+
+func TestRotatePasswordFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	manager := NewCredentialManager("old-password", NewFileCredentialStore(path))
+	entries := seedCredentials(t, manager)
+
+	store := &countingFileStore{FileCredentialStore: NewFileCredentialStore(path)}
+	manager = NewCredentialManager("old-password", store)
+	if err := manager.RotatePassword("old-password", "new-password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if store.storeAlls != 1 || store.stores != 0 {
+		t.Errorf("Expected a single write of every entry, got %d batch and %d single writes", store.storeAlls, store.stores)
+	}
+
+	// A manager in a later process reads every entry with the new password
+	reopened := NewCredentialManager("new-password", NewFileCredentialStore(path))
+	for key, creds := range entries {
+		username, password, err := reopened.RetrieveCredentials(key)
+		if err != nil || username != creds[0] || password != creds[1] {
+			t.Errorf("Expected %s to be %v with the new password, got %s/%s (%v)", key, creds, username, password, err)
+		}
+	}
+
+	leftovers, _ := filepath.Glob(path + ".tmp-*")
+	if len(leftovers) != 0 {
+		t.Errorf("Expected no temporary files to remain, got %v", leftovers)
+	}
+}
+
+func TestRotatePasswordRequiresListableStore(t *testing.T) {
+	manager := NewCredentialManager("old-password", struct{ CredentialStore }{NewMemoryCredentialStore()})
+
+	if err := manager.RotatePassword("old-password", "new-password"); err == nil {
+		t.Error("Expected an error for a store that can't list its keys")
+	}
+}
+
+func TestStoreCredentialsRoundTrip(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	manager := NewCredentialManager("encryption-password", store)
+
+	if err := manager.StoreCredentials("primary", "alice", "secret-1"); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+
+	stored, _ := store.Retrieve("primary")
+	if stored.KDF != KDFScrypt || stored.KDFParams == nil || *stored.KDFParams != DefaultKDFParams {
+		t.Errorf("Expected scrypt parameters to be stored with the entry, got %q %+v", stored.KDF, stored.KDFParams)
+	}
+
+	username, password, err := NewCredentialManager("encryption-password", store).RetrieveCredentials("primary")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if username != "alice" || password != "secret-1" {
+		t.Errorf("Expected alice/secret-1, got %s/%s", username, password)
+	}
+
+	if _, _, err := NewCredentialManager("wrong-password", store).RetrieveCredentials("primary"); err == nil {
+		t.Error("Expected the wrong password to fail")
+	}
+}
+
+func TestStoreCredentialsSaltsEachEntry(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	manager := NewCredentialManager("encryption-password", store)
+	manager.StoreCredentials("first", "alice", "secret")
+	manager.StoreCredentials("second", "alice", "secret")
+
+	first, _ := store.Retrieve("first")
+	second, _ := store.Retrieve("second")
+	if first.Salt == second.Salt {
+		t.Fatal("Expected each entry to get its own salt")
+	}
+
+	keys := make([][]byte, 0, 2)
+	for _, credentials := range []*SecureCredentials{first, second} {
+		salt, _ := base64.URLEncoding.DecodeString(credentials.Salt)
+		encryption, err := NewSaltedCredentialEncryption("encryption-password", salt, *credentials.KDFParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, encryption.key)
+	}
+	if bytes.Equal(keys[0], keys[1]) {
+		t.Error("Expected the same password to derive different keys for different entries")
+	}
+
+	legacy := NewCredentialEncryption("encryption-password")
+	if bytes.Equal(keys[0], legacy.key) {
+		t.Error("Expected the salted key to differ from the unsalted SHA-256 key")
+	}
+}
+
+func TestRetrieveLegacyCredentials(t *testing.T) {
+	store := NewMemoryCredentialStore()
+
+	// An entry written before keys were salted: the salt was stored but unused
+	legacy := NewCredentialEncryption("encryption-password")
+	encryptedUsername, _ := legacy.Encrypt("alice")
+	encryptedPassword, _ := legacy.Encrypt("secret-1")
+	store.Store("primary", &SecureCredentials{
+		EncryptedUsername: encryptedUsername,
+		EncryptedPassword: encryptedPassword,
+		Salt:              base64.URLEncoding.EncodeToString([]byte("decorative-salt")),
+	})
+
+	manager := NewCredentialManager("encryption-password", store)
+	username, password, err := manager.RetrieveCredentials("primary")
+	if err != nil || username != "alice" || password != "secret-1" {
+		t.Fatalf("Expected the legacy entry to decrypt, got %s/%s (%v)", username, password, err)
+	}
+
+	// Rotating to the same password upgrades the entry
+	if err := manager.RotatePassword("encryption-password", "encryption-password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	upgraded, _ := store.Retrieve("primary")
+	if upgraded.KDF != KDFScrypt {
+		t.Errorf("Expected the entry to be upgraded to scrypt, got %q", upgraded.KDF)
+	}
+	if username, password, err := manager.RetrieveCredentials("primary"); err != nil || username != "alice" || password != "secret-1" {
+		t.Errorf("Expected the upgraded entry to decrypt, got %s/%s (%v)", username, password, err)
+	}
+}
+
+func TestRetrieveCredentialsUnknownKDF(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	store.Store("primary", &SecureCredentials{KDF: "bcrypt"})
+
+	if _, _, err := NewCredentialManager("encryption-password", store).RetrieveCredentials("primary"); err == nil {
+		t.Error("Expected an error for an unsupported key derivation function")
+	}
+}
+
+func TestFileCredentialStorePersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "dir", "credentials.json")
+	entry := &SecureCredentials{EncryptedUsername: "user", EncryptedPassword: "pass", Salt: "salt"}
+
+	store := NewFileCredentialStore(path)
+	if store.Exists("primary") {
+		t.Error("Expected a new store to be empty")
+	}
+	if err := store.Store("primary", entry); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+	if err := store.Store("secondary", entry); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected the credential file to be created, got %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected 0600 permissions, got %o", perm)
+	}
+
+	reopened := NewFileCredentialStore(path)
+	retrieved, err := reopened.Retrieve("primary")
+	if err != nil {
+		t.Fatalf("Expected the entry to survive reopening, got %v", err)
+	}
+	if *retrieved != *entry {
+		t.Errorf("Expected %+v, got %+v", entry, retrieved)
+	}
+
+	if err := reopened.Delete("primary"); err != nil {
+		t.Fatalf("Failed to delete credentials: %v", err)
+	}
+	if NewFileCredentialStore(path).Exists("primary") {
+		t.Error("Expected the deleted entry to be gone after reopening")
+	}
+	if !NewFileCredentialStore(path).Exists("secondary") {
+		t.Error("Expected the other entry to remain")
+	}
+	if _, err := reopened.Retrieve("primary"); err == nil {
+		t.Error("Expected an error retrieving a deleted entry")
+	}
+}
+
+func TestFileCredentialStoreWithManager(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	config := DefaultConfig().SetCredentialStore(NewFileCredentialStore(path))
+
+	manager := NewCredentialManager("encryption-password", config.Security.CredentialStore)
+	if err := manager.StoreCredentials("primary", "alice", "secret-1"); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+
+	// A manager in a later process reads the same file
+	username, password, err := NewCredentialManager("encryption-password", NewFileCredentialStore(path)).RetrieveCredentials("primary")
+	if err != nil || username != "alice" || password != "secret-1" {
+		t.Errorf("Expected alice/secret-1, got %s/%s (%v)", username, password, err)
+	}
+}
+
+func TestFileCredentialStoreCorruptedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileCredentialStore(path)
+	if _, err := store.Retrieve("primary"); err == nil {
+		t.Error("Expected an error reading a corrupted file")
+	}
+	if err := store.Store("primary", &SecureCredentials{}); err == nil {
+		t.Error("Expected an error rather than overwriting a corrupted file")
+	}
+	if _, err := store.Keys(); err == nil {
+		t.Error("Expected an error listing a corrupted file")
+	}
+	if store.Exists("primary") {
+		t.Error("Expected no entries in a corrupted file")
+	}
+}
+
+func TestMemoryCredentialStoreConcurrentAccess(t *testing.T) {
+	store := NewMemoryCredentialStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := store.Store(fmt.Sprintf("key-%d", i), &SecureCredentials{Salt: "salt"}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		// Listing while other goroutines write must not race with them
+		go func() {
+			defer wg.Done()
+			if _, err := store.Keys(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	keys, err := store.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 20 {
+		t.Errorf("Expected all 20 concurrent writes to be kept, got %d", len(keys))
+	}
+}
+
+func TestFileCredentialStoreConcurrentAccess(t *testing.T) {
+	store := NewFileCredentialStore(filepath.Join(t.TempDir(), "credentials.json"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := store.Store(fmt.Sprintf("key-%d", i), &SecureCredentials{Salt: "salt"}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	keys, err := store.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 20 {
+		t.Errorf("Expected all 20 concurrent writes to be kept, got %d", len(keys))
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -11,19 +12,117 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"abhi-go-sdk/errors"
 )
 
+// NonceHeader carries the random per-request value that makes each signature unique
+const NonceHeader = "X-Nonce"
+
+// DefaultSignatureWindow is how far a signed request's timestamp may be from the verifier's clock
+const DefaultSignatureWindow = 5 * time.Minute
+
+// DefaultSignedHeaders are the headers included in the signature unless others are configured
+var DefaultSignedHeaders = []string{"authorization", "content-type", "x-timestamp"}
+
 // RequestSigner handles HMAC-SHA256 request signing for additional security
 type RequestSigner struct {
-	secret []byte
+	secret        []byte
+	signedHeaders []string
+	window        time.Duration
+	nonces        NonceCache
 }
 
-// NewRequestSigner creates a new request signer with the given secret
-func NewRequestSigner(secret string) *RequestSigner {
+// NewRequestSigner creates a new request signer with the given secret. The signature covers
+// signedHeaders in the given order, or DefaultSignedHeaders if none are given; the verifier must
+// be configured with the same list.
+func NewRequestSigner(secret string, signedHeaders ...string) *RequestSigner {
+	if len(signedHeaders) == 0 {
+		signedHeaders = DefaultSignedHeaders
+	}
+
+	headers := make([]string, len(signedHeaders))
+	for i, name := range signedHeaders {
+		headers[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+
 	return &RequestSigner{
-		secret: []byte(secret),
+		secret:        []byte(secret),
+		signedHeaders: headers,
+		window:        DefaultSignatureWindow,
+		nonces:        NewMemoryNonceCache(),
+	}
+}
+
+// SignedHeaders returns the names of the headers the signature covers, in order
+func (rs *RequestSigner) SignedHeaders() []string {
+	return append([]string(nil), rs.signedHeaders...)
+}
+
+// SetTimestampWindow sets how far a request's timestamp may be from the current time for
+// VerifySignature to accept it; zero or negative restores DefaultSignatureWindow
+func (rs *RequestSigner) SetTimestampWindow(window time.Duration) *RequestSigner {
+	if window <= 0 {
+		window = DefaultSignatureWindow
+	}
+	rs.window = window
+	return rs
+}
+
+// SetNonceCache sets the cache VerifySignature uses to reject replayed nonces, e.g. one shared
+// by several verifying instances
+func (rs *RequestSigner) SetNonceCache(cache NonceCache) *RequestSigner {
+	rs.nonces = cache
+	return rs
+}
+
+// NonceCache remembers the nonces of verified requests so a replayed request is rejected
+type NonceCache interface {
+	// Seen reports whether nonce was already recorded; if not, it records the nonce until expiresAt
+	Seen(nonce string, expiresAt time.Time) bool
+}
+
+// MemoryNonceCache implements an in-memory nonce cache that forgets nonces once they expire
+type MemoryNonceCache struct {
+	nonces map[string]time.Time
+	mutex  sync.Mutex
+}
+
+// NewMemoryNonceCache creates a new in-memory nonce cache
+func NewMemoryNonceCache() *MemoryNonceCache {
+	return &MemoryNonceCache{nonces: make(map[string]time.Time)}
+}
+
+// Seen reports whether nonce was already recorded, recording it otherwise
+func (mc *MemoryNonceCache) Seen(nonce string, expiresAt time.Time) bool {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	now := time.Now()
+	if seenUntil, ok := mc.nonces[nonce]; ok && now.Before(seenUntil) {
+		return true
 	}
+
+	// Forget expired nonces; their requests now fail the timestamp check instead
+	for seen, seenUntil := range mc.nonces {
+		if !now.Before(seenUntil) {
+			delete(mc.nonces, seen)
+		}
+	}
+
+	mc.nonces[nonce] = expiresAt
+	return false
+}
+
+// generateNonce returns a random hex nonce
+func generateNonce() (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(nonce), nil
 }
 
 // SignRequest adds authentication signature to the request
@@ -36,6 +135,13 @@ func (rs *RequestSigner) SignRequest(req *http.Request, body []byte) error {
 	timestamp := time.Now().Unix()
 	req.Header.Set("X-Timestamp", strconv.FormatInt(timestamp, 10))
 
+	// Generate a nonce so the request can't be replayed within the timestamp window
+	nonce, err := generateNonce()
+	if err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	req.Header.Set(NonceHeader, nonce)
+
 	// Create string to sign
 	stringToSign := rs.createStringToSign(req, body, timestamp)
 
@@ -52,8 +158,8 @@ func (rs *RequestSigner) SignRequest(req *http.Request, body []byte) error {
 func (rs *RequestSigner) createStringToSign(req *http.Request, body []byte, timestamp int64) string {
 	var parts []string
 
-	// HTTP method
-	parts = append(parts, req.Method)
+	// HTTP method, using the real method of requests tunneled through POST
+	parts = append(parts, effectiveMethod(req))
 
 	// Path
 	parts = append(parts, req.URL.Path)
@@ -65,7 +171,7 @@ func (rs *RequestSigner) createStringToSign(req *http.Request, body []byte, time
 		parts = append(parts, "")
 	}
 
-	// Headers (specific headers only, sorted)
+	// Headers (the configured headers only, in order)
 	parts = append(parts, rs.canonicalizeHeaders(req))
 
 	// Body hash
@@ -75,9 +181,22 @@ func (rs *RequestSigner) createStringToSign(req *http.Request, body []byte, time
 	// Timestamp
 	parts = append(parts, strconv.FormatInt(timestamp, 10))
 
+	// Nonce
+	parts = append(parts, req.Header.Get(NonceHeader))
+
 	return strings.Join(parts, "\n")
 }
 
+// effectiveMethod returns the method the server will act on, honoring X-HTTP-Method-Override on POST
+func effectiveMethod(req *http.Request) string {
+	if req.Method == http.MethodPost {
+		if override := req.Header.Get(MethodOverrideHeader); override != "" {
+			return strings.ToUpper(override)
+		}
+	}
+	return req.Method
+}
+
 // canonicalizeQuery sorts query parameters
 func (rs *RequestSigner) canonicalizeQuery(query string) string {
 	if query == "" {
@@ -90,18 +209,11 @@ func (rs *RequestSigner) canonicalizeQuery(query string) string {
 	return strings.Join(params, "&")
 }
 
-// canonicalizeHeaders includes specific headers in the signature
+// canonicalizeHeaders includes the configured headers in the signature
 func (rs *RequestSigner) canonicalizeHeaders(req *http.Request) string {
-	// Include specific headers that should be signed
-	headersToSign := []string{
-		"authorization",
-		"content-type",
-		"x-timestamp",
-	}
-
 	var headerParts []string
 
-	for _, headerName := range headersToSign {
+	for _, headerName := range rs.signedHeaders {
 		value := req.Header.Get(headerName)
 		if value != "" {
 			headerParts = append(headerParts, fmt.Sprintf("%s:%s", headerName, strings.TrimSpace(value)))
@@ -118,26 +230,40 @@ func (rs *RequestSigner) generateSignature(stringToSign string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// VerifySignature verifies a request signature (useful for testing)
+// VerifySignature verifies a request signature the way the server does: the timestamp must be
+// within the window, the signature must match, and the nonce must not have been seen before
 func (rs *RequestSigner) VerifySignature(req *http.Request, body []byte, signature string) bool {
 	if rs == nil {
 		return true // No verification needed
 	}
+	return rs.verify(req, body, signature) == nil
+}
 
+// verify performs the VerifySignature checks, reporting why a request was rejected
+func (rs *RequestSigner) verify(req *http.Request, body []byte, signature string) error {
 	timestampStr := req.Header.Get("X-Timestamp")
 	if timestampStr == "" {
-		return false
+		return fmt.Errorf("missing X-Timestamp header: %w", errors.ErrInvalidSignature)
 	}
 
 	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
 	if err != nil {
-		return false
+		return fmt.Errorf("malformed X-Timestamp header %q: %w", timestampStr, errors.ErrInvalidSignature)
+	}
+
+	nonce := req.Header.Get(NonceHeader)
+	if nonce == "" {
+		return fmt.Errorf("missing %s header: %w", NonceHeader, errors.ErrInvalidSignature)
 	}
 
-	// Check timestamp is within acceptable range (5 minutes)
+	// Check timestamp is within the acceptable range
+	window := rs.window
+	if window <= 0 {
+		window = DefaultSignatureWindow
+	}
 	now := time.Now().Unix()
-	if abs(now-timestamp) > 300 {
-		return false
+	if abs(now-timestamp) > int64(window/time.Second) {
+		return fmt.Errorf("timestamp %d is more than %s from now: %w", timestamp, window, errors.ErrStaleTimestamp)
 	}
 
 	// Generate expected signature
@@ -145,7 +271,15 @@ func (rs *RequestSigner) VerifySignature(req *http.Request, body []byte, signatu
 	expectedSignature := rs.generateSignature(stringToSign)
 
 	// Compare signatures (constant time comparison)
-	return hmac.Equal([]byte(signature), []byte(expectedSignature))
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return errors.ErrInvalidSignature
+	}
+
+	// Only a genuine request records its nonce; after the window its timestamp is rejected anyway
+	if rs.nonces != nil && rs.nonces.Seen(nonce, time.Unix(timestamp, 0).Add(window)) {
+		return fmt.Errorf("nonce %s: %w", nonce, errors.ErrReplayedRequest)
+	}
+	return nil
 }
 
 // signingTransport wraps an HTTP transport with request signing
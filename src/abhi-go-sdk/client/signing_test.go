@@ -0,0 +1,226 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/models"
+)
+
+func TestMethodOverrideTunnelsThroughPost(t *testing.T) {
+	signer := NewRequestSigner("signing-secret")
+
+	var method, override string
+	var verified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		method = r.Method
+		override = r.Header.Get(MethodOverrideHeader)
+		verified = signer.VerifySignature(r, body, r.Header.Get("X-Signature"))
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200})
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "test", "pass").
+		SetMethodOverride(true).
+		EnableRequestSigning("signing-secret")
+	client := New(config)
+
+	if err := client.DELETE(context.Background(), "/employees/emp-1", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if method != http.MethodPost || override != http.MethodDelete {
+		t.Errorf("Expected POST with override DELETE, got %s with override %q", method, override)
+	}
+	if !verified {
+		t.Error("Expected the signature to verify against the tunneled request")
+	}
+
+	if err := client.GET(context.Background(), "/employees/emp-1", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if method != http.MethodGet || override != "" {
+		t.Errorf("Expected GET to be sent as-is, got %s with override %q", method, override)
+	}
+}
+
+func TestSignedMethodUsesRealMethod(t *testing.T) {
+	signer := NewRequestSigner("signing-secret")
+
+	tunneled, _ := http.NewRequest(http.MethodPost, "https://example.com/employees/emp-1", nil)
+	tunneled.Header.Set(MethodOverrideHeader, "put")
+	direct, _ := http.NewRequest(http.MethodPut, "https://example.com/employees/emp-1", nil)
+
+	if signer.createStringToSign(tunneled, nil, 1700000000) != signer.createStringToSign(direct, nil, 1700000000) {
+		t.Error("Expected a tunneled PUT to be signed the same as a direct PUT")
+	}
+}
+
+// newSignedRequest creates a request signed by signer
+func newSignedRequest(t *testing.T, signer *RequestSigner, body []byte) *http.Request {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/transactions?b=2&a=1", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err := signer.SignRequest(req, body); err != nil {
+		t.Fatalf("Failed to sign request: %v", err)
+	}
+	return req
+}
+
+func TestVerifySignatureRejectsReplayedNonce(t *testing.T) {
+	signer := NewRequestSigner("signing-secret")
+	verifier := NewRequestSigner("signing-secret")
+	body := []byte(`{"amount":500}`)
+
+	req := newSignedRequest(t, signer, body)
+	if req.Header.Get(NonceHeader) == "" {
+		t.Fatal("Expected a nonce header")
+	}
+	if !verifier.VerifySignature(req, body, req.Header.Get("X-Signature")) {
+		t.Fatal("Expected a fresh request to verify")
+	}
+	if verifier.VerifySignature(req, body, req.Header.Get("X-Signature")) {
+		t.Error("Expected the replayed request to be rejected")
+	}
+
+	// Signing again gives a new nonce, so the same call is accepted
+	again := newSignedRequest(t, signer, body)
+	if again.Header.Get(NonceHeader) == req.Header.Get(NonceHeader) {
+		t.Error("Expected each request to get its own nonce")
+	}
+	if !verifier.VerifySignature(again, body, again.Header.Get("X-Signature")) {
+		t.Error("Expected a request with a fresh nonce to verify")
+	}
+}
+
+func TestVerifySignatureNonceIsSigned(t *testing.T) {
+	signer := NewRequestSigner("signing-secret")
+	body := []byte(`{"amount":500}`)
+
+	req := newSignedRequest(t, signer, body)
+	req.Header.Set(NonceHeader, "forged-nonce")
+	if NewRequestSigner("signing-secret").VerifySignature(req, body, req.Header.Get("X-Signature")) {
+		t.Error("Expected a swapped nonce to invalidate the signature")
+	}
+
+	req.Header.Del(NonceHeader)
+	if NewRequestSigner("signing-secret").VerifySignature(req, body, req.Header.Get("X-Signature")) {
+		t.Error("Expected a request without a nonce to be rejected")
+	}
+}
+
+func TestVerifySignatureForgedRequestDoesNotConsumeNonce(t *testing.T) {
+	signer := NewRequestSigner("signing-secret")
+	verifier := NewRequestSigner("signing-secret")
+	body := []byte(`{"amount":500}`)
+
+	req := newSignedRequest(t, signer, body)
+	if verifier.VerifySignature(req, body, "bad-signature") {
+		t.Fatal("Expected a bad signature to be rejected")
+	}
+	if !verifier.VerifySignature(req, body, req.Header.Get("X-Signature")) {
+		t.Error("Expected the genuine request to verify after a forged attempt with its nonce")
+	}
+}
+
+func TestVerifySignatureTimestampWindow(t *testing.T) {
+	signer := NewRequestSigner("signing-secret")
+	body := []byte(`{}`)
+
+	// Sign as if the request was made two minutes ago
+	req := newSignedRequest(t, signer, body)
+	timestamp := time.Now().Add(-2 * time.Minute).Unix()
+	req.Header.Set("X-Timestamp", strconv.FormatInt(timestamp, 10))
+	signature := signer.generateSignature(signer.createStringToSign(req, body, timestamp))
+
+	if NewRequestSigner("signing-secret").SetTimestampWindow(time.Minute).VerifySignature(req, body, signature) {
+		t.Error("Expected a two-minute-old request to fall outside a one-minute window")
+	}
+	if !NewRequestSigner("signing-secret").VerifySignature(req, body, signature) {
+		t.Error("Expected a two-minute-old request to fall inside the default window")
+	}
+}
+
+func TestMemoryNonceCacheExpiry(t *testing.T) {
+	cache := NewMemoryNonceCache()
+
+	if cache.Seen("expired", time.Now().Add(-time.Second)) {
+		t.Error("Expected a new nonce not to be seen")
+	}
+	if cache.Seen("expired", time.Now().Add(time.Minute)) {
+		t.Error("Expected an expired nonce to be forgotten")
+	}
+	if !cache.Seen("expired", time.Now().Add(time.Minute)) {
+		t.Error("Expected a recorded nonce to be seen")
+	}
+}
+
+func TestSignedHeadersIncludeCustomHeader(t *testing.T) {
+	signedHeaders := []string{"authorization", "content-type", "x-timestamp", "X-Partner-Id"}
+	verifier := NewRequestSigner("signing-secret", signedHeaders...)
+	defaultVerifier := NewRequestSigner("signing-secret")
+
+	var verified, verifiedWithDefaults bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/auth/login" {
+			json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]interface{}{"token": "test-token"}})
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		signature := r.Header.Get("X-Signature")
+		verifiedWithDefaults = defaultVerifier.VerifySignature(r, body, signature)
+		verified = verifier.VerifySignature(r, body, signature)
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200})
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "test", "pass").EnableRequestSigning("signing-secret", signedHeaders...)
+	config.DefaultHeaders = map[string]string{"X-Partner-Id": "partner-42"}
+	client := New(config)
+
+	if err := client.GET(context.Background(), "/employees", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !verified {
+		t.Error("Expected the signature to verify with the same header list")
+	}
+	if verifiedWithDefaults {
+		t.Error("Expected the signature not to verify without the partner header in the list")
+	}
+}
+
+func TestSignedHeadersDetectTampering(t *testing.T) {
+	signer := NewRequestSigner("signing-secret", "x-api-key")
+	body := []byte(`{}`)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/employees", nil)
+	req.Header.Set("X-Api-Key", "key-1")
+	if err := signer.SignRequest(req, body); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := signer.SignedHeaders(); len(got) != 1 || got[0] != "x-api-key" {
+		t.Errorf("Expected only x-api-key to be signed, got %v", got)
+	}
+
+	req.Header.Set("X-Api-Key", "key-2")
+	if NewRequestSigner("signing-secret", "x-api-key").VerifySignature(req, body, req.Header.Get("X-Signature")) {
+		t.Error("Expected a changed signed header to invalidate the signature")
+	}
+}
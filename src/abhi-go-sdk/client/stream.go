@@ -0,0 +1,303 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"abhi-go-sdk/errors"
+)
+
+// Event represents a server-sent event
+type Event struct {
+	ID    string
+	Type  string // The event field; empty means "message"
+	Data  string // Data lines joined with newlines
+	Retry time.Duration
+}
+
+// StreamOptions configures reconnection for Client.Stream. Reconnection delays start at
+// InitialBackoff, grow by Multiplier after each failed attempt up to MaxBackoff, and are
+// randomly shortened by up to Jitter. Receiving an event resets the backoff.
+type StreamOptions struct {
+	InitialBackoff time.Duration // First reconnection delay, unless the server sent a retry field (default 1s)
+	MaxBackoff     time.Duration // Upper bound for the reconnection delay (default 30s)
+	Multiplier     float64       // Growth factor applied after each failed attempt (default 2)
+	Jitter         float64       // Fraction of each delay to randomize, 0-1 (default 0.2, negative disables)
+	MaxRetries     int           // Consecutive reconnections without an event before giving up; zero means unlimited
+	MaxElapsed     time.Duration // Time without an event before giving up; zero means unlimited
+	LastEventID    string        // Resume after this event ID on the first connection
+
+	// OnReconnect, if set, is called before each reconnection with the attempt number since the
+	// last received event, the delay before reconnecting, and the error that ended the connection
+	OnReconnect func(attempt int, delay time.Duration, err error)
+}
+
+// DefaultStreamOptions returns the default reconnection configuration
+func DefaultStreamOptions() StreamOptions {
+	return StreamOptions{
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// withDefaults fills unset options with their defaults
+func (o StreamOptions) withDefaults() StreamOptions {
+	defaults := DefaultStreamOptions()
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = defaults.InitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaults.MaxBackoff
+	}
+	if o.Multiplier <= 0 {
+		o.Multiplier = defaults.Multiplier
+	}
+	if o.Jitter == 0 {
+		o.Jitter = defaults.Jitter
+	}
+	if o.Jitter < 0 {
+		o.Jitter = 0
+	}
+	if o.Jitter > 1 {
+		o.Jitter = 1
+	}
+	return o
+}
+
+// errStreamEnded is reported to OnReconnect when the server closes the stream cleanly
+var errStreamEnded = stderrors.New("stream ended by server")
+
+// Stream subscribes to a server-sent event stream, calling handler for each event in order.
+// Dropped connections are re-established with exponential backoff, sending Last-Event-ID so the
+// server resumes after the last event handled. Stream returns when the context is done, the
+// handler returns an error, the server rejects the request with a non-retryable status, or the
+// retry limits are exhausted.
+func (c *Client) Stream(ctx context.Context, endpoint string, opts StreamOptions, handler func(Event) error) error {
+	opts = opts.withDefaults()
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The configured client timeout would cut off long-lived streams, so share only its transport
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+
+	lastEventID := opts.LastEventID
+	baseDelay := opts.InitialBackoff
+	attempt := 0
+	lastProgress := time.Now()
+
+	for {
+		received, err := c.streamOnce(ctx, streamClient, endpoint, organizationID, lastEventID, func(event Event) error {
+			if event.Retry > 0 {
+				baseDelay = event.Retry
+			}
+			// Skip an event the server replays on resumption so it isn't handled twice
+			if event.ID != "" && event.ID == lastEventID {
+				return nil
+			}
+			if event.ID != "" {
+				lastEventID = event.ID
+			}
+			if event.Data == "" && event.Type == "" {
+				return nil
+			}
+			return handler(event)
+		})
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if handlerErr, ok := err.(*streamHandlerError); ok {
+			return handlerErr.err
+		}
+		if apiErr, ok := err.(*errors.APIError); ok && !isRetryableStreamStatus(apiErr.StatusCode) {
+			return apiErr
+		}
+		if err == nil {
+			err = errStreamEnded
+		}
+
+		if received {
+			attempt = 0
+			lastProgress = time.Now()
+		}
+		attempt++
+
+		if opts.MaxRetries > 0 && attempt > opts.MaxRetries {
+			return &errors.NetworkError{
+				Operation: fmt.Sprintf("stream %s: gave up after %d reconnection attempts", endpoint, opts.MaxRetries),
+				Err:       err,
+			}
+		}
+		if opts.MaxElapsed > 0 && time.Since(lastProgress) >= opts.MaxElapsed {
+			return &errors.NetworkError{
+				Operation: fmt.Sprintf("stream %s: gave up after %s without events", endpoint, opts.MaxElapsed),
+				Err:       err,
+			}
+		}
+
+		delay := streamBackoff(baseDelay, opts, attempt, rng)
+		if opts.OnReconnect != nil {
+			opts.OnReconnect(attempt, delay, err)
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// streamHandlerError marks an error returned by the caller's handler, which ends the stream
+type streamHandlerError struct {
+	err error
+}
+
+func (e *streamHandlerError) Error() string {
+	return e.err.Error()
+}
+
+// streamOnce runs a single stream connection until it ends, reporting whether any event was received
+func (c *Client) streamOnce(ctx context.Context, streamClient *http.Client, endpoint, organizationID, lastEventID string, dispatch func(Event) error) (bool, error) {
+	authName, authValue, err := c.authHeader(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	streamURL, err := joinURL(c.config.BaseURL, endpoint)
+	if err != nil {
+		return false, fmt.Errorf("failed to build stream URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create stream request: %w", err)
+	}
+	req.Header.Set(authName, authValue)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Set("User-Agent", c.config.userAgent())
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	if organizationID != "" {
+		req.Header.Set(OrganizationIDHeader, organizationID)
+	}
+
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return false, &errors.NetworkError{
+			Operation: fmt.Sprintf("GET %s", endpoint),
+			Err:       err,
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.authManager.ClearToken()
+		}
+		apiErr := errors.NewAPIError(resp.StatusCode, "Stream request failed", string(body), endpoint)
+		setRetryAfter(apiErr, resp)
+		return false, apiErr
+	}
+
+	received := false
+	err = readEvents(resp.Body, func(event Event) error {
+		received = true
+		if err := dispatch(event); err != nil {
+			return &streamHandlerError{err: err}
+		}
+		return nil
+	})
+	return received, err
+}
+
+// readEvents parses a text/event-stream body, dispatching each complete event. Events cut off
+// by the end of the body are discarded, as the SSE specification requires.
+func readEvents(body io.Reader, dispatch func(Event) error) error {
+	reader := bufio.NewReader(body)
+
+	var event Event
+	var data []string
+	pending := false
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		// A blank line dispatches the buffered event
+		if line == "" {
+			if pending {
+				event.Data = strings.Join(data, "\n")
+				if err := dispatch(event); err != nil {
+					return err
+				}
+			}
+			event, data, pending = Event{}, nil, false
+			continue
+		}
+
+		// Lines starting with a colon are comments, often used as keep-alives
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		pending = true
+
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				event.Retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// isRetryableStreamStatus returns true if a stream rejected with the status code should be reconnected
+func isRetryableStreamStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return statusCode >= 500
+}
+
+// streamBackoff returns the jittered delay before the given reconnection attempt
+func streamBackoff(base time.Duration, opts StreamOptions, attempt int, rng *rand.Rand) time.Duration {
+	delay := time.Duration(float64(base) * math.Pow(opts.Multiplier, float64(attempt-1)))
+	if delay > opts.MaxBackoff || delay <= 0 {
+		delay = opts.MaxBackoff
+	}
+	if opts.Jitter > 0 {
+		delay -= time.Duration(opts.Jitter * rng.Float64() * float64(delay))
+	}
+	return delay
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/errors"
+)
+
+// newStreamTestServer serves logins and passes other requests to handler
+func newStreamTestServer(handler http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"statusCode":200,"data":{"token":"test-token"}}`))
+			return
+		}
+		handler(w, r)
+	}))
+}
+
+func TestStreamResumesAfterDrop(t *testing.T) {
+	var connections int32
+	var lastEventIDs []string
+	server := newStreamTestServer(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			// Drop the connection partway through the third event
+			fmt.Fprint(w, ": keep-alive\n\nid: 1\ndata: first\n\nid: 2\nevent: repayment\ndata: second\ndata: line\n\nid: 3\ndata: thi")
+			return
+		}
+		// Resume inclusively, replaying the last event the client saw
+		fmt.Fprint(w, "id: 2\ndata: second\n\nid: 3\ndata: third\n\nid: 4\ndata: fourth\n\n")
+	})
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+
+	var reconnects int32
+	opts := StreamOptions{
+		InitialBackoff: time.Millisecond,
+		Jitter:         -1,
+		OnReconnect: func(attempt int, delay time.Duration, err error) {
+			atomic.AddInt32(&reconnects, 1)
+		},
+	}
+
+	done := stderrors.New("done")
+	var events []Event
+	err := client.Stream(context.Background(), "/events", opts, func(event Event) error {
+		events = append(events, event)
+		if event.ID == "4" {
+			return done
+		}
+		return nil
+	})
+
+	if err != done {
+		t.Fatalf("Expected the handler error to end the stream, got %v", err)
+	}
+
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	if strings.Join(ids, ",") != "1,2,3,4" {
+		t.Errorf("Expected events 1,2,3,4 exactly once, got %v", ids)
+	}
+	if events[1].Type != "repayment" || events[1].Data != "second\nline" {
+		t.Errorf("Unexpected second event %+v", events[1])
+	}
+	if events[2].Data != "third" {
+		t.Errorf("Expected the truncated event to be discarded and resent whole, got %q", events[2].Data)
+	}
+	if len(lastEventIDs) != 2 || lastEventIDs[0] != "" || lastEventIDs[1] != "2" {
+		t.Errorf("Expected reconnection to send Last-Event-ID 2, got %v", lastEventIDs)
+	}
+	if reconnects != 1 {
+		t.Errorf("Expected 1 reconnection, got %d", reconnects)
+	}
+}
+
+func TestStreamGivesUpAfterMaxRetries(t *testing.T) {
+	var connections int32
+	server := newStreamTestServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+
+	var delays []time.Duration
+	opts := StreamOptions{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     3 * time.Millisecond,
+		Jitter:         -1,
+		MaxRetries:     3,
+		OnReconnect: func(attempt int, delay time.Duration, err error) {
+			delays = append(delays, delay)
+		},
+	}
+
+	err := client.Stream(context.Background(), "/events", opts, func(event Event) error {
+		return nil
+	})
+
+	var netErr *errors.NetworkError
+	if !stderrors.As(err, &netErr) {
+		t.Fatalf("Expected a network error after exhausting retries, got %v", err)
+	}
+	if connections != 4 {
+		t.Errorf("Expected 4 connection attempts, got %d", connections)
+	}
+	expected := []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}
+	if fmt.Sprint(delays) != fmt.Sprint(expected) {
+		t.Errorf("Expected capped exponential delays %v, got %v", expected, delays)
+	}
+}
+
+func TestStreamStopsOnNonRetryableStatus(t *testing.T) {
+	var connections int32
+	server := newStreamTestServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		w.WriteHeader(http.StatusForbidden)
+	})
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+
+	err := client.Stream(context.Background(), "/events", StreamOptions{InitialBackoff: time.Millisecond}, func(event Event) error {
+		return nil
+	})
+
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("Expected the 403 to be returned, got %v", err)
+	}
+	if connections != 1 {
+		t.Errorf("Expected no reconnection, got %d connections", connections)
+	}
+}
+
+func TestStreamOutlivesPerAttemptTimeout(t *testing.T) {
+	var connections int32
+	server := newStreamTestServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		// Send events well past the per-attempt timeout
+		for i := 1; i <= 4; i++ {
+			time.Sleep(60 * time.Millisecond)
+			fmt.Fprintf(w, "id: %d\ndata: event %d\n\n", i, i)
+			w.(http.Flusher).Flush()
+		}
+	})
+	defer server.Close()
+
+	client := New(NewConfig(server.URL, "test", "pass"))
+	client.SetRetryConfig(RetryConfig{MaxRetries: 2, RetryDelay: time.Millisecond, PerAttemptTimeout: 50 * time.Millisecond})
+
+	var reconnects int32
+	opts := StreamOptions{
+		InitialBackoff: time.Millisecond,
+		Jitter:         -1,
+		OnReconnect: func(attempt int, delay time.Duration, err error) {
+			atomic.AddInt32(&reconnects, 1)
+		},
+	}
+
+	// Fail rather than hang if the stream keeps being cut off
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	done := stderrors.New("done")
+	var ids []string
+	err := client.Stream(ctx, "/events", opts, func(event Event) error {
+		ids = append(ids, event.ID)
+		if event.ID == "4" {
+			return done
+		}
+		return nil
+	})
+
+	if err != done {
+		t.Fatalf("Expected the handler error to end the stream, got %v", err)
+	}
+	if strings.Join(ids, ",") != "1,2,3,4" {
+		t.Errorf("Expected events 1,2,3,4, got %v", ids)
+	}
+	if reconnects != 0 || connections != 1 {
+		t.Errorf("Expected a single connection, got %d connections and %d reconnects", connections, reconnects)
+	}
+}
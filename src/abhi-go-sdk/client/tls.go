@@ -0,0 +1,76 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// TLSConfig builds the TLS configuration enforced on API traffic, returning an error
+// if the configured minimum version or any cipher suite is insecure or unknown
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	minVersion := c.MinTLSVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+
+	switch minVersion {
+	case tls.VersionTLS12, tls.VersionTLS13:
+	case tls.VersionSSL30, tls.VersionTLS10, tls.VersionTLS11:
+		return nil, fmt.Errorf("insecure minimum TLS version %s, TLS 1.2 or newer is required", tls.VersionName(minVersion))
+	default:
+		return nil, fmt.Errorf("unknown minimum TLS version 0x%04x", minVersion)
+	}
+
+	secure := make(map[uint16]bool)
+	for _, suite := range tls.CipherSuites() {
+		secure[suite.ID] = true
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		secure[suite.ID] = false
+	}
+
+	for _, id := range c.CipherSuites {
+		allowed, known := secure[id]
+		if !known {
+			return nil, fmt.Errorf("unknown TLS cipher suite 0x%04x", id)
+		}
+		if !allowed {
+			return nil, fmt.Errorf("insecure TLS cipher suite %s", tls.CipherSuiteName(id))
+		}
+	}
+
+	return &tls.Config{
+		MinVersion:   minVersion,
+		CipherSuites: c.CipherSuites,
+	}, nil
+}
+
+// applyTLSConfig returns a copy of transport enforcing the given TLS settings. Transports
+// other than *http.Transport are returned unchanged since their TLS setup isn't reachable.
+func applyTLSConfig(transport http.RoundTripper, tlsConfig *tls.Config) http.RoundTripper {
+	base, ok := transport.(*http.Transport)
+	if !ok {
+		return transport
+	}
+
+	base = base.Clone()
+	if base.TLSClientConfig == nil {
+		base.TLSClientConfig = &tls.Config{}
+	}
+	base.TLSClientConfig.MinVersion = tlsConfig.MinVersion
+	if tlsConfig.CipherSuites != nil {
+		base.TLSClientConfig.CipherSuites = tlsConfig.CipherSuites
+	}
+
+	return base
+}
+
+// errTransport fails every request with a configuration error
+type errTransport struct {
+	err error
+}
+
+func (t *errTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"abhi-go-sdk/models"
+)
+
+// newTLSTestServer starts a TLS server limited to the given maximum version
+func newTLSTestServer(t *testing.T, maxVersion uint16) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		data := map[string]interface{}{"test": "value"}
+		if r.URL.Path == "/auth/login" {
+			data = map[string]interface{}{"token": "test-token"}
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: data})
+	}))
+	server.TLS = &tls.Config{MaxVersion: maxVersion}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestTLSRejectsOutdatedServerVersion(t *testing.T) {
+	server := newTLSTestServer(t, tls.VersionTLS11)
+
+	config := NewConfig(server.URL, "test", "pass").SetHTTPClient(server.Client())
+	client := New(config)
+
+	var result map[string]string
+	err := client.GET(context.Background(), "/test", &result)
+	if err == nil {
+		t.Fatal("Expected a TLS 1.1 server to be rejected")
+	}
+	if !strings.Contains(err.Error(), "protocol version") {
+		t.Errorf("Expected a protocol version error, got %v", err)
+	}
+}
+
+func TestTLSAcceptsModernServerVersion(t *testing.T) {
+	server := newTLSTestServer(t, tls.VersionTLS12)
+
+	config := NewConfig(server.URL, "test", "pass").
+		SetHTTPClient(server.Client()).
+		SetCipherSuites(tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384)
+	client := New(config)
+
+	var result map[string]string
+	if err := client.GET(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result["test"] != "value" {
+		t.Error("Expected result to contain test data")
+	}
+}
+
+func TestTLSConfigRejectsInsecureSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+	}{
+		{"TLS 1.0", DefaultConfig().SetMinTLSVersion(tls.VersionTLS10)},
+		{"TLS 1.1", DefaultConfig().SetMinTLSVersion(tls.VersionTLS11)},
+		{"unknown version", DefaultConfig().SetMinTLSVersion(0x9999)},
+		{"insecure cipher suite", DefaultConfig().SetCipherSuites(tls.TLS_RSA_WITH_RC4_128_SHA)},
+		{"unknown cipher suite", DefaultConfig().SetCipherSuites(0xffff)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.config.TLSConfig(); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTLSInsecureConfigFailsRequests(t *testing.T) {
+	server := newTLSTestServer(t, tls.VersionTLS13)
+
+	config := NewConfig(server.URL, "test", "pass").
+		SetHTTPClient(server.Client()).
+		SetMinTLSVersion(tls.VersionTLS10)
+	client := New(config)
+
+	var result map[string]string
+	err := client.GET(context.Background(), "/test", &result)
+	if err == nil || !strings.Contains(err.Error(), "insecure minimum TLS version") {
+		t.Errorf("Expected requests to fail with the TLS configuration error, got %v", err)
+	}
+}
+
+func TestTLSDefaultsToVersion12(t *testing.T) {
+	tlsConfig, err := (&Config{}).TLSConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected TLS 1.2 minimum, got %s", tls.VersionName(tlsConfig.MinVersion))
+	}
+}
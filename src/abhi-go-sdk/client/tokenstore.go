@@ -0,0 +1,116 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TokenStore persists the access token so that later SDK instances, including ones in other
+// processes, can reuse it instead of logging in again
+type TokenStore interface {
+	// Load returns the stored token and its expiry; ok is false if there is none
+	Load() (token string, expiresAt time.Time, ok bool)
+	// Save stores a newly obtained token, replacing any previous one
+	Save(token string, expiresAt time.Time) error
+}
+
+// MemoryTokenStore implements in-memory token storage, shared by the SDK instances given it
+type MemoryTokenStore struct {
+	token     string
+	expiresAt time.Time
+	mutex     sync.RWMutex
+}
+
+// NewMemoryTokenStore creates a new in-memory token store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Load returns the stored token
+func (ms *MemoryTokenStore) Load() (string, time.Time, bool) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+	return ms.token, ms.expiresAt, ms.token != ""
+}
+
+// Save stores the token
+func (ms *MemoryTokenStore) Save(token string, expiresAt time.Time) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	ms.token = token
+	ms.expiresAt = expiresAt
+	return nil
+}
+
+// FileTokenStore persists the token as JSON in a file readable only by its owner
+type FileTokenStore struct {
+	Path string
+}
+
+// NewFileTokenStore creates a token store backed by the file at path
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+// storedToken is the file format of FileTokenStore
+type storedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Load reads the token file; a missing or unreadable file holds no token
+func (fs *FileTokenStore) Load() (string, time.Time, bool) {
+	data, err := os.ReadFile(fs.Path)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	var stored storedToken
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Token == "" {
+		return "", time.Time{}, false
+	}
+	return stored.Token, stored.ExpiresAt, true
+}
+
+// Save writes the token file with 0600 permissions. The file is replaced atomically so a
+// concurrent Load never sees a partial write.
+func (fs *FileTokenStore) Save(token string, expiresAt time.Time) error {
+	data, err := json.Marshal(storedToken{Token: token, ExpiresAt: expiresAt})
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+
+	if err := writeFileAtomic(fs.Path, data); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic replaces the file at path with data, readable only by its owner. The data is
+// written to a temporary file that is then renamed over path, so readers never see a partial write.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// CreateTemp already uses 0600, but be explicit as the file holds a credential
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/models"
+)
+
+func TestTokenStoreSharedAcrossInstances(t *testing.T) {
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			atomic.AddInt32(&logins, 1)
+			json.NewEncoder(w).Encode(models.APIResponse{
+				StatusCode: 200,
+				Data:       map[string]interface{}{"token": createTestJWT(time.Now().Add(time.Hour))},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"data":{}}`))
+	}))
+	defer server.Close()
+
+	store := NewMemoryTokenStore()
+	for i := 0; i < 2; i++ {
+		client := New(NewConfig(server.URL, "test", "pass").SetTokenStore(store))
+		if err := client.GET(context.Background(), "/employees", nil); err != nil {
+			t.Fatalf("Instance %d: expected no error, got %v", i+1, err)
+		}
+	}
+
+	if n := atomic.LoadInt32(&logins); n != 1 {
+		t.Errorf("Expected the second instance to reuse the cached token, got %d logins", n)
+	}
+}
+
+func TestTokenStoreSkipsExpiredAndRejectedTokens(t *testing.T) {
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&logins, 1)
+		json.NewEncoder(w).Encode(models.APIResponse{
+			StatusCode: 200,
+			Data:       map[string]interface{}{"token": createTestJWT(time.Now().Add(time.Hour))},
+		})
+	}))
+	defer server.Close()
+
+	store := NewMemoryTokenStore()
+	store.Save("stale-token", time.Now().Add(time.Minute))
+
+	authManager := NewAuthManager(NewConfig(server.URL, "test", "pass").SetTokenStore(store))
+	token, err := authManager.GetToken(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token == "stale-token" || atomic.LoadInt32(&logins) != 1 {
+		t.Fatal("Expected a token about to expire to be replaced by a login")
+	}
+	if saved, _, _ := store.Load(); saved != token {
+		t.Error("Expected the new token to be saved")
+	}
+
+	// A token the API rejected isn't reloaded from the store
+	authManager.ClearToken()
+	if _, err := authManager.GetToken(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&logins); n != 2 {
+		t.Errorf("Expected a login after the token was cleared, got %d logins", n)
+	}
+}
+
+func TestFileTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path)
+
+	if _, _, ok := store.Load(); ok {
+		t.Error("Expected no token before the first save")
+	}
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := store.Save("file-token", expiresAt); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected the token file to exist, got %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected 0600 permissions, got %o", perm)
+	}
+
+	token, loadedExpiry, ok := NewFileTokenStore(path).Load()
+	if !ok || token != "file-token" || !loadedExpiry.Equal(expiresAt) {
+		t.Errorf("Expected the saved token, got %q %v %v", token, loadedExpiry, ok)
+	}
+}
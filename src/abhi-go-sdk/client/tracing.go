@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Span attribute keys recorded on every API call span, following OpenTelemetry's HTTP
+// semantic conventions where one applies
+const (
+	SpanAttributeMethod     = "http.request.method"
+	SpanAttributeEndpoint   = "abhi.endpoint"
+	SpanAttributeStatusCode = "http.response.status_code"
+)
+
+// TracerProvider starts a span around each API call and propagates its trace context to the
+// API. The client depends only on this interface, so tracing libraries stay optional; the
+// otelabhi module adapts an OpenTelemetry TracerProvider.
+type TracerProvider interface {
+	// StartSpan starts a span named name as a child of any span in ctx, returning a context
+	// carrying the new span
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+	// Inject writes the trace context carried by ctx into the headers of an outgoing request
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is the traced portion of a single API call
+type Span interface {
+	// SetAttribute records a string, int or bool attribute on the span
+	SetAttribute(key string, value interface{})
+	// RecordError records err on the span and marks it as failed
+	RecordError(err error)
+	// End finishes the span
+	End()
+}
+
+// startSpan starts a span named "HTTP <method> <endpoint>" when a tracer provider is configured.
+// The returned function records the outcome of the call and ends the span.
+func (c *Client) startSpan(ctx context.Context, method, endpoint string) (context.Context, func(status int, err error)) {
+	provider := c.config.TracerProvider
+	if provider == nil {
+		return ctx, func(int, error) {}
+	}
+
+	ctx, span := provider.StartSpan(ctx, "HTTP "+method+" "+endpoint)
+	span.SetAttribute(SpanAttributeMethod, method)
+	span.SetAttribute(SpanAttributeEndpoint, endpoint)
+
+	return ctx, func(status int, err error) {
+		if status > 0 {
+			span.SetAttribute(SpanAttributeStatusCode, status)
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"abhi-go-sdk/models"
+)
+
+// recordingSpan is a Span that keeps what was recorded on it
+type recordingSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *recordingSpan) RecordError(err error)                      { s.err = err }
+func (s *recordingSpan) End()                                       { s.ended = true }
+
+type spanKey struct{}
+
+// recordingTracer is a TracerProvider that injects the current span's name as a header
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (rt *recordingTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordingSpan{name: name, attributes: make(map[string]interface{})}
+	rt.spans = append(rt.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (rt *recordingTracer) Inject(ctx context.Context, header http.Header) {
+	if span, ok := ctx.Value(spanKey{}).(*recordingSpan); ok {
+		header.Set("X-Test-Span", span.name)
+	}
+}
+
+func TestTracerProviderWrapsEachCall(t *testing.T) {
+	var injected []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		injected = append(injected, r.Header.Get("X-Test-Span"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/employees/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ErrorResponse{StatusCode: 404, Message: "Employee not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]string{}})
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	config := NewConfig(server.URL, "", "").SetTracerProvider(tracer)
+	config.APIKey = "test-key"
+	client := New(config)
+
+	if err := client.GETWithQuery(context.Background(), "/employees", map[string][]string{"page": {"1"}}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.GET(context.Background(), "/employees/missing", nil); err == nil {
+		t.Fatal("Expected a not found error")
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(tracer.spans))
+	}
+
+	ok := tracer.spans[0]
+	if ok.name != "HTTP GET /employees" || !ok.ended || ok.err != nil {
+		t.Errorf("Unexpected span for the successful call: %+v", ok)
+	}
+	if ok.attributes[SpanAttributeMethod] != "GET" || ok.attributes[SpanAttributeEndpoint] != "/employees" || ok.attributes[SpanAttributeStatusCode] != 200 {
+		t.Errorf("Unexpected attributes: %v", ok.attributes)
+	}
+
+	failed := tracer.spans[1]
+	if failed.err == nil || !failed.ended || failed.attributes[SpanAttributeStatusCode] != 404 {
+		t.Errorf("Expected the failed call's span to record the error and status, got %+v", failed)
+	}
+
+	if len(injected) != 2 || injected[0] != "HTTP GET /employees" || injected[1] != "HTTP GET /employees/missing" {
+		t.Errorf("Expected each request to carry its span's context, got %q", injected)
+	}
+}
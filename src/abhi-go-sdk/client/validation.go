@@ -0,0 +1,51 @@
+package client
+
+import (
+	"reflect"
+
+	"abhi-go-sdk/models"
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator creates a validator with the SDK's custom validation tags registered
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	// netsalary: a positive number with at most 2 decimal places
+	v.RegisterValidation("netsalary", func(fl validator.FieldLevel) bool {
+		switch fl.Field().Kind() {
+		case reflect.Float32, reflect.Float64:
+			return models.ValidateNetSalary(models.Money(fl.Field().Float())) == nil
+		case reflect.String:
+			_, err := models.ParseNetSalary(fl.Field().String())
+			return err == nil
+		}
+		return false
+	})
+
+	// emiratesid: the 784-YYYY-NNNNNNN-C shape with a valid check digit
+	v.RegisterValidation("emiratesid", func(fl validator.FieldLevel) bool {
+		return models.ValidateEmiratesID(fl.Field().String()) == nil
+	})
+
+	// uaephone: a UAE mobile number in +9715XXXXXXXX or 05XXXXXXXX form
+	v.RegisterValidation("uaephone", func(fl validator.FieldLevel) bool {
+		_, err := models.NormalizeUAEPhone(fl.Field().String())
+		return err == nil
+	})
+
+	return v
+}
+
+// isStructBody reports whether body is a struct or a pointer to one. Other bodies, such as the
+// maps sent by partial updates, carry no validation tags.
+func isStructBody(body interface{}) bool {
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	return v.Kind() == reflect.Struct
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+// MaxWebhookBodySize bounds how much of a webhook body WebhookVerifier reads
+const MaxWebhookBodySize = 1 << 20
+
+// WebhookVerifier authenticates status updates Abhi posts to a customer webhook. Abhi signs them
+// with the shared secret using the same canonicalization as RequestSigner, so the verifier
+// checks the X-Signature, X-Timestamp and X-Nonce headers exactly as the API checks signed
+// requests.
+type WebhookVerifier struct {
+	signer *RequestSigner
+}
+
+// NewWebhookVerifier creates a verifier for webhooks signed with secret. The signature covers
+// signedHeaders in the given order, or DefaultSignedHeaders if none are given.
+func NewWebhookVerifier(secret string, signedHeaders ...string) *WebhookVerifier {
+	return &WebhookVerifier{signer: NewRequestSigner(secret, signedHeaders...)}
+}
+
+// SetTimestampWindow sets how far a webhook's timestamp may be from the current time; zero or
+// negative restores DefaultSignatureWindow
+func (wv *WebhookVerifier) SetTimestampWindow(window time.Duration) *WebhookVerifier {
+	wv.signer.SetTimestampWindow(window)
+	return wv
+}
+
+// SetNonceCache sets the cache used to reject redelivered nonces, e.g. one shared by several
+// receiving instances
+func (wv *WebhookVerifier) SetNonceCache(cache NonceCache) *WebhookVerifier {
+	wv.signer.SetNonceCache(cache)
+	return wv
+}
+
+// VerifyAndParse verifies an incoming webhook's signature and timestamp and decodes its body.
+// Rejections wrap errors.ErrInvalidSignature, errors.ErrStaleTimestamp or
+// errors.ErrReplayedRequest. The request body is restored so handlers can read it again.
+func (wv *WebhookVerifier) VerifyAndParse(r *http.Request) (*models.WebhookEvent, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(io.LimitReader(r.Body, MaxWebhookBodySize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook body: %w", err)
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if len(body) > MaxWebhookBodySize {
+		return nil, fmt.Errorf("webhook body exceeds %d bytes", MaxWebhookBodySize)
+	}
+
+	signature := r.Header.Get("X-Signature")
+	if signature == "" {
+		return nil, fmt.Errorf("missing X-Signature header: %w", errors.ErrInvalidSignature)
+	}
+	if err := wv.signer.verify(r, body, signature); err != nil {
+		return nil, fmt.Errorf("webhook rejected: %w", err)
+	}
+
+	return models.ParseWebhookEvent(body)
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+const testWebhookBody = `{"id":"evt-1","type":"transaction.status_updated","createdAt":"2024-01-15T10:00:00Z","data":{"id":"txn-1","employeeId":"emp-1","amount":500,"status":"completed"}}`
+
+// signedWebhook builds a webhook request signed by Abhi's side of the shared secret
+func signedWebhook(t *testing.T, secret, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/hooks/abhi", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err := NewRequestSigner(secret).SignRequest(req, []byte(body)); err != nil {
+		t.Fatalf("Failed to sign webhook: %v", err)
+	}
+	return req
+}
+
+func TestWebhookVerifierAcceptsValidPayload(t *testing.T) {
+	verifier := NewWebhookVerifier("webhook-secret")
+
+	event, err := verifier.VerifyAndParse(signedWebhook(t, "webhook-secret", testWebhookBody))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.ID != "evt-1" || event.Type != models.WebhookTransactionStatusUpdated {
+		t.Errorf("Expected event evt-1 of type %s, got %s of type %s", models.WebhookTransactionStatusUpdated, event.ID, event.Type)
+	}
+	if event.Transaction == nil {
+		t.Fatal("Expected the transaction payload to be decoded")
+	}
+	if event.Transaction.ID != "txn-1" || event.Transaction.Status != "completed" || event.Transaction.Amount != 500 {
+		t.Errorf("Unexpected transaction payload: %+v", event.Transaction)
+	}
+	if event.Repayment != nil {
+		t.Error("Expected no repayment payload on a transaction event")
+	}
+}
+
+func TestWebhookVerifierRejectsTamperedBody(t *testing.T) {
+	verifier := NewWebhookVerifier("webhook-secret")
+
+	req := signedWebhook(t, "webhook-secret", testWebhookBody)
+	tampered := httptest.NewRequest(http.MethodPost, "/hooks/abhi", strings.NewReader(strings.Replace(testWebhookBody, "500", "50000", 1)))
+	tampered.Header = req.Header
+
+	_, err := verifier.VerifyAndParse(tampered)
+	if !stderrors.Is(err, errors.ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+
+	// A payload signed with a different secret is rejected the same way
+	_, err = verifier.VerifyAndParse(signedWebhook(t, "other-secret", testWebhookBody))
+	if !stderrors.Is(err, errors.ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for a foreign secret, got %v", err)
+	}
+}
+
+func TestWebhookVerifierRejectsStaleTimestamp(t *testing.T) {
+	signer := NewRequestSigner("webhook-secret")
+	verifier := NewWebhookVerifier("webhook-secret")
+
+	// Sign correctly, but ten minutes ago
+	req := httptest.NewRequest(http.MethodPost, "/hooks/abhi", strings.NewReader(testWebhookBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(NonceHeader, "stale-nonce")
+	timestamp := time.Now().Add(-10 * time.Minute).Unix()
+	req.Header.Set("X-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Signature", signer.generateSignature(signer.createStringToSign(req, []byte(testWebhookBody), timestamp)))
+
+	_, err := verifier.VerifyAndParse(req)
+	if !stderrors.Is(err, errors.ErrStaleTimestamp) {
+		t.Errorf("Expected ErrStaleTimestamp, got %v", err)
+	}
+}
+
+func TestWebhookVerifierRejectsRedelivery(t *testing.T) {
+	verifier := NewWebhookVerifier("webhook-secret")
+	req := signedWebhook(t, "webhook-secret", testWebhookBody)
+
+	if _, err := verifier.VerifyAndParse(req); err != nil {
+		t.Fatalf("Expected the first delivery to verify, got %v", err)
+	}
+	// The body was restored, so the same request can be presented again
+	if _, err := verifier.VerifyAndParse(req); !stderrors.Is(err, errors.ErrReplayedRequest) {
+		t.Errorf("Expected ErrReplayedRequest, got %v", err)
+	}
+}
@@ -1,8 +1,14 @@
 package errors
 
 import (
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // APIError represents an error from the Abhi API
@@ -11,6 +17,24 @@ type APIError struct {
 	Message    string `json:"message"`
 	Details    string `json:"details,omitempty"`
 	Endpoint   string `json:"endpoint,omitempty"`
+	Code       string `json:"code,omitempty"`
+	// ContentType is set when the error body wasn't JSON, e.g. an HTML page from a gateway
+	ContentType string `json:"contentType,omitempty"`
+	// Data holds structured details from the error body; a non-object value is kept under "value"
+	Data map[string]interface{} `json:"data,omitempty"`
+	// RetryAfter is the delay requested by the Retry-After header of a 429 or 503 response, or
+	// zero if the server didn't send one
+	RetryAfter time.Duration `json:"retryAfter,omitempty"`
+	// ValidationErrors holds the per-field details the API sends with a rejected request
+	ValidationErrors []ValidationError `json:"validationErrors,omitempty"`
+}
+
+// tokenScopeErrorCodes lists the 403 error codes returned when the token's scope or
+// permissions changed server-side and a fresh token would be accepted
+var tokenScopeErrorCodes = map[string]bool{
+	"TOKEN_SCOPE_EXPIRED": true,
+	"TOKEN_SCOPE_CHANGED": true,
+	"PERMISSIONS_CHANGED": true,
 }
 
 func (e *APIError) Error() string {
@@ -40,6 +64,12 @@ func (e *APIError) IsForbidden() bool {
 	return e.StatusCode == http.StatusForbidden
 }
 
+// IsTokenScopeExpired returns true if the error is a 403 that a re-authentication would resolve,
+// as opposed to a genuine permission denial
+func (e *APIError) IsTokenScopeExpired() bool {
+	return e.StatusCode == http.StatusForbidden && tokenScopeErrorCodes[strings.ToUpper(e.Code)]
+}
+
 // IsNotFound returns true if the error is a 404 Not Found
 func (e *APIError) IsNotFound() bool {
 	return e.StatusCode == http.StatusNotFound
@@ -55,6 +85,60 @@ func (e *APIError) IsRateLimited() bool {
 	return e.StatusCode == http.StatusTooManyRequests
 }
 
+// IsInsufficientBalance returns true if the error rejects a transaction for exceeding the available balance
+func (e *APIError) IsInsufficientBalance() bool {
+	if strings.EqualFold(e.Code, "INSUFFICIENT_BALANCE") {
+		return true
+	}
+	return e.IsClientError() && strings.Contains(strings.ToLower(e.Message), "insufficient balance")
+}
+
+// IsApprovalRequired returns true if the error rejects a change that needs approval first
+func (e *APIError) IsApprovalRequired() bool {
+	return strings.EqualFold(e.Code, "APPROVAL_REQUIRED")
+}
+
+// Sentinel errors matched by APIError via errors.Is, so callers can test the category of a
+// wrapped API error without asserting its type
+var (
+	ErrNotFound     = stderrors.New("not found")
+	ErrUnauthorized = stderrors.New("unauthorized")
+	ErrRateLimited  = stderrors.New("rate limited")
+	ErrConflict     = stderrors.New("conflict")
+)
+
+// Is reports whether target is the sentinel for the error's category, e.g. ErrNotFound for a 404
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.IsNotFound()
+	case ErrUnauthorized:
+		return e.IsUnauthorized()
+	case ErrRateLimited:
+		return e.IsRateLimited()
+	case ErrConflict:
+		return e.IsConflict()
+	case ErrInsufficientBalance:
+		return e.IsInsufficientBalance()
+	case ErrApprovalRequired:
+		return e.IsApprovalRequired()
+	}
+	return false
+}
+
+// FieldErrors groups the error's field-level validation messages by field name, e.g. to show
+// them next to the matching form inputs. It returns nil if the API sent none.
+func (e *APIError) FieldErrors() map[string][]string {
+	if len(e.ValidationErrors) == 0 {
+		return nil
+	}
+	fields := make(map[string][]string)
+	for _, fieldErr := range e.ValidationErrors {
+		fields[fieldErr.Field] = append(fields[fieldErr.Field], fieldErr.Message)
+	}
+	return fields
+}
+
 // NewAPIError creates a new API error
 func NewAPIError(statusCode int, message, details, endpoint string) *APIError {
 	return &APIError{
@@ -76,6 +160,27 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("Validation error for field '%s': %s", e.Field, e.Message)
 }
 
+// UnmarshalJSON decodes a validation error from the API, which may send the rejected value as a
+// number or boolean rather than a string
+func (e *ValidationError) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Field   string      `json:"field"`
+		Message string      `json:"message"`
+		Value   interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	e.Field = raw.Field
+	e.Message = raw.Message
+	e.Value = ""
+	if raw.Value != nil {
+		e.Value = fmt.Sprint(raw.Value)
+	}
+	return nil
+}
+
 // NetworkError represents a network-related error
 type NetworkError struct {
 	Operation string
@@ -90,6 +195,32 @@ func (e *NetworkError) Unwrap() error {
 	return e.Err
 }
 
+// ErrorKind classifies the stage at which an SDKError occurred
+type ErrorKind string
+
+const (
+	KindEncoding    ErrorKind = "encoding"    // The request body could not be encoded
+	KindRequest     ErrorKind = "request"     // The request URL or HTTP request could not be built
+	KindInterceptor ErrorKind = "interceptor" // A request or response interceptor rejected the call
+	KindDecoding    ErrorKind = "decoding"    // The response body could not be decoded
+)
+
+// SDKError represents a failure inside the SDK while preparing a request or handling its
+// response, as opposed to an error reported by the API or the network
+type SDKError struct {
+	Kind      ErrorKind
+	Operation string
+	Err       error
+}
+
+func (e *SDKError) Error() string {
+	return fmt.Sprintf("SDK %s error during %s: %v", e.Kind, e.Operation, e.Err)
+}
+
+func (e *SDKError) Unwrap() error {
+	return e.Err
+}
+
 // AuthenticationError represents an authentication-related error
 type AuthenticationError struct {
 	Message string
@@ -105,4 +236,191 @@ func (e *AuthenticationError) Error() string {
 
 func (e *AuthenticationError) Unwrap() error {
 	return e.Err
-}
\ No newline at end of file
+}
+
+// BatchError aggregates the per-item failures of a batch operation
+type BatchError struct {
+	Total  int
+	Errors map[int]error // Keyed by the index of the failed input item
+}
+
+// NewBatchError creates a new batch error for a batch of the given size
+func NewBatchError(total int) *BatchError {
+	return &BatchError{
+		Total:  total,
+		Errors: make(map[int]error),
+	}
+}
+
+// Add records the failure of the item at the given index
+func (e *BatchError) Add(index int, err error) {
+	e.Errors[index] = err
+}
+
+// HasErrors returns true if any item in the batch failed
+func (e *BatchError) HasErrors() bool {
+	return len(e.Errors) > 0
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("Batch error: %d of %d items failed", len(e.Errors), e.Total)
+}
+
+// Unwrap returns the individual item errors ordered by index
+func (e *BatchError) Unwrap() []error {
+	indexes := make([]int, 0, len(e.Errors))
+	for index := range e.Errors {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	errs := make([]error, 0, len(indexes))
+	for _, index := range indexes {
+		errs = append(errs, e.Errors[index])
+	}
+	return errs
+}
+
+// LookupError reports the keys of a multi-key lookup that were not found or failed
+type LookupError struct {
+	Total    int
+	NotFound []string
+	Errors   map[string]error // Keyed by the key whose lookup failed
+}
+
+// NewLookupError creates a new lookup error for a lookup of the given number of keys
+func NewLookupError(total int) *LookupError {
+	return &LookupError{
+		Total:  total,
+		Errors: make(map[string]error),
+	}
+}
+
+// HasErrors returns true if any key was not found or failed
+func (e *LookupError) HasErrors() bool {
+	return len(e.NotFound) > 0 || len(e.Errors) > 0
+}
+
+func (e *LookupError) Error() string {
+	return fmt.Sprintf("Lookup error: %d of %d keys not found, %d failed", len(e.NotFound), e.Total, len(e.Errors))
+}
+
+// Unwrap returns the individual lookup errors ordered by key
+func (e *LookupError) Unwrap() []error {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := make([]error, 0, len(keys))
+	for _, key := range keys {
+		errs = append(errs, e.Errors[key])
+	}
+	return errs
+}
+
+// ErrRateLimitWaitExceeded indicates the client-side rate limiter couldn't grant a request a
+// token within RateLimitConfig.MaxWait
+var ErrRateLimitWaitExceeded = stderrors.New("rate limiter wait exceeded")
+
+// ErrFullyPaid indicates an employee has nothing outstanding, so no installment is due
+var ErrFullyPaid = stderrors.New("balance is fully paid")
+
+// ErrTerminalStatus indicates a resource being waited on reached a final status other than the
+// ones the caller was waiting for
+var ErrTerminalStatus = stderrors.New("reached a terminal status")
+
+// ErrAmbiguousMatch indicates a lookup expected to find a single record matched several
+var ErrAmbiguousMatch = stderrors.New("ambiguous match")
+
+// ErrInvalidSignature indicates a signed request or webhook is unsigned or its signature doesn't match
+var ErrInvalidSignature = stderrors.New("invalid signature")
+
+// ErrStaleTimestamp indicates a signed request or webhook's timestamp is outside the accepted window
+var ErrStaleTimestamp = stderrors.New("timestamp outside signature window")
+
+// ErrReplayedRequest indicates a signed request or webhook reused a nonce that was already verified
+var ErrReplayedRequest = stderrors.New("replayed request")
+
+// ErrInsufficientBalance matches any InsufficientBalanceError via errors.Is
+var ErrInsufficientBalance = stderrors.New("insufficient balance")
+
+// InsufficientBalanceError represents a transaction rejected for exceeding the employee's available balance
+type InsufficientBalanceError struct {
+	RequestedAmount float64
+	AvailableAmount float64
+	APIError        *APIError
+}
+
+// NewInsufficientBalanceError creates an insufficient balance error from an API error,
+// reading the requested and available amounts from its structured details
+func NewInsufficientBalanceError(apiErr *APIError) *InsufficientBalanceError {
+	return &InsufficientBalanceError{
+		RequestedAmount: amountField(apiErr.Data, "requestedAmount", "amount"),
+		AvailableAmount: amountField(apiErr.Data, "availableAmount", "availableBalance", "available"),
+		APIError:        apiErr,
+	}
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("Insufficient balance: requested %.2f, available %.2f", e.RequestedAmount, e.AvailableAmount)
+}
+
+func (e *InsufficientBalanceError) Unwrap() error {
+	return e.APIError
+}
+
+// Is reports whether target is ErrInsufficientBalance
+func (e *InsufficientBalanceError) Is(target error) bool {
+	return target == ErrInsufficientBalance
+}
+
+// ErrApprovalRequired matches any ApprovalRequiredError via errors.Is
+var ErrApprovalRequired = stderrors.New("approval required")
+
+// ApprovalRequiredError represents a governed change, such as a credit limit increase, that
+// wasn't applied because it needs approval it didn't get
+type ApprovalRequiredError struct {
+	Resource string // The resource being changed, e.g. an organization ID
+	ChangeID string // The pending or rejected change record, if one was created
+	Status   string // The change's approval status, e.g. pending or rejected
+	APIError *APIError
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	if e.ChangeID != "" {
+		return fmt.Sprintf("Approval required for %s: change %s is %s", e.Resource, e.ChangeID, e.Status)
+	}
+	if e.APIError != nil {
+		return fmt.Sprintf("Approval required for %s: %s", e.Resource, e.APIError.Message)
+	}
+	return fmt.Sprintf("Approval required for %s", e.Resource)
+}
+
+func (e *ApprovalRequiredError) Unwrap() error {
+	if e.APIError == nil {
+		return nil
+	}
+	return e.APIError
+}
+
+// Is reports whether target is ErrApprovalRequired
+func (e *ApprovalRequiredError) Is(target error) bool {
+	return target == ErrApprovalRequired
+}
+
+// amountField returns the first of the given keys holding a numeric or numeric string value
+func amountField(data map[string]interface{}, keys ...string) float64 {
+	for _, key := range keys {
+		switch value := data[key].(type) {
+		case float64:
+			return value
+		case string:
+			if amount, err := strconv.ParseFloat(value, 64); err == nil {
+				return amount
+			}
+		}
+	}
+	return 0
+}
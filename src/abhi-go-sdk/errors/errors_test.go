@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -189,6 +190,35 @@ func TestAPIErrorIsRateLimited(t *testing.T) {
 	}
 }
 
+func TestAPIErrorIsSentinel(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		sentinel   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusConflict, ErrConflict},
+	}
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrConflict}
+
+	for _, test := range tests {
+		// The message is irrelevant; only the status decides the category
+		err := fmt.Errorf("failed to get employee: %w", NewAPIError(test.statusCode, "Whatever", "", "/test"))
+		for _, sentinel := range sentinels {
+			expected := sentinel == test.sentinel
+			if stderrors.Is(err, sentinel) != expected {
+				t.Errorf("StatusCode %d: expected errors.Is(err, %v) to be %v", test.statusCode, sentinel, expected)
+			}
+		}
+
+		var apiErr *APIError
+		if !stderrors.As(err, &apiErr) || apiErr.StatusCode != test.statusCode {
+			t.Errorf("StatusCode %d: expected errors.As to find the APIError", test.statusCode)
+		}
+	}
+}
+
 func TestNewAPIError(t *testing.T) {
 	statusCode := http.StatusBadRequest
 	message := "Bad Request"
@@ -224,6 +254,26 @@ func TestValidationError(t *testing.T) {
 	}
 }
 
+func TestAPIErrorFieldErrors(t *testing.T) {
+	err := &APIError{StatusCode: http.StatusBadRequest}
+	if err.FieldErrors() != nil {
+		t.Error("Expected no field errors without validation errors")
+	}
+
+	err.ValidationErrors = []ValidationError{
+		{Field: "email", Message: "is required"},
+		{Field: "email", Message: "must be a valid email"},
+		{Field: "phone", Message: "must be a UAE number"},
+	}
+	fields := err.FieldErrors()
+	if len(fields["email"]) != 2 || fields["email"][1] != "must be a valid email" {
+		t.Errorf("Expected both email messages, got %v", fields["email"])
+	}
+	if len(fields["phone"]) != 1 {
+		t.Errorf("Expected one phone message, got %v", fields["phone"])
+	}
+}
+
 func TestNetworkError(t *testing.T) {
 	innerErr := fmt.Errorf("connection timeout")
 	err := &NetworkError{
@@ -247,7 +247,7 @@ func authExamples(ctx context.Context, sdk *abhi.SDK) error {
 	fmt.Println("\n--- Login Examples (will fail without real credentials) ---")
 
 	// Employee login example
-	_, err = sdk.Auth.LoginEmployee(ctx, "employee@company.com", "password", "784-1990-1234567-1")
+	_, err = sdk.Auth.LoginEmployee(ctx, "employee@company.com", "password", "784-1990-1234567-6")
 	if err != nil {
 		fmt.Printf("⚠ Employee login failed (expected): %v\n", err)
 	}
@@ -82,8 +82,8 @@ func createEmployeeExample(ctx context.Context, sdk *abhi.SDK) error {
 		DateOfJoining:   "2024-01-01",
 		AccountTitle:    "John Doe",
 		AccountNumber:   "1234567890",
-		NetSalary:       "8000",
-		EmiratesID:      "784-1990-1234567-1",
+		NetSalary:       8000,
+		EmiratesID:      "784-1990-1234567-6",
 		Gender:          "Male",
 		BankID:          "9b5fcf65-5fca-4acf-a3a5-6f79055644e1",
 		PayrollStartDay: 1,
@@ -4,7 +4,7 @@ package models
 type EmployeeLoginRequest struct {
 	Username   string `json:"username" validate:"required"`
 	Password   string `json:"password" validate:"required"`
-	EmiratesID string `json:"emiratesId" validate:"required"`
+	EmiratesID string `json:"emiratesId" validate:"required,emiratesid"`
 }
 
 // EmployerLoginRequest represents an employer login request  
@@ -80,7 +80,7 @@ type ResetPasswordConfirmRequest struct {
 // MFASetupRequest represents MFA setup request
 type MFASetupRequest struct {
 	Method string `json:"method" validate:"required,oneof=sms email totp"`
-	Phone  string `json:"phone,omitempty"`
+	Phone  string `json:"phone,omitempty" validate:"omitempty,uaephone"`
 	Email  string `json:"email,omitempty"`
 }
 
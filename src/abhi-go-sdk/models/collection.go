@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CollectionRate reports how the advances due in a period were repaid. Amounts are in minor
+// units of Currency so the on-time, late and outstanding parts always add up to the amount due.
+type CollectionRate struct {
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	Currency         string    `json:"currency"`
+	AdvanceCount     int       `json:"advanceCount"`
+	DueMinor         int64     `json:"dueMinor"`
+	OnTimeMinor      int64     `json:"onTimeMinor"`      // Repaid on or before the due date
+	LateMinor        int64     `json:"lateMinor"`        // Repaid after the due date
+	OutstandingMinor int64     `json:"outstandingMinor"` // Still unpaid
+	OnTimeRate       float64   `json:"onTimeRate"`
+	LateRate         float64   `json:"lateRate"`
+	OutstandingRate  float64   `json:"outstandingRate"`
+}
+
+// unsettledStatuses lists statuses of advances that were never disbursed and of repayments that
+// never settled, which don't count towards a collection rate
+var unsettledStatuses = map[string]bool{
+	"pending":   true,
+	"failed":    true,
+	"rejected":  true,
+	"cancelled": true,
+	"reversed":  true,
+}
+
+// ComputeCollectionRate computes the collection rate of the advances due between from and to,
+// inclusive of both dates. Repayments linked to an advance are split into on-time and late by
+// the date they were processed. When balances list an advance, its remaining amount is taken as
+// outstanding, and any other settled amount that no repayment accounts for is counted as late,
+// keeping the rate conservative.
+func ComputeCollectionRate(from, to time.Time, advances []EmployerTransaction, repayments []Repayment, balances []OutstandingBalance, currency Currency) (*CollectionRate, error) {
+	rate := &CollectionRate{From: from, To: to, Currency: currency.Code}
+	fromDate, toDate := from.Format(DateLayout), to.Format(DateLayout)
+
+	remaining := make(map[string]float64)
+	for _, balance := range balances {
+		for _, item := range balance.TransactionHistory {
+			if item.Type != "repayment" {
+				remaining[item.ID] += item.RemainingAmount
+			}
+		}
+	}
+
+	repaymentsByAdvance := make(map[string][]Repayment)
+	for _, repayment := range repayments {
+		if repayment.TransactionID != "" && !unsettledStatuses[strings.ToLower(repayment.Status)] {
+			repaymentsByAdvance[repayment.TransactionID] = append(repaymentsByAdvance[repayment.TransactionID], repayment)
+		}
+	}
+
+	for _, advance := range advances {
+		if advance.DueDate == "" || unsettledStatuses[strings.ToLower(advance.Status)] {
+			continue
+		}
+		dueDate, err := parseDate(advance.DueDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date for transaction %s: %w", advance.ID, err)
+		}
+		due := dueDate.Format(DateLayout)
+		if due < fromDate || due > toDate {
+			continue
+		}
+
+		amount := advance.RepaymentAmount
+		if amount <= 0 {
+			amount = advance.Amount
+		}
+		dueMinor := Money(amount).MinorUnits(currency)
+
+		var onTime, late int64
+		for _, repayment := range repaymentsByAdvance[advance.ID] {
+			paidAt := repayment.ProcessedAt
+			if paidAt.IsZero() {
+				paidAt = repayment.CreatedAt
+			}
+			paid := Money(repayment.Amount).MinorUnits(currency)
+			if paidAt.Format(DateLayout) <= due {
+				onTime += paid
+			} else {
+				late += paid
+			}
+		}
+
+		// Overpayments don't raise the rate above the amount due
+		if onTime > dueMinor {
+			onTime = dueMinor
+		}
+		if late > dueMinor-onTime {
+			late = dueMinor - onTime
+		}
+
+		outstanding := dueMinor - onTime - late
+		if amount, ok := remaining[advance.ID]; ok {
+			if reported := Money(amount).MinorUnits(currency); reported < outstanding {
+				late += outstanding - maxMinor(reported, 0)
+				outstanding = maxMinor(reported, 0)
+			}
+		}
+
+		rate.AdvanceCount++
+		rate.DueMinor += dueMinor
+		rate.OnTimeMinor += onTime
+		rate.LateMinor += late
+		rate.OutstandingMinor += outstanding
+	}
+
+	if rate.DueMinor > 0 {
+		rate.OnTimeRate = float64(rate.OnTimeMinor) / float64(rate.DueMinor)
+		rate.LateRate = float64(rate.LateMinor) / float64(rate.DueMinor)
+		rate.OutstandingRate = float64(rate.OutstandingMinor) / float64(rate.DueMinor)
+	}
+
+	return rate, nil
+}
+
+// maxMinor returns the larger of two minor-unit amounts
+func maxMinor(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
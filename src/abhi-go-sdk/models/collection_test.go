@@ -0,0 +1,62 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestComputeCollectionRate(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	advances := []EmployerTransaction{
+		{ID: "a1", Amount: 1000, RepaymentAmount: 1000.10, Status: "completed", DueDate: "2024-05-15"},
+		{ID: "a2", Amount: 500, Status: "completed", DueDate: "2024-05-31"},
+		{ID: "a3", Amount: 300, Status: "completed", DueDate: "2024-05-20"},
+		{ID: "a4", Amount: 700, Status: "completed", DueDate: "2024-06-01"},
+		{ID: "a5", Amount: 900, Status: "rejected", DueDate: "2024-05-10"},
+	}
+	repayments := []Repayment{
+		{TransactionID: "a1", Amount: 600.05, Status: "completed", ProcessedAt: time.Date(2024, 5, 14, 9, 0, 0, 0, time.UTC)},
+		{TransactionID: "a1", Amount: 400.05, Status: "completed", ProcessedAt: time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)},
+		{TransactionID: "a1", Amount: 1000, Status: "failed", ProcessedAt: time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)},
+		{TransactionID: "a2", Amount: 200, Status: "completed", CreatedAt: time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC)},
+	}
+	balances := []OutstandingBalance{{
+		EmployeeID: "e1",
+		TransactionHistory: []OutstandingTransaction{
+			{ID: "a2", Type: "advance", RemainingAmount: 300},
+			{ID: "a3", Type: "advance", RemainingAmount: 100},
+		},
+	}}
+
+	rate, err := ComputeCollectionRate(from, to, advances, repayments, balances, CurrencyAED)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rate.AdvanceCount != 3 {
+		t.Errorf("Expected 3 advances due in the period, got %d", rate.AdvanceCount)
+	}
+	if rate.DueMinor != 180010 || rate.OnTimeMinor != 80005 || rate.LateMinor != 60005 || rate.OutstandingMinor != 40000 {
+		t.Errorf("Unexpected amounts: due=%d onTime=%d late=%d outstanding=%d",
+			rate.DueMinor, rate.OnTimeMinor, rate.LateMinor, rate.OutstandingMinor)
+	}
+	if sum := rate.OnTimeRate + rate.LateRate + rate.OutstandingRate; math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Expected rates to sum to 1, got %v", sum)
+	}
+	if math.Abs(rate.OnTimeRate-80005.0/180010.0) > 1e-9 {
+		t.Errorf("Unexpected on-time rate %v", rate.OnTimeRate)
+	}
+}
+
+func TestComputeCollectionRateWithoutAdvances(t *testing.T) {
+	rate, err := ComputeCollectionRate(time.Now(), time.Now(), nil, nil, nil, CurrencyAED)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rate.DueMinor != 0 || rate.OnTimeRate != 0 {
+		t.Errorf("Expected an empty rate, got %+v", rate)
+	}
+}
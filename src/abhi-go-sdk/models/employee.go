@@ -10,17 +10,18 @@ type Employee struct {
 	LastName        string    `json:"lastName" validate:"required"`
 	Department      string    `json:"department" validate:"required"`
 	Designation     string    `json:"designation" validate:"required"`
-	Phone           string    `json:"phone"`
+	Phone           string    `json:"phone" validate:"omitempty,uaephone"`
 	Email           string    `json:"email" validate:"required,email"`
 	DOB             string    `json:"dob" validate:"required"` // Format: YYYY-MM-DD
 	DateOfJoining   string    `json:"dateOfJoining" validate:"required"` // Format: YYYY-MM-DD
 	AccountTitle    string    `json:"accountTitle" validate:"required"`
 	AccountNumber   string    `json:"accountNumber" validate:"required"`
-	NetSalary       string    `json:"netSalary" validate:"required"`
-	EmiratesID      string    `json:"emiratesId" validate:"required"`
+	NetSalary       Money     `json:"netSalary" validate:"required,netsalary"` // Positive, at most 2 decimal places
+	EmiratesID      string    `json:"emiratesId" validate:"required,emiratesid"`
 	Gender          string    `json:"gender" validate:"required,oneof=Male Female male female"`
 	BankID          string    `json:"bankId" validate:"required,uuid4"`
 	PayrollStartDay int       `json:"payrollStartDay" validate:"required,min=1,max=31"`
+	Status          string    `json:"status,omitempty"` // "active" or "inactive", set by the API
 	CreatedAt       time.Time `json:"createdAt,omitempty"`
 	UpdatedAt       time.Time `json:"updatedAt,omitempty"`
 }
@@ -30,13 +31,28 @@ type EmployeesRequest struct {
 	Employees []Employee `json:"employees" validate:"required,min=1,dive"`
 }
 
+// EmployeeCreateResult reports the outcome of one employee in a bulk create
+type EmployeeCreateResult struct {
+	Index          int    `json:"index"` // Position of the employee in the request
+	EmployeeCode   string `json:"employeeCode"`
+	Success        bool   `json:"success"`
+	AlreadyExisted bool   `json:"alreadyExisted,omitempty"` // Set by idempotent creates when the employee was already on the roster
+	Error          string `json:"error,omitempty"`
+}
+
+// EmployeeCreateResponse represents the per-row response to a bulk create
+type EmployeeCreateResponse struct {
+	Results []EmployeeCreateResult `json:"results"`
+}
+
 // EmployeeListOptions represents query options for listing employees
 type EmployeeListOptions struct {
-	Page       int    `json:"page,omitempty"`
-	Limit      int    `json:"limit,omitempty"`
-	Search     string `json:"search,omitempty"`
-	Department string `json:"department,omitempty"`
-	Status     string `json:"status,omitempty"`
+	Page           int    `json:"page,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+	Search         string `json:"search,omitempty"`
+	Department     string `json:"department,omitempty"`
+	Status         string `json:"status,omitempty"`
+	OrganizationID string `json:"organizationId,omitempty"` // Restrict results to a sub-organization
 }
 
 // EmployeeListResponse represents the response for employee list
@@ -50,3 +66,8 @@ type EmployeeResponse struct {
 	Employee Employee `json:"employee"`
 }
 
+// EligibleEmployee represents an active employee currently eligible for an advance
+type EligibleEmployee struct {
+	Employee        Employee `json:"employee"`
+	AvailableAmount float64  `json:"availableAmount"`
+}
@@ -0,0 +1,39 @@
+package models
+
+// Import file formats accepted by EmployeeService.ValidateImport
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+)
+
+// ImportRowResult describes the validation outcome of a single row of an import file
+type ImportRowResult struct {
+	Row          int      `json:"row"` // 1-based data row, excluding any CSV header
+	EmployeeCode string   `json:"employeeCode,omitempty"`
+	Valid        bool     `json:"valid"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// ImportValidationReport describes the validation outcome of an employee import file
+type ImportValidationReport struct {
+	TotalRows   int               `json:"totalRows"`
+	ValidRows   int               `json:"validRows"`
+	InvalidRows int               `json:"invalidRows"`
+	Rows        []ImportRowResult `json:"rows"`
+}
+
+// HasErrors returns true if any row failed validation
+func (r *ImportValidationReport) HasErrors() bool {
+	return r.InvalidRows > 0
+}
+
+// InvalidRowResults returns only the rows that failed validation
+func (r *ImportValidationReport) InvalidRowResults() []ImportRowResult {
+	var invalid []ImportRowResult
+	for _, row := range r.Rows {
+		if !row.Valid {
+			invalid = append(invalid, row)
+		}
+	}
+	return invalid
+}
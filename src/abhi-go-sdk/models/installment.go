@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Installment represents the next repayment due from an employee
+type Installment struct {
+	EmployeeID       string    `json:"employeeId"`
+	DueDate          time.Time `json:"dueDate"`
+	Amount           float64   `json:"amount"`
+	RemainingBalance float64   `json:"remainingBalance"`         // Total outstanding, including this installment
+	TransactionIDs   []string  `json:"transactionIds,omitempty"` // Scheduled items making up the installment
+}
+
+// NextInstallment derives the next installment from the outstanding schedule. The earliest due date
+// among unpaid scheduled items is used, and the amount is the sum of their remaining amounts.
+// Without a schedule the NextDueDate applies to the full outstanding amount.
+// It returns nil if nothing is outstanding.
+func (b OutstandingBalance) NextInstallment() (*Installment, error) {
+	if b.TotalOutstanding <= 0 {
+		return nil, nil
+	}
+
+	installment := &Installment{
+		EmployeeID:       b.EmployeeID,
+		RemainingBalance: b.TotalOutstanding,
+	}
+
+	for _, item := range b.TransactionHistory {
+		if item.DueDate == "" || item.RemainingAmount <= 0 || item.Type == "repayment" {
+			continue
+		}
+
+		dueDate, err := parseDate(item.DueDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date for transaction %s: %w", item.ID, err)
+		}
+
+		switch {
+		case installment.DueDate.IsZero() || dueDate.Before(installment.DueDate):
+			installment.DueDate = dueDate
+			installment.Amount = item.RemainingAmount
+			installment.TransactionIDs = []string{item.ID}
+		case dueDate.Equal(installment.DueDate):
+			installment.Amount += item.RemainingAmount
+			installment.TransactionIDs = append(installment.TransactionIDs, item.ID)
+		}
+	}
+
+	if !installment.DueDate.IsZero() {
+		return installment, nil
+	}
+
+	if b.NextDueDate == "" {
+		return nil, fmt.Errorf("no due date for outstanding balance of %.2f", b.TotalOutstanding)
+	}
+
+	dueDate, err := parseDate(b.NextDueDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next due date: %w", err)
+	}
+	installment.DueDate = dueDate
+	installment.Amount = b.TotalOutstanding
+
+	return installment, nil
+}
+
+// parseDate parses a date sent either as YYYY-MM-DD or as a full timestamp
+func parseDate(value string) (time.Time, error) {
+	if date, err := time.Parse(DateLayout, value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -13,10 +13,11 @@ type Bank struct {
 
 // BankListOptions represents query options for listing banks
 type BankListOptions struct {
-	Page    int    `json:"page,omitempty"`
-	Limit   int    `json:"limit,omitempty"`
-	Country string `json:"country,omitempty"`
-	Active  *bool  `json:"active,omitempty"`
+	Page      int    `json:"page,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+	Country   string `json:"country,omitempty"`
+	Active    *bool  `json:"active,omitempty"`
+	SwiftCode string `json:"swiftCode,omitempty"`
 }
 
 // BankListResponse represents the response for bank list
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode determines how amounts are rounded to a currency's precision
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // Round half away from zero
+	RoundHalfEven                     // Round half to even (banker's rounding)
+	RoundDown                         // Round toward zero
+	RoundUp                           // Round away from zero
+)
+
+// Currency describes a currency's precision and rounding convention
+type Currency struct {
+	Code          string
+	DecimalPlaces int
+	RoundingMode  RoundingMode
+	// RoundingIncrement is the smallest allowed step in minor units,
+	// e.g. 25 to round fils to the nearest 25. Zero or one means no increment.
+	RoundingIncrement int64
+}
+
+// Known currencies
+var (
+	CurrencyAED = Currency{Code: "AED", DecimalPlaces: 2, RoundingMode: RoundHalfUp}
+	CurrencySAR = Currency{Code: "SAR", DecimalPlaces: 2, RoundingMode: RoundHalfUp}
+	CurrencyUSD = Currency{Code: "USD", DecimalPlaces: 2, RoundingMode: RoundHalfUp}
+	CurrencyPKR = Currency{Code: "PKR", DecimalPlaces: 2, RoundingMode: RoundHalfUp}
+	CurrencyKWD = Currency{Code: "KWD", DecimalPlaces: 3, RoundingMode: RoundHalfUp}
+	CurrencyBHD = Currency{Code: "BHD", DecimalPlaces: 3, RoundingMode: RoundHalfUp}
+	CurrencyOMR = Currency{Code: "OMR", DecimalPlaces: 3, RoundingMode: RoundHalfUp}
+)
+
+var currenciesByCode = map[string]Currency{
+	"AED": CurrencyAED,
+	"SAR": CurrencySAR,
+	"USD": CurrencyUSD,
+	"PKR": CurrencyPKR,
+	"KWD": CurrencyKWD,
+	"BHD": CurrencyBHD,
+	"OMR": CurrencyOMR,
+}
+
+// DefaultCurrency is the currency assumed when the API doesn't specify one
+var DefaultCurrency = CurrencyAED
+
+// CurrencyByCode looks up a known currency by its ISO 4217 code
+func CurrencyByCode(code string) (Currency, bool) {
+	currency, ok := currenciesByCode[strings.ToUpper(strings.TrimSpace(code))]
+	return currency, ok
+}
+
+// Money represents a monetary amount in major units (e.g. dirhams)
+type Money float64
+
+// RoundTo rounds the amount to the currency's precision using its rounding mode and increment
+func (m Money) RoundTo(currency Currency) Money {
+	scale := math.Pow10(currency.DecimalPlaces)
+	increment := float64(currency.RoundingIncrement)
+	if increment <= 1 {
+		increment = 1
+	}
+
+	// Strip binary representation error (e.g. 1.005*100 = 100.49999...) before rounding
+	minor := math.Round(float64(m)*scale*1e6) / 1e6
+	steps := minor / increment
+
+	switch currency.RoundingMode {
+	case RoundHalfEven:
+		steps = math.RoundToEven(steps)
+	case RoundDown:
+		steps = math.Trunc(steps)
+	case RoundUp:
+		if steps < 0 {
+			steps = math.Floor(steps)
+		} else {
+			steps = math.Ceil(steps)
+		}
+	default:
+		steps = math.Round(steps)
+	}
+
+	return Money(steps * increment / scale)
+}
+
+// MinorUnits returns the amount in the currency's minor units (e.g. fils) after rounding
+func (m Money) MinorUnits(currency Currency) int64 {
+	return int64(math.Round(float64(m.RoundTo(currency)) * math.Pow10(currency.DecimalPlaces)))
+}
+
+// MoneyFromMinorUnits converts an amount in minor units to Money
+func MoneyFromMinorUnits(minor int64, currency Currency) Money {
+	return Money(float64(minor) / math.Pow10(currency.DecimalPlaces))
+}
+
+// Float64 returns the amount as a float64
+func (m Money) Float64() float64 {
+	return float64(m)
+}
+
+// MarshalJSON encodes the amount as a quoted number such as "8000.5", the numeric string form
+// the API documents for fields like netSalary
+func (m Money) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(m)) || math.IsInf(float64(m), 0) {
+		return nil, fmt.Errorf("amount %v is not a finite number", float64(m))
+	}
+	return []byte(strconv.Quote(strconv.FormatFloat(float64(m), 'f', -1, 64))), nil
+}
+
+// quotedAmountPattern matches a plain decimal number, rejecting forms like "8,000" or "8000 AED"
+var quotedAmountPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// UnmarshalJSON decodes the amount from a quoted number such as "8000.50" or, leniently, a
+// bare JSON number. An empty string decodes as zero.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			*m = 0
+			return nil
+		}
+		if !quotedAmountPattern.MatchString(value) {
+			return fmt.Errorf("amount %q is not a number", value)
+		}
+		data = []byte(value)
+	}
+
+	amount, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("amount %s is not a number", data)
+	}
+	*m = Money(amount)
+	return nil
+}
+
+// AmountDecimalPlaces is the precision amounts are exchanged with the API in
+const AmountDecimalPlaces = 2
+
+// Amount is a monetary amount exchanged with the API. It is always encoded with exactly
+// AmountDecimalPlaces decimals, so arithmetic noise such as 1234.5599999999 never reaches the
+// wire. It decodes like Money.
+type Amount float64
+
+// Float64 returns the amount as a float64
+func (a Amount) Float64() float64 {
+	return float64(a)
+}
+
+// MarshalJSON encodes the amount as a JSON number with exactly two decimals
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(a)) || math.IsInf(float64(a), 0) {
+		return nil, fmt.Errorf("amount %v is not a finite number", float64(a))
+	}
+	return []byte(strconv.FormatFloat(float64(a), 'f', AmountDecimalPlaces, 64)), nil
+}
+
+// UnmarshalJSON decodes the amount from a JSON number or a quoted number, like Money
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	return (*Money)(a).UnmarshalJSON(data)
+}
+
+// CheckAmountRoundTrip verifies that amount survives encoding to JSON and back unchanged at
+// AmountDecimalPlaces precision, returning an error describing any discrepancy
+func CheckAmountRoundTrip(amount float64) error {
+	data, err := json.Marshal(Amount(amount))
+	if err != nil {
+		return err
+	}
+
+	var decoded Amount
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("amount %v encoded as %s does not decode: %w", amount, data, err)
+	}
+
+	scale := math.Pow10(AmountDecimalPlaces)
+	if math.Round(amount*scale) != math.Round(float64(decoded)*scale) {
+		return fmt.Errorf("amount %v encoded as %s decodes as %v", amount, data, float64(decoded))
+	}
+	return nil
+}
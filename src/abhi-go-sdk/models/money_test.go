@@ -0,0 +1,168 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMoneyRoundTo(t *testing.T) {
+	cashAED := CurrencyAED
+	cashAED.RoundingIncrement = 25
+
+	tests := []struct {
+		name     string
+		amount   Money
+		currency Currency
+		expected Money
+	}{
+		{"half up at binary boundary", 1.005, CurrencyAED, 1.01},
+		{"half up negative", -1.005, CurrencyAED, -1.01},
+		{"already rounded", 1234.56, CurrencyAED, 1234.56},
+		{"below half", 10.004, CurrencyAED, 10.00},
+		{"three decimals", 1.0005, CurrencyKWD, 1.001},
+		{"half even rounds down to even", 2.125, Currency{DecimalPlaces: 2, RoundingMode: RoundHalfEven}, 2.12},
+		{"half even rounds up to even", 2.135, Currency{DecimalPlaces: 2, RoundingMode: RoundHalfEven}, 2.14},
+		{"round down", 9.999, Currency{DecimalPlaces: 2, RoundingMode: RoundDown}, 9.99},
+		{"round up", 9.991, Currency{DecimalPlaces: 2, RoundingMode: RoundUp}, 10.00},
+		{"round up negative", -9.991, Currency{DecimalPlaces: 2, RoundingMode: RoundUp}, -10.00},
+		{"nearest 25 fils down", 10.12, cashAED, 10.00},
+		{"nearest 25 fils half", 10.125, cashAED, 10.25},
+		{"nearest 25 fils up", 10.88, cashAED, 11.00},
+		{"zero", 0, CurrencyAED, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.amount.RoundTo(test.currency)
+			if result.MinorUnits(test.currency) != test.expected.MinorUnits(test.currency) {
+				t.Errorf("Expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestMoneyMinorUnits(t *testing.T) {
+	if minor := Money(1234.56).MinorUnits(CurrencyAED); minor != 123456 {
+		t.Errorf("Expected 123456 fils, got %d", minor)
+	}
+	if minor := Money(0.1 + 0.2).MinorUnits(CurrencyAED); minor != 30 {
+		t.Errorf("Expected 30 fils, got %d", minor)
+	}
+	if amount := MoneyFromMinorUnits(123456, CurrencyAED); amount != 1234.56 {
+		t.Errorf("Expected 1234.56, got %v", amount)
+	}
+}
+
+func TestCurrencyByCode(t *testing.T) {
+	currency, ok := CurrencyByCode(" kwd ")
+	if !ok || currency.DecimalPlaces != 3 {
+		t.Errorf("Expected KWD with 3 decimal places, got %+v (found=%v)", currency, ok)
+	}
+	if _, ok := CurrencyByCode("XYZ"); ok {
+		t.Error("Expected unknown currency to not be found")
+	}
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Money
+		json  string
+	}{
+		{input: `"8000"`, want: 8000, json: `"8000"`},
+		{input: `8000`, want: 8000, json: `"8000"`},
+		{input: `"8000.50"`, want: 8000.50, json: `"8000.5"`},
+		{input: `8000.50`, want: 8000.50, json: `"8000.5"`},
+		{input: `" 8000.50 "`, want: 8000.50, json: `"8000.5"`},
+		{input: `""`, want: 0, json: `"0"`},
+	}
+
+	for _, tt := range tests {
+		var employee Employee
+		if err := json.Unmarshal([]byte(`{"netSalary":`+tt.input+`}`), &employee); err != nil {
+			t.Errorf("%s: expected no error, got %v", tt.input, err)
+			continue
+		}
+		if employee.NetSalary != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.input, tt.want, employee.NetSalary)
+		}
+
+		data, err := json.Marshal(employee.NetSalary)
+		if err != nil || string(data) != tt.json {
+			t.Errorf("%s: expected to marshal as %s, got %s (err %v)", tt.input, tt.json, data, err)
+		}
+
+		var decoded Money
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != tt.want {
+			t.Errorf("%s: expected %s to decode back to %v, got %v (err %v)", tt.input, data, tt.want, decoded, err)
+		}
+	}
+}
+
+func TestEmployeeNetSalaryMarshalsAsString(t *testing.T) {
+	data, err := json.Marshal(Employee{NetSalary: 9500.5})
+	if err != nil || !strings.Contains(string(data), `"netSalary":"9500.5"`) {
+		t.Fatalf("Expected netSalary as a numeric string, got %s (err %v)", data, err)
+	}
+
+	var decoded Employee
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.NetSalary != 9500.5 {
+		t.Errorf("Expected %s to decode back to 9500.5, got %v (err %v)", data, decoded.NetSalary, err)
+	}
+}
+
+func TestMoneyUnmarshalRejectsMalformedAmounts(t *testing.T) {
+	for _, input := range []string{`"8,000"`, `"8000 AED"`, `"abc"`, `true`, `"1e4"`} {
+		var amount Money
+		if err := json.Unmarshal([]byte(input), &amount); err == nil {
+			t.Errorf("%s: expected an error, got %v", input, amount)
+		}
+	}
+}
+
+func TestAmountMarshalsTwoDecimals(t *testing.T) {
+	tests := []struct {
+		amount float64
+		json   string
+	}{
+		{amount: 1234.56, json: `1234.56`},
+		{amount: 1234.5599999999, json: `1234.56`},
+		{amount: 0.1 + 0.2, json: `0.30`},
+		{amount: 1.005, json: `1.00`}, // 1.005 is stored as 1.00499...
+		{amount: 100, json: `100.00`},
+		{amount: 0, json: `0.00`},
+		{amount: -42.1, json: `-42.10`},
+		{amount: 99999999.99, json: `99999999.99`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(Amount(tt.amount))
+		if err != nil || string(data) != tt.json {
+			t.Errorf("%v: expected %s, got %s (err %v)", tt.amount, tt.json, data, err)
+		}
+	}
+
+	data, err := json.Marshal(Transaction{Amount: 1234.56})
+	if err != nil || !strings.Contains(string(data), `"amount":1234.56,`) {
+		t.Errorf("Expected the transaction amount with two decimals, got %s (err %v)", data, err)
+	}
+}
+
+func TestCheckAmountRoundTrip(t *testing.T) {
+	amounts := []float64{0, 0.01, 0.1 + 0.2, 1.005, 19.99, 1234.56, 1234.5599999999, 8000.5, 4503599627370.49, -0.01}
+	for _, amount := range amounts {
+		if err := CheckAmountRoundTrip(amount); err != nil {
+			t.Errorf("%v: expected a round trip, got %v", amount, err)
+		}
+	}
+
+	// Summaries decode from numbers and quoted numbers alike
+	var summary OutstandingBalanceSummary
+	if err := json.Unmarshal([]byte(`{"totalOutstanding":"1234.56","totalOverdue":0.07}`), &summary); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if summary.TotalOutstanding != 1234.56 || summary.TotalOverdue != 0.07 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
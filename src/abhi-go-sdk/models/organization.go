@@ -1,29 +1,32 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Organization represents an organization entity
 type Organization struct {
-	ID                     string       `json:"id,omitempty"`
-	DeletedDate            *time.Time   `json:"deletedDate,omitempty"`
-	Name                   string       `json:"name" validate:"required"`
-	OrganizationNumber     int          `json:"organizationNumber,omitempty"`
-	Industry               string       `json:"industry" validate:"required"`
-	ManagementAlias        string       `json:"managementAlias" validate:"required,min=4,max=100,lowercase"`
-	OrganizationType       string       `json:"organizationType,omitempty"`
-	Active                 bool         `json:"active,omitempty"`
-	CreditLimit            float64      `json:"creditLimit" validate:"required,gt=0"`
-	Address                string       `json:"address" validate:"required"`
-	City                   string       `json:"city" validate:"required"`
-	Phone                  string       `json:"phone,omitempty"`
-	Email                  string       `json:"email,omitempty,email"`
-	PayrollStartDay        int          `json:"payrollStartDay,omitempty" validate:"omitempty,min=1,max=31"`
-	BusinessTypeID         string       `json:"businessTypeId" validate:"required,uuid4"`
-	BusinessType           BusinessType `json:"businessType,omitempty"`
-	ParentOrganizationID   string       `json:"parentOrganizationId,omitempty"`
-	ParentOrganizations    *ParentOrg   `json:"parentOrganizations,omitempty"`
-	CreatedAt              time.Time    `json:"createdAt,omitempty"`
-	UpdatedAt              time.Time    `json:"updatedAt,omitempty"`
+	ID                   string           `json:"id,omitempty"`
+	DeletedDate          *time.Time       `json:"deletedDate,omitempty"`
+	Name                 string           `json:"name" validate:"required"`
+	OrganizationNumber   int              `json:"organizationNumber,omitempty"`
+	Industry             string           `json:"industry" validate:"required"`
+	ManagementAlias      string           `json:"managementAlias" validate:"required,min=4,max=100,lowercase"`
+	OrganizationType     OrganizationType `json:"organizationType,omitempty"`
+	Active               bool             `json:"active,omitempty"`
+	CreditLimit          float64          `json:"creditLimit" validate:"required,gt=0"`
+	Address              string           `json:"address" validate:"required"`
+	City                 string           `json:"city" validate:"required"`
+	Phone                string           `json:"phone,omitempty"`
+	Email                string           `json:"email,omitempty,email"`
+	PayrollStartDay      int              `json:"payrollStartDay,omitempty" validate:"omitempty,min=1,max=31"`
+	BusinessTypeID       string           `json:"businessTypeId" validate:"required,uuid4"`
+	BusinessType         BusinessType     `json:"businessType,omitempty"`
+	ParentOrganizationID string           `json:"parentOrganizationId,omitempty"`
+	ParentOrganizations  *ParentOrg       `json:"parentOrganizations,omitempty"`
+	CreatedAt            time.Time        `json:"createdAt,omitempty"`
+	UpdatedAt            time.Time        `json:"updatedAt,omitempty"`
 }
 
 // ParentOrg represents parent organization relationship
@@ -41,11 +44,30 @@ type CreateOrganizationRequest struct {
 	City            string  `json:"city" validate:"required"`
 	ManagementAlias string  `json:"managementAlias" validate:"required,min=4,max=100"`
 	CreditLimit     float64 `json:"creditLimit" validate:"required,gt=0"`
-	Phone           string  `json:"phone,omitempty"`
+	Phone           string  `json:"phone,omitempty" validate:"omitempty,uaephone"`
 	Email           string  `json:"email,omitempty,email"`
 	PayrollStartDay int     `json:"payrollStartDay,omitempty" validate:"omitempty,min=1,max=31"`
 }
 
+// UpdateOrganizationRequest represents a partial update of an organization. Only non-nil fields
+// are sent; the credit limit is changed with its own approval flow.
+type UpdateOrganizationRequest struct {
+	Name            *string `json:"name,omitempty" validate:"omitempty,min=1"`
+	Industry        *string `json:"industry,omitempty" validate:"omitempty,min=1"`
+	Address         *string `json:"address,omitempty" validate:"omitempty,min=1"`
+	City            *string `json:"city,omitempty" validate:"omitempty,min=1"`
+	Phone           *string `json:"phone,omitempty" validate:"omitempty,uaephone"`
+	Email           *string `json:"email,omitempty" validate:"omitempty,email"`
+	PayrollStartDay *int    `json:"payrollStartDay,omitempty" validate:"omitempty,min=1,max=31"`
+	Active          *bool   `json:"active,omitempty"`
+}
+
+// IsEmpty reports whether the request sets no fields
+func (r UpdateOrganizationRequest) IsEmpty() bool {
+	return r.Name == nil && r.Industry == nil && r.Address == nil && r.City == nil &&
+		r.Phone == nil && r.Email == nil && r.PayrollStartDay == nil && r.Active == nil
+}
+
 // OrganizationListOptions represents query options for listing organizations
 type OrganizationListOptions struct {
 	Page         int    `json:"page,omitempty"`
@@ -55,6 +77,7 @@ type OrganizationListOptions struct {
 	ShowInactive bool   `json:"showInactive,omitempty"` // Include inactive organizations
 	Column       string `json:"column,omitempty"`       // Sort column: "organizations.createdAt", "organizations.name"
 	Order        string `json:"order,omitempty"`        // Sort order: "ASC", "DESC"
+	Search       string `json:"search,omitempty"`       // Case-insensitive name filter applied server-side
 }
 
 // OrganizationListResponse represents the response for organization list
@@ -63,6 +86,67 @@ type OrganizationListResponse struct {
 	Results []Organization `json:"results"`
 }
 
+// OrgWithStats represents an organization together with its employee counts
+type OrgWithStats struct {
+	Organization
+	TotalEmployees  int `json:"totalEmployees"`
+	ActiveEmployees int `json:"activeEmployees"`
+}
+
+// OrgCreditView represents an organization together with how much of its credit limit is in use.
+// Utilized is the organization's total outstanding balance; Error is set instead when it could
+// not be fetched.
+type OrgCreditView struct {
+	Organization
+	Utilized        float64 `json:"utilized"`
+	Available       float64 `json:"available"`       // Credit limit less utilization; negative when over the limit
+	UtilizationRate float64 `json:"utilizationRate"` // Utilized as a fraction of the credit limit; zero without a limit
+	Error           string  `json:"error,omitempty"`
+}
+
+// NewOrgCreditView computes the credit view of an organization with the given outstanding total
+func NewOrgCreditView(org Organization, utilized float64) OrgCreditView {
+	view := OrgCreditView{
+		Organization: org,
+		Utilized:     utilized,
+		Available:    org.CreditLimit - utilized,
+	}
+	if org.CreditLimit > 0 {
+		view.UtilizationRate = utilized / org.CreditLimit
+	}
+	return view
+}
+
+// Credit limit approval statuses
+const (
+	CreditLimitApproved = "approved"
+	CreditLimitPending  = "pending"
+	CreditLimitRejected = "rejected"
+)
+
+// UpdateCreditLimitRequest represents a request to change an organization's credit limit
+type UpdateCreditLimitRequest struct {
+	CreditLimit float64 `json:"creditLimit" validate:"required,gt=0"`
+	Reason      string  `json:"reason,omitempty"`
+}
+
+// CreditLimitChange represents a credit limit change record
+type CreditLimitChange struct {
+	ID             string    `json:"id,omitempty"`
+	OrganizationID string    `json:"organizationId"`
+	PreviousLimit  float64   `json:"previousLimit"`
+	NewLimit       float64   `json:"newLimit"`
+	Reason         string    `json:"reason,omitempty"`
+	ApprovalStatus string    `json:"approvalStatus"`
+	ApprovedBy     string    `json:"approvedBy,omitempty"`
+	CreatedAt      time.Time `json:"createdAt,omitempty"`
+}
+
+// IsApplied returns false if the change is still pending approval or was rejected
+func (c CreditLimitChange) IsApplied() bool {
+	return !strings.EqualFold(c.ApprovalStatus, CreditLimitPending) && !strings.EqualFold(c.ApprovalStatus, CreditLimitRejected)
+}
+
 // CreateOrganizationResponse represents the response when creating an organization
 type CreateOrganizationResponse struct {
 	Message string                     `json:"message"`
@@ -87,5 +171,27 @@ type OrganizationUser struct {
 	Username  string `json:"username"`
 	Password  string `json:"password"`
 	MFASecret string `json:"MFAsecret,omitempty"`
+	Role      string `json:"role,omitempty"`
 }
 
+// Organization user roles
+const (
+	OrganizationRoleAdmin    = "admin"
+	OrganizationRoleOperator = "operator"
+	OrganizationRoleSupport  = "support"
+)
+
+// IsKnownOrganizationRole returns true if role is one of the roles created for every organization
+func IsKnownOrganizationRole(role string) bool {
+	switch strings.ToLower(role) {
+	case OrganizationRoleAdmin, OrganizationRoleOperator, OrganizationRoleSupport:
+		return true
+	}
+	return false
+}
+
+// ResetPasswordResponse represents the response when regenerating an organization user's password
+type ResetPasswordResponse struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OrganizationType identifies an organization's place in the organization hierarchy
+type OrganizationType string
+
+// Known organization types, as sent by the API
+const (
+	OrganizationTypeParent     OrganizationType = "parent"
+	OrganizationTypeChild      OrganizationType = "child"
+	OrganizationTypeSubsidiary OrganizationType = "subsidiary"
+	OrganizationTypeBranch     OrganizationType = "branch"
+)
+
+var knownOrganizationTypes = []OrganizationType{
+	OrganizationTypeParent,
+	OrganizationTypeChild,
+	OrganizationTypeSubsidiary,
+	OrganizationTypeBranch,
+}
+
+// ParseOrganizationType parses an organization type case-insensitively
+func ParseOrganizationType(value string) (OrganizationType, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	for _, known := range knownOrganizationTypes {
+		if string(known) == normalized {
+			return known, nil
+		}
+	}
+	return OrganizationType(value), fmt.Errorf("unknown organization type %q", value)
+}
+
+// IsKnown returns true if the type is one of the known organization types
+func (t OrganizationType) IsKnown() bool {
+	for _, known := range knownOrganizationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsParent returns true if the organization is a parent organization
+func (t OrganizationType) IsParent() bool {
+	return t == OrganizationTypeParent
+}
+
+// IsSubOrganization returns true if the organization sits under a parent organization
+func (t OrganizationType) IsSubOrganization() bool {
+	return t == OrganizationTypeChild || t == OrganizationTypeSubsidiary || t == OrganizationTypeBranch
+}
+
+// UnmarshalJSON normalizes known types regardless of case and keeps unknown types verbatim
+func (t *OrganizationType) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := ParseOrganizationType(value)
+	if err != nil {
+		*t = OrganizationType(value)
+		return nil
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseOrganizationType(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected OrganizationType
+		valid    bool
+	}{
+		{"parent", OrganizationTypeParent, true},
+		{"Child", OrganizationTypeChild, true},
+		{" SUBSIDIARY ", OrganizationTypeSubsidiary, true},
+		{"branch", OrganizationTypeBranch, true},
+		{"franchise", OrganizationType("franchise"), false},
+	}
+
+	for _, tt := range tests {
+		parsed, err := ParseOrganizationType(tt.value)
+		if (err == nil) != tt.valid {
+			t.Errorf("ParseOrganizationType(%q): expected valid=%v, got error %v", tt.value, tt.valid, err)
+		}
+		if parsed != tt.expected {
+			t.Errorf("ParseOrganizationType(%q): expected %q, got %q", tt.value, tt.expected, parsed)
+		}
+	}
+}
+
+func TestOrganizationTypePredicates(t *testing.T) {
+	tests := []struct {
+		orgType OrganizationType
+		parent  bool
+		subOrg  bool
+		known   bool
+	}{
+		{OrganizationTypeParent, true, false, true},
+		{OrganizationTypeChild, false, true, true},
+		{OrganizationTypeSubsidiary, false, true, true},
+		{OrganizationTypeBranch, false, true, true},
+		{OrganizationType(""), false, false, false},
+		{OrganizationType("franchise"), false, false, false},
+	}
+
+	for _, tt := range tests {
+		if tt.orgType.IsParent() != tt.parent {
+			t.Errorf("%q.IsParent(): expected %v", tt.orgType, tt.parent)
+		}
+		if tt.orgType.IsSubOrganization() != tt.subOrg {
+			t.Errorf("%q.IsSubOrganization(): expected %v", tt.orgType, tt.subOrg)
+		}
+		if tt.orgType.IsKnown() != tt.known {
+			t.Errorf("%q.IsKnown(): expected %v", tt.orgType, tt.known)
+		}
+	}
+}
+
+func TestOrganizationTypeJSON(t *testing.T) {
+	var org Organization
+	if err := json.Unmarshal([]byte(`{"organizationType":"Child"}`), &org); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if org.OrganizationType != OrganizationTypeChild {
+		t.Errorf("Expected child, got %q", org.OrganizationType)
+	}
+
+	if err := json.Unmarshal([]byte(`{"organizationType":"franchise"}`), &org); err != nil {
+		t.Fatalf("Expected unknown types to decode, got %v", err)
+	}
+	if org.OrganizationType != "franchise" {
+		t.Errorf("Expected unknown type to be kept verbatim, got %q", org.OrganizationType)
+	}
+
+	data, err := json.Marshal(Organization{OrganizationType: OrganizationTypeSubsidiary})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var wire map[string]interface{}
+	json.Unmarshal(data, &wire)
+	if wire["organizationType"] != "subsidiary" {
+		t.Errorf("Expected wire value subsidiary, got %v", wire["organizationType"])
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// DateLayout is the format used by the API for date-only fields (YYYY-MM-DD)
+const DateLayout = "2006-01-02"
+
+// PayrollCycle represents a monthly payroll cycle anchored on a start day
+type PayrollCycle struct {
+	StartDay int
+}
+
+// NewPayrollCycle creates a payroll cycle starting on the given day of the month
+func NewPayrollCycle(startDay int) PayrollCycle {
+	return PayrollCycle{StartDay: startDay}
+}
+
+// CurrentPeriod returns the first and last day of the payroll period containing t.
+// Start days beyond the length of a month fall on that month's last day.
+func (p PayrollCycle) CurrentPeriod(t time.Time) (start, end time.Time) {
+	year, month, day := t.Date()
+
+	start = p.startInMonth(year, month, t.Location())
+	if day < start.Day() {
+		start = p.startInMonth(year, month-1, t.Location())
+	}
+
+	next := p.startInMonth(start.Year(), start.Month()+1, t.Location())
+	end = next.AddDate(0, 0, -1)
+
+	return start, end
+}
+
+// startInMonth returns the cycle start date within the given month
+func (p PayrollCycle) startInMonth(year int, month time.Month, loc *time.Location) time.Time {
+	// Normalize month overflow (e.g. month 0 or 13)
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+
+	startDay := p.StartDay
+	if startDay < 1 {
+		startDay = 1
+	}
+
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if startDay > lastDay {
+		startDay = lastDay
+	}
+
+	return first.AddDate(0, 0, startDay-1)
+}
+
+// PayrollCycle returns the payroll cycle for the employee
+func (e Employee) PayrollCycle() PayrollCycle {
+	return NewPayrollCycle(e.PayrollStartDay)
+}
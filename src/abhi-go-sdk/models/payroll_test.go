@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPayrollCycleCurrentPeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		startDay int
+		at       string
+		start    string
+		end      string
+	}{
+		{name: "first of month", startDay: 1, at: "2024-05-15", start: "2024-05-01", end: "2024-05-31"},
+		{name: "before start day", startDay: 25, at: "2024-05-15", start: "2024-04-25", end: "2024-05-24"},
+		{name: "on start day", startDay: 25, at: "2024-05-25", start: "2024-05-25", end: "2024-06-24"},
+		{name: "day before start day", startDay: 25, at: "2024-05-24", start: "2024-04-25", end: "2024-05-24"},
+		{name: "unset start day", startDay: 0, at: "2024-12-31", start: "2024-12-01", end: "2024-12-31"},
+		{name: "31st in leap February", startDay: 31, at: "2024-02-10", start: "2024-01-31", end: "2024-02-28"},
+		{name: "31st on last day of leap February", startDay: 31, at: "2024-02-29", start: "2024-02-29", end: "2024-03-30"},
+		{name: "31st on last day of February", startDay: 31, at: "2023-02-28", start: "2023-02-28", end: "2023-03-30"},
+		{name: "30th from February", startDay: 30, at: "2024-03-01", start: "2024-02-29", end: "2024-03-29"},
+		{name: "31st in 30-day month", startDay: 31, at: "2024-04-30", start: "2024-04-30", end: "2024-05-30"},
+		{name: "31st before end of 31-day month", startDay: 31, at: "2024-05-30", start: "2024-04-30", end: "2024-05-30"},
+		{name: "December into January", startDay: 25, at: "2024-12-30", start: "2024-12-25", end: "2025-01-24"},
+		{name: "January from December", startDay: 25, at: "2025-01-10", start: "2024-12-25", end: "2025-01-24"},
+		{name: "31st across year end", startDay: 31, at: "2025-01-15", start: "2024-12-31", end: "2025-01-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, _ := time.Parse(DateLayout, tt.at)
+			start, end := NewPayrollCycle(tt.startDay).CurrentPeriod(at)
+			if start.Format(DateLayout) != tt.start || end.Format(DateLayout) != tt.end {
+				t.Errorf("Expected %s to %s, got %s to %s",
+					tt.start, tt.end, start.Format(DateLayout), end.Format(DateLayout))
+			}
+		})
+	}
+}
+
+func TestPayrollCycleCurrentPeriodKeepsLocation(t *testing.T) {
+	gst := time.FixedZone("GST", 4*60*60)
+	start, end := NewPayrollCycle(25).CurrentPeriod(time.Date(2024, 12, 31, 23, 30, 0, 0, gst))
+	if start.Location() != gst || end.Location() != gst {
+		t.Errorf("Expected the period in %v, got %v and %v", gst, start.Location(), end.Location())
+	}
+	if start.Hour() != 0 || end.Hour() != 0 {
+		t.Errorf("Expected the period to start and end at midnight, got %v and %v", start, end)
+	}
+}
+
+func TestPayrollCycleStartInMonth(t *testing.T) {
+	tests := []struct {
+		startDay int
+		year     int
+		month    time.Month
+		want     string
+	}{
+		{startDay: 15, year: 2024, month: time.May, want: "2024-05-15"},
+		{startDay: 0, year: 2024, month: time.May, want: "2024-05-01"},
+		{startDay: -3, year: 2024, month: time.May, want: "2024-05-01"},
+		{startDay: 31, year: 2024, month: time.February, want: "2024-02-29"},
+		{startDay: 31, year: 2023, month: time.February, want: "2023-02-28"},
+		{startDay: 29, year: 2023, month: time.February, want: "2023-02-28"},
+		{startDay: 31, year: 2024, month: time.April, want: "2024-04-30"},
+		{startDay: 31, year: 2024, month: time.December, want: "2024-12-31"},
+		{startDay: 15, year: 2024, month: 0, want: "2023-12-15"},
+		{startDay: 31, year: 2024, month: 13, want: "2025-01-31"},
+	}
+
+	for _, tt := range tests {
+		got := NewPayrollCycle(tt.startDay).startInMonth(tt.year, tt.month, time.UTC)
+		if got.Format(DateLayout) != tt.want {
+			t.Errorf("start day %d in %d-%02d: expected %s, got %s",
+				tt.startDay, tt.year, tt.month, tt.want, got.Format(DateLayout))
+		}
+	}
+}
@@ -5,7 +5,8 @@ import "time"
 // Repayment represents a repayment entity
 type Repayment struct {
 	ID                             string    `json:"id,omitempty"`
-	Amount                         float64   `json:"amount" validate:"required,gt=0"`
+	Amount                         Amount    `json:"amount" validate:"required,gt=0"`
+	Currency                       string    `json:"currency,omitempty"`
 	ClientRepaymentReferenceNumber string    `json:"clientRepaymentReferenceNumber" validate:"required"`
 	EmployeeID                     string    `json:"employeeId,omitempty"`
 	TransactionID                  string    `json:"transactionId,omitempty"`
@@ -26,6 +27,14 @@ type CreateRepaymentRequest struct {
 	TransactionID                  string  `json:"transactionId,omitempty"`
 	Description                    string  `json:"description,omitempty"`
 	PaymentMethod                  string  `json:"paymentMethod,omitempty"`
+	DueDate                        string  `json:"dueDate,omitempty"` // YYYY-MM-DD, for scheduled installments
+	// IdempotencyKey is sent as the Idempotency-Key header rather than in the body
+	IdempotencyKey string `json:"-"`
+}
+
+// CancelRepaymentRequest represents a request to cancel a pending repayment
+type CancelRepaymentRequest struct {
+	Reason string `json:"reason" validate:"required"`
 }
 
 // RepaymentResponse represents the response when creating a repayment
@@ -40,6 +49,7 @@ type OutstandingBalance struct {
 	EmployeeID           string  `json:"employeeId"`
 	EmployeeCode         string  `json:"employeeCode,omitempty"`
 	EmployeeName         string  `json:"employeeName,omitempty"`
+	Department           string  `json:"department,omitempty"`
 	TotalOutstanding     float64 `json:"totalOutstanding"`
 	PrincipalAmount      float64 `json:"principalAmount"`
 	InterestAmount       float64 `json:"interestAmount"`
@@ -82,14 +92,69 @@ type OutstandingBalanceListResponse struct {
 	Total   int                  `json:"total"`
 	Results []OutstandingBalance `json:"results"`
 	Summary OutstandingBalanceSummary `json:"summary,omitempty"`
+	// Warnings describes client-side corrections such as merged duplicate records
+	Warnings []string `json:"-"`
+}
+
+// DeduplicateBalances merges outstanding balance records that share an employee ID.
+// Amounts are summed, the largest days-past-due is kept, the earliest next due date and
+// the most recent payment are retained, and transaction histories are concatenated.
+// Records without an employee ID are left untouched. Order of first appearance is preserved.
+func DeduplicateBalances(balances []OutstandingBalance) []OutstandingBalance {
+	merged := make([]OutstandingBalance, 0, len(balances))
+	positions := make(map[string]int)
+
+	for _, balance := range balances {
+		pos, seen := positions[balance.EmployeeID]
+		if !seen || balance.EmployeeID == "" {
+			if balance.EmployeeID != "" {
+				positions[balance.EmployeeID] = len(merged)
+			}
+			merged = append(merged, balance)
+			continue
+		}
+
+		target := &merged[pos]
+		target.TotalOutstanding += balance.TotalOutstanding
+		target.PrincipalAmount += balance.PrincipalAmount
+		target.InterestAmount += balance.InterestAmount
+		target.PenaltyAmount += balance.PenaltyAmount
+		target.ProcessingFee += balance.ProcessingFee
+		target.OverdueAmount += balance.OverdueAmount
+
+		if balance.DaysPastDue > target.DaysPastDue {
+			target.DaysPastDue = balance.DaysPastDue
+		}
+		// Dates are YYYY-MM-DD so lexical order matches chronological order
+		if balance.NextDueDate != "" && (target.NextDueDate == "" || balance.NextDueDate < target.NextDueDate) {
+			target.NextDueDate = balance.NextDueDate
+		}
+		if balance.LastPaymentDate > target.LastPaymentDate {
+			target.LastPaymentDate = balance.LastPaymentDate
+			target.LastPaymentAmount = balance.LastPaymentAmount
+		}
+		if target.EmployeeCode == "" {
+			target.EmployeeCode = balance.EmployeeCode
+		}
+		if target.EmployeeName == "" {
+			target.EmployeeName = balance.EmployeeName
+		}
+		if target.Department == "" {
+			target.Department = balance.Department
+		}
+
+		target.TransactionHistory = append(target.TransactionHistory, balance.TransactionHistory...)
+	}
+
+	return merged
 }
 
 // OutstandingBalanceSummary represents summary statistics for outstanding balances
 type OutstandingBalanceSummary struct {
 	TotalEmployees       int     `json:"totalEmployees"`
-	TotalOutstanding     float64 `json:"totalOutstanding"`
-	TotalOverdue         float64 `json:"totalOverdue"`
-	AverageOutstanding   float64 `json:"averageOutstanding"`
+	TotalOutstanding     Amount  `json:"totalOutstanding"`
+	TotalOverdue         Amount  `json:"totalOverdue"`
+	AverageOutstanding   Amount  `json:"averageOutstanding"`
 	EmployeesWithOverdue int     `json:"employeesWithOverdue"`
 }
 
@@ -104,10 +169,13 @@ type RepaymentListOptions struct {
 	ClientRepaymentReferenceNumber string `json:"clientRepaymentReferenceNumber,omitempty"`
 	MinAmount                      float64 `json:"minAmount,omitempty"`
 	MaxAmount                      float64 `json:"maxAmount,omitempty"`
+	Cursor                         string  `json:"cursor,omitempty"` // Server-issued cursor; takes precedence over Page
+	PaymentMethod                  string  `json:"paymentMethod,omitempty"`
 }
 
 // RepaymentListResponse represents the response for repayment list
 type RepaymentListResponse struct {
-	Total   int         `json:"total"`
-	Results []Repayment `json:"results"`
+	Total      int         `json:"total"`
+	Results    []Repayment `json:"results"`
+	NextCursor string      `json:"nextCursor,omitempty"`
 }
\ No newline at end of file
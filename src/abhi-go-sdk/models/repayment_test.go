@@ -0,0 +1,49 @@
+package models
+
+import "testing"
+
+func TestDeduplicateBalances(t *testing.T) {
+	balances := []OutstandingBalance{
+		{EmployeeID: "emp-1", TotalOutstanding: 300, PrincipalAmount: 250, InterestAmount: 50, DaysPastDue: 3, NextDueDate: "2024-02-01", LastPaymentDate: "2024-01-05", LastPaymentAmount: 100},
+		{EmployeeID: "emp-2", TotalOutstanding: 500, PrincipalAmount: 500},
+		{EmployeeID: "emp-1", TotalOutstanding: 200, PrincipalAmount: 200, DaysPastDue: 10, NextDueDate: "2024-01-25", LastPaymentDate: "2024-01-10", LastPaymentAmount: 40,
+			TransactionHistory: []OutstandingTransaction{{ID: "tx-1"}}},
+	}
+
+	merged := DeduplicateBalances(balances)
+
+	if len(merged) != 2 {
+		t.Fatalf("Expected 2 balances after merge, got %d", len(merged))
+	}
+	if merged[0].EmployeeID != "emp-1" || merged[1].EmployeeID != "emp-2" {
+		t.Errorf("Expected order of first appearance to be preserved, got %s, %s", merged[0].EmployeeID, merged[1].EmployeeID)
+	}
+
+	emp1 := merged[0]
+	if emp1.TotalOutstanding != 500 {
+		t.Errorf("Expected total outstanding 500, got %v", emp1.TotalOutstanding)
+	}
+	if emp1.PrincipalAmount != 450 || emp1.InterestAmount != 50 {
+		t.Errorf("Expected principal 450 and interest 50, got %v and %v", emp1.PrincipalAmount, emp1.InterestAmount)
+	}
+	if emp1.DaysPastDue != 10 {
+		t.Errorf("Expected max days past due 10, got %d", emp1.DaysPastDue)
+	}
+	if emp1.NextDueDate != "2024-01-25" {
+		t.Errorf("Expected earliest next due date 2024-01-25, got %s", emp1.NextDueDate)
+	}
+	if emp1.LastPaymentDate != "2024-01-10" || emp1.LastPaymentAmount != 40 {
+		t.Errorf("Expected latest payment 2024-01-10/40, got %s/%v", emp1.LastPaymentDate, emp1.LastPaymentAmount)
+	}
+	if len(emp1.TransactionHistory) != 1 {
+		t.Errorf("Expected transaction history to be merged, got %d entries", len(emp1.TransactionHistory))
+	}
+}
+
+func TestDeduplicateBalancesWithoutDuplicates(t *testing.T) {
+	balances := []OutstandingBalance{{EmployeeID: "emp-1"}, {EmployeeID: "emp-2"}, {}, {}}
+
+	if merged := DeduplicateBalances(balances); len(merged) != len(balances) {
+		t.Errorf("Expected %d balances, got %d", len(balances), len(merged))
+	}
+}
@@ -1,5 +1,13 @@
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"abhi-go-sdk/errors"
+)
+
 // APIResponse represents the standard API response structure
 type APIResponse struct {
 	StatusCode int         `json:"statusCode"`
@@ -36,8 +44,83 @@ type User struct {
 
 // ErrorResponse represents an error response from the API
 type ErrorResponse struct {
-	StatusCode int    `json:"statusCode"`
-	Message    string `json:"message"`
-	Error      string `json:"error,omitempty"`
-	Details    string `json:"details,omitempty"`
-}
\ No newline at end of file
+	StatusCode int       `json:"statusCode"`
+	Message    string    `json:"message"`
+	Error      string    `json:"error,omitempty"`
+	Code       ErrorCode `json:"code,omitempty"`
+	Details    string    `json:"details,omitempty"`
+	// Data holds structured error details, e.g. the amounts behind a rejected transaction
+	Data ErrorData `json:"data,omitempty"`
+	// ValidationErrors holds per-field details for a rejected request
+	ValidationErrors []errors.ValidationError `json:"validationErrors,omitempty"`
+}
+
+// ErrorCode is the code of an API error. Documented error bodies send the HTTP status as a
+// number, e.g. "code": 400, while some endpoints send a symbolic string such as
+// "INSUFFICIENT_BALANCE"; both decode, numbers as their decimal form.
+type ErrorCode string
+
+// UnmarshalJSON accepts a JSON string, number or null
+func (c *ErrorCode) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*c = ""
+	case len(data) > 0 && data[0] == '"':
+		var code string
+		if err := json.Unmarshal(data, &code); err != nil {
+			return err
+		}
+		*c = ErrorCode(code)
+	default:
+		var code json.Number
+		if err := json.Unmarshal(data, &code); err != nil {
+			return fmt.Errorf("error code must be a string or number, got %s", data)
+		}
+		*c = ErrorCode(code.String())
+	}
+	return nil
+}
+
+// ErrorData holds the details of an API error. Most endpoints send an object, but some send
+// a bare string, number or array; those decode under the "value" key rather than failing
+// the whole error body.
+type ErrorData map[string]interface{}
+
+// UnmarshalJSON accepts any JSON value, keeping non-object values under "value"
+func (d *ErrorData) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = nil
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		*d = fields
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*d = ErrorData{"value": value}
+	return nil
+}
+
+// IsNumeric reports whether the code is a number, which mirrors the HTTP status rather than
+// identifying the error
+func (c ErrorCode) IsNumeric() bool {
+	if c == "" {
+		return false
+	}
+	for _, r := range c {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// joinDate parses DateOfJoining as a calendar date, accepting YYYY-MM-DD or a full timestamp
+func (e Employee) joinDate() (time.Time, error) {
+	value := strings.TrimSpace(e.DateOfJoining)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("employee %s has no date of joining", e.EmployeeCode)
+	}
+
+	joined, err := parseDate(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date of joining %q for employee %s: %w", value, e.EmployeeCode, err)
+	}
+	return calendarDate(joined), nil
+}
+
+// calendarDate returns midnight UTC on t's calendar date, so dates compare regardless of zone
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Tenure returns how long the employee has been employed as of the given date, counted in whole
+// days. An employee who joined on asOf has zero tenure; a future join date is an error.
+func (e Employee) Tenure(asOf time.Time) (time.Duration, error) {
+	joined, err := e.joinDate()
+	if err != nil {
+		return 0, err
+	}
+
+	today := calendarDate(asOf)
+	if joined.After(today) {
+		return 0, fmt.Errorf("employee %s joins on %s, after %s", e.EmployeeCode, joined.Format(DateLayout), today.Format(DateLayout))
+	}
+	return today.Sub(joined), nil
+}
+
+// IsEligibleByTenure reports whether the employee has been employed for at least minMonths
+// calendar months as of the given date. Employees who haven't joined yet are not eligible.
+func (e Employee) IsEligibleByTenure(minMonths int, asOf time.Time) (bool, error) {
+	joined, err := e.joinDate()
+	if err != nil {
+		return false, err
+	}
+
+	today := calendarDate(asOf)
+	if joined.After(today) {
+		return false, nil
+	}
+	return !joined.AddDate(0, minMonths, 0).After(today), nil
+}
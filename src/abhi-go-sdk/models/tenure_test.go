@@ -0,0 +1,64 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmployeeTenure(t *testing.T) {
+	asOf := time.Date(2024, 5, 15, 17, 30, 0, 0, time.FixedZone("GST", 4*60*60))
+
+	tests := []struct {
+		joined  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{joined: "2024-05-15", want: 0},
+		{joined: "2024-05-14", want: 24 * time.Hour},
+		{joined: " 2024-04-15 ", want: 30 * 24 * time.Hour},
+		{joined: "2024-05-01T09:00:00Z", want: 14 * 24 * time.Hour},
+		{joined: "2024-05-16", wantErr: true},
+		{joined: "15/05/2024", wantErr: true},
+		{joined: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Employee{EmployeeCode: "E001", DateOfJoining: tt.joined}.Tenure(asOf)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %v", tt.joined, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q: expected %v, got %v (err %v)", tt.joined, tt.want, got, err)
+		}
+	}
+}
+
+func TestEmployeeIsEligibleByTenure(t *testing.T) {
+	asOf := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		joined    string
+		minMonths int
+		want      bool
+	}{
+		{joined: "2024-02-15", minMonths: 3, want: true},
+		{joined: "2024-02-16", minMonths: 3, want: false},
+		{joined: "2024-05-15", minMonths: 0, want: true},
+		{joined: "2024-05-15", minMonths: 1, want: false},
+		{joined: "2024-06-01", minMonths: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		got, err := Employee{DateOfJoining: tt.joined}.IsEligibleByTenure(tt.minMonths, asOf)
+		if err != nil || got != tt.want {
+			t.Errorf("%s with %d months: expected %v, got %v (err %v)", tt.joined, tt.minMonths, tt.want, got, err)
+		}
+	}
+
+	if _, err := (Employee{DateOfJoining: "not a date"}).IsEligibleByTenure(3, asOf); err == nil {
+		t.Error("Expected an error for an unparseable date of joining")
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 	"abhi-go-sdk/errors"
 )
@@ -9,7 +10,8 @@ import (
 type Transaction struct {
 	ID                string    `json:"id,omitempty"`
 	EmployeeID        string    `json:"employeeId" validate:"required"`
-	Amount            float64   `json:"amount" validate:"required,gt=0"`
+	Amount            Amount    `json:"amount" validate:"required,gt=0"`
+	Currency          string    `json:"currency,omitempty"`
 	Type              string    `json:"type" validate:"required,oneof=advance repayment"`
 	Status            string    `json:"status,omitempty"`
 	Description       string    `json:"description,omitempty"`
@@ -31,17 +33,21 @@ type TransactionRequest struct {
 	Type        string  `json:"type" validate:"required,oneof=advance repayment"`
 	Description string  `json:"description,omitempty"`
 	DueDate     string  `json:"dueDate,omitempty"`
+	// IdempotencyKey is sent as the Idempotency-Key header rather than in the body
+	IdempotencyKey string `json:"-"`
 }
 
 // TransactionListOptions represents query options for listing transactions
 type TransactionListOptions struct {
-	Page       int    `json:"page,omitempty"`
-	Limit      int    `json:"limit,omitempty"`
-	EmployeeID string `json:"employeeId,omitempty"`
-	Status     string `json:"status,omitempty"`
-	Type       string `json:"type,omitempty"`
-	StartDate  string `json:"startDate,omitempty"`
-	EndDate    string `json:"endDate,omitempty"`
+	Page       int     `json:"page,omitempty"`
+	Limit      int     `json:"limit,omitempty"`
+	EmployeeID string  `json:"employeeId,omitempty"`
+	Status     string  `json:"status,omitempty"`
+	Type       string  `json:"type,omitempty"`
+	StartDate  string  `json:"startDate,omitempty"`
+	EndDate    string  `json:"endDate,omitempty"`
+	MinAmount  float64 `json:"minAmount,omitempty"`
+	MaxAmount  float64 `json:"maxAmount,omitempty"`
 }
 
 // TransactionListResponse represents the response for transaction list
@@ -98,16 +104,57 @@ type TransactionStatusResponse struct {
 	LastUpdated   string `json:"lastUpdated"`
 }
 
+// terminalTransactionStatuses lists the statuses a transaction never leaves
+var terminalTransactionStatuses = map[string]bool{
+	"completed": true,
+	"failed":    true,
+	"rejected":  true,
+	"cancelled": true,
+	"reversed":  true,
+}
+
+// IsTerminalTransactionStatus reports whether a transaction with the given status will no longer
+// change, ignoring case
+func IsTerminalTransactionStatus(status string) bool {
+	return terminalTransactionStatuses[strings.ToLower(status)]
+}
+
+// CancelTransactionRequest represents an employee's request to cancel a pending transaction
+type CancelTransactionRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// RejectTransactionRequest represents an employer's rejection of a pending transaction
+type RejectTransactionRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// StatusEvent represents a single status change in a transaction's lifecycle
+type StatusEvent struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Actor     string    `json:"actor,omitempty"` // User or system that made the change
+	Message   string    `json:"message,omitempty"`
+}
+
+// TransactionStatusHistoryResponse represents the status timeline of a transaction
+type TransactionStatusHistoryResponse struct {
+	TransactionID string        `json:"transactionId"`
+	Events        []StatusEvent `json:"events"`
+}
+
 // EmployerTransactionListOptions represents query options for employer transaction listing
 type EmployerTransactionListOptions struct {
-	Page         int    `json:"page,omitempty"`
-	Limit        int    `json:"limit,omitempty"`
-	Status       string `json:"status,omitempty"`
-	Type         string `json:"type,omitempty"`
-	StartDate    string `json:"startDate,omitempty"`
-	EndDate      string `json:"endDate,omitempty"`
-	EmployeeCode string `json:"employeeCode,omitempty"`
-	Department   string `json:"department,omitempty"`
+	Page         int     `json:"page,omitempty"`
+	Limit        int     `json:"limit,omitempty"`
+	Status       string  `json:"status,omitempty"`
+	Type         string  `json:"type,omitempty"`
+	StartDate    string  `json:"startDate,omitempty"`
+	EndDate      string  `json:"endDate,omitempty"`
+	EmployeeCode string  `json:"employeeCode,omitempty"`
+	Department   string  `json:"department,omitempty"`
+	MinAmount    float64 `json:"minAmount,omitempty"`
+	MaxAmount    float64 `json:"maxAmount,omitempty"`
 }
 
 // EmployerTransactionResponse represents employer view of transactions
@@ -124,6 +171,7 @@ type EmployerTransaction struct {
 	EmployeeName    string  `json:"employeeName"`
 	Department      string  `json:"department"`
 	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency,omitempty"`
 	Type            string  `json:"type"`
 	Status          string  `json:"status"`
 	RequestedAt     string  `json:"requestedAt"`
@@ -132,6 +180,19 @@ type EmployerTransaction struct {
 	RepaymentAmount float64 `json:"repaymentAmount"`
 }
 
+// TransactionTotals holds the number and total amount of a group of transactions
+type TransactionTotals struct {
+	Count  int    `json:"count"`
+	Amount Amount `json:"amount"`
+}
+
+// TransactionSummary aggregates employer transactions over a period
+type TransactionSummary struct {
+	TransactionTotals
+	ByType   map[string]TransactionTotals `json:"byType"`
+	ByStatus map[string]TransactionTotals `json:"byStatus"`
+}
+
 // ValidationQuestion represents a validation question
 type ValidationQuestion struct {
 	ID       string `json:"id"`
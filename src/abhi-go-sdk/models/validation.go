@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// emiratesIDPattern matches the 784-YYYY-NNNNNNN-C shape of an Emirates ID
+var emiratesIDPattern = regexp.MustCompile(`^784-\d{4}-\d{7}-\d$`)
+
+// ValidateEmiratesID checks that id has the 784-YYYY-NNNNNNN-C shape of an Emirates ID and that
+// its final digit is the Luhn check digit of the others
+func ValidateEmiratesID(id string) error {
+	if !emiratesIDPattern.MatchString(id) {
+		return fmt.Errorf("emirates ID %q must have the form 784-YYYY-NNNNNNN-C", id)
+	}
+	if !luhnValid(strings.ReplaceAll(id, "-", "")) {
+		return fmt.Errorf("emirates ID %q has an invalid check digit", id)
+	}
+	return nil
+}
+
+// luhnValid reports whether a string of digits ends with a valid Luhn check digit
+func luhnValid(digits string) bool {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// uaeMobilePattern matches a UAE mobile number in international (+9715XXXXXXXX) or local
+// (05XXXXXXXX) form, capturing the 9 digits after the country code
+var uaeMobilePattern = regexp.MustCompile(`^(?:\+971|0)(5\d{8})$`)
+
+// NormalizeUAEPhone checks that phone is a UAE mobile number, in +9715XXXXXXXX or 05XXXXXXXX form
+// with optional spaces or hyphens, and returns it in E.164 form (+9715XXXXXXXX)
+func NormalizeUAEPhone(phone string) (string, error) {
+	compact := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(phone))
+	match := uaeMobilePattern.FindStringSubmatch(compact)
+	if match == nil {
+		return "", fmt.Errorf("phone %q must be a UAE mobile number like +971501234567 or 0501234567", phone)
+	}
+	return "+971" + match[1], nil
+}
+
+// netSalaryPattern matches a plain decimal number with at most 2 decimal places
+var netSalaryPattern = regexp.MustCompile(`^\d+(\.\d{1,2})?$`)
+
+// ParseNetSalary parses a net salary, which must be a positive number with at most 2 decimal places
+func ParseNetSalary(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("net salary is empty")
+	}
+	if strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("net salary %q must be positive", value)
+	}
+	if !netSalaryPattern.MatchString(value) {
+		return 0, fmt.Errorf("net salary %q must be a number with at most 2 decimal places", value)
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("net salary %q is not a number: %w", value, err)
+	}
+
+	return Money(amount), ValidateNetSalary(Money(amount))
+}
+
+// ValidateNetSalary checks that a net salary is positive with at most 2 decimal places
+func ValidateNetSalary(amount Money) error {
+	value := float64(amount)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("net salary %v is not a number", value)
+	}
+	if value <= 0 {
+		return fmt.Errorf("net salary %v must be positive", value)
+	}
+	// Allow for binary representation error, e.g. 8000.10*100 = 800009.9999...
+	if cents := value * 100; math.Abs(cents-math.Round(cents)) > 1e-6 {
+		return fmt.Errorf("net salary %v must have at most 2 decimal places", value)
+	}
+	return nil
+}
+
+// NetSalaryAmount returns the employee's net salary as a number, checking it is valid
+func (e Employee) NetSalaryAmount() (float64, error) {
+	if err := ValidateNetSalary(e.NetSalary); err != nil {
+		return 0, err
+	}
+	return e.NetSalary.Float64(), nil
+}
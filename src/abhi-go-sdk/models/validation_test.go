@@ -0,0 +1,97 @@
+package models
+
+import "testing"
+
+func TestParseNetSalary(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Money
+		wantErr bool
+	}{
+		{value: "8000", want: 8000},
+		{value: "8000.5", want: 8000.5},
+		{value: " 12500.75 ", want: 12500.75},
+		{value: "abc", wantErr: true},
+		{value: "8,000", wantErr: true},
+		{value: "1e4", wantErr: true},
+		{value: "-500", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "0.00", wantErr: true},
+		{value: "100.123", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNetSalary(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %v", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q: expected %v, got %v (err %v)", tt.value, tt.want, got, err)
+		}
+	}
+}
+
+func TestValidateEmiratesID(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{id: "784-1990-1234567-6"},
+		{id: "784-1985-7654321-3"},
+		{id: "784-1990-1234567-1", wantErr: true},  // Wrong check digit
+		{id: "784-1990-1234568-6", wantErr: true},  // Mistyped digit
+		{id: "784-1990-123456-6", wantErr: true},   // Too short
+		{id: "784-1990-12345678-6", wantErr: true}, // Too long
+		{id: "785-1990-1234567-6", wantErr: true},
+		{id: "784199012345676", wantErr: true},
+		{id: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateEmiratesID(tt.id)
+		if tt.wantErr && err == nil {
+			t.Errorf("%q: expected an error", tt.id)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%q: expected no error, got %v", tt.id, err)
+		}
+	}
+}
+
+func TestNormalizeUAEPhone(t *testing.T) {
+	tests := []struct {
+		phone   string
+		want    string
+		wantErr bool
+	}{
+		{phone: "+971501234567", want: "+971501234567"},
+		{phone: "0501234567", want: "+971501234567"},
+		{phone: "+971 50 123 4567", want: "+971501234567"},
+		{phone: "050-123-4567", want: "+971501234567"},
+		{phone: "+97150123456", wantErr: true},   // Too short
+		{phone: "+9715012345678", wantErr: true}, // Too long
+		{phone: "+971401234567", wantErr: true},  // Landline
+		{phone: "971501234567", wantErr: true},
+		{phone: "501234567", wantErr: true},
+		{phone: "+966501234567", wantErr: true},
+		{phone: "+971-50-12a-4567", wantErr: true},
+		{phone: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeUAEPhone(tt.phone)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %q", tt.phone, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q: expected %q, got %q (err %v)", tt.phone, tt.want, got, err)
+		}
+	}
+}
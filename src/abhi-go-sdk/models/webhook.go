@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WebhookEventType identifies the kind of status update Abhi posts to a customer webhook
+type WebhookEventType string
+
+// Webhook event types
+const (
+	WebhookTransactionStatusUpdated WebhookEventType = "transaction.status_updated"
+	WebhookRepaymentStatusUpdated   WebhookEventType = "repayment.status_updated"
+)
+
+// WebhookEvent represents a status update delivered to a customer webhook. Data holds the raw
+// payload; for known event types it is also decoded into Transaction or Repayment.
+type WebhookEvent struct {
+	ID          string           `json:"id"`
+	Type        WebhookEventType `json:"type"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	Data        json.RawMessage  `json:"data"`
+	Transaction *Transaction     `json:"-"`
+	Repayment   *Repayment       `json:"-"`
+}
+
+// ParseWebhookEvent decodes a webhook body, decoding its data into the typed payload for known
+// event types. Unknown event types are returned with only the raw Data so new events don't break
+// older receivers.
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+
+	switch event.Type {
+	case WebhookTransactionStatusUpdated:
+		event.Transaction = &Transaction{}
+		if err := json.Unmarshal(event.Data, event.Transaction); err != nil {
+			return nil, fmt.Errorf("failed to decode %s data: %w", event.Type, err)
+		}
+	case WebhookRepaymentStatusUpdated:
+		event.Repayment = &Repayment{}
+		if err := json.Unmarshal(event.Data, event.Repayment); err != nil {
+			return nil, fmt.Errorf("failed to decode %s data: %w", event.Type, err)
+		}
+	}
+
+	return &event, nil
+}
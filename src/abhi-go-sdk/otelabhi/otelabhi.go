@@ -0,0 +1,79 @@
+// Package otelabhi traces Abhi SDK calls with OpenTelemetry. It lives in its own module so the
+// SDK itself doesn't depend on OpenTelemetry.
+package otelabhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"abhi-go-sdk/client"
+)
+
+// ScopeName is the instrumentation scope of the spans created for SDK calls
+const ScopeName = "abhi-go-sdk"
+
+// TracerProvider adapts an OpenTelemetry TracerProvider to client.TracerProvider
+type TracerProvider struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// New creates a tracer provider that starts client spans with provider and propagates trace
+// context with the global propagator
+func New(provider trace.TracerProvider) *TracerProvider {
+	return &TracerProvider{
+		tracer:     provider.Tracer(ScopeName),
+		propagator: otel.GetTextMapPropagator(),
+	}
+}
+
+// WithPropagator sets the propagator that writes trace context into outgoing request headers
+func (tp *TracerProvider) WithPropagator(propagator propagation.TextMapPropagator) *TracerProvider {
+	tp.propagator = propagator
+	return tp
+}
+
+// StartSpan starts a client span named name as a child of any span in ctx
+func (tp *TracerProvider) StartSpan(ctx context.Context, name string) (context.Context, client.Span) {
+	ctx, span := tp.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &otelSpan{span: span}
+}
+
+// Inject writes the trace context carried by ctx into header
+func (tp *TracerProvider) Inject(ctx context.Context, header http.Header) {
+	tp.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// otelSpan adapts an OpenTelemetry span to client.Span
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) SetAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case bool:
+		s.span.SetAttributes(attribute.Bool(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+	}
+}
+
+func (s *otelSpan) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *otelSpan) End() {
+	s.span.End()
+}
@@ -0,0 +1,90 @@
+package otelabhi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"abhi-go-sdk/client"
+	"abhi-go-sdk/models"
+)
+
+func TestSpansAroundRequests(t *testing.T) {
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ErrorResponse{StatusCode: 404, Message: "Employee not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(models.APIResponse{StatusCode: 200, Data: map[string]string{}})
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	config := client.NewConfig(server.URL, "", "").
+		SetTracerProvider(New(provider).WithPropagator(propagation.TraceContext{}))
+	config.APIKey = "test-key"
+	c := client.New(config)
+
+	if err := c.GET(context.Background(), "/employees?page=1", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := c.GET(context.Background(), "/employees/missing", nil); err == nil {
+		t.Fatal("Expected a not found error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+
+	ok := spans[0]
+	if ok.Name != "HTTP GET /employees" || ok.SpanKind != trace.SpanKindClient {
+		t.Errorf("Unexpected span %q of kind %v", ok.Name, ok.SpanKind)
+	}
+	expected := map[attribute.Key]attribute.Value{
+		client.SpanAttributeMethod:     attribute.StringValue("GET"),
+		client.SpanAttributeEndpoint:   attribute.StringValue("/employees"),
+		client.SpanAttributeStatusCode: attribute.IntValue(200),
+	}
+	for _, attr := range ok.Attributes {
+		if want, found := expected[attr.Key]; found && attr.Value != want {
+			t.Errorf("Expected %s=%v, got %v", attr.Key, want.Emit(), attr.Value.Emit())
+		}
+		delete(expected, attr.Key)
+	}
+	if len(expected) != 0 {
+		t.Errorf("Missing attributes %v", expected)
+	}
+	if ok.Status.Code == codes.Error {
+		t.Error("Expected the successful call's span not to be marked as an error")
+	}
+
+	failed := spans[1]
+	if failed.Status.Code != codes.Error || len(failed.Events) == 0 {
+		t.Errorf("Expected the failed call's span to record the error, got status %v with %d events", failed.Status, len(failed.Events))
+	}
+
+	// The API receives the trace context of the span wrapping its request
+	for i, span := range spans {
+		if !strings.Contains(traceparents[i], span.SpanContext.SpanID().String()) {
+			t.Errorf("Request %d: expected traceparent with span %s, got %q", i+1, span.SpanContext.SpanID(), traceparents[i])
+		}
+	}
+}
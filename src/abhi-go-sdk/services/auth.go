@@ -136,6 +136,8 @@ func (s *AuthService) ConfirmPasswordReset(ctx context.Context, req models.Reset
 
 // SetupMFA sets up multi-factor authentication for the current user
 func (s *AuthService) SetupMFA(ctx context.Context, req models.MFASetupRequest) (*models.MFAResponse, error) {
+	req.Phone = normalizePhone(req.Phone)
+
 	var result models.MFAResponse
 	err := s.client.POST(ctx, "/auth/mfa/setup", req, &result)
 	if err != nil {
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// lookupConcurrency bounds the number of concurrent read-only lookups made on the caller's behalf
+const lookupConcurrency = 4
+
+// BatchOpts configures concurrent batch operations
+type BatchOpts struct {
+	Concurrency int           // Maximum number of in-flight requests (defaults to 1)
+	Stagger     time.Duration // Minimum delay between starting consecutive requests
+}
+
+// bulkWriteConcurrency bounds the number of concurrent writes made by bulk helpers that don't take BatchOpts
+const bulkWriteConcurrency = 4
+
+// BatchReport describes the outcome of a bulk operation keyed by caller-supplied keys
+type BatchReport struct {
+	Total     int
+	Succeeded []string         // Keys applied successfully, sorted
+	Failed    map[string]error // Keys that failed, with the reason
+}
+
+// newBatchReport creates an empty report for a bulk operation of the given size
+func newBatchReport(total int) *BatchReport {
+	return &BatchReport{
+		Total:  total,
+		Failed: make(map[string]error),
+	}
+}
+
+// FailedKeys returns the keys that failed, sorted
+func (r *BatchReport) FailedKeys() []string {
+	keys := make([]string, 0, len(r.Failed))
+	for key := range r.Failed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// runBatch calls fn for each of n items using up to opts.Concurrency workers,
+// starting consecutive items at least opts.Stagger apart. Once ctx is done no
+// further items are started; in-flight items are awaited and the context error
+// is returned.
+func runBatch(ctx context.Context, n int, opts BatchOpts, fn func(ctx context.Context, index int)) error {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+dispatch:
+	for i := 0; i < n; i++ {
+		if i > 0 && opts.Stagger > 0 {
+			timer := time.NewTimer(opts.Stagger)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				break dispatch
+			}
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+
+		// Don't start new work if the context was cancelled while acquiring a slot
+		if ctx.Err() != nil {
+			<-slots
+			break
+		}
+
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(ctx, index)
+		}(i)
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
+
+// batchResult holds the outcome of a single batch item
+type batchResult[T any] struct {
+	Value T
+	Err   error
+	Done  bool // False if the item never started because the context was done
+}
+
+// collectBatch runs fn for each of n items like runBatch and returns every item's outcome.
+// On cancellation the outcomes of the items that completed are returned along with the
+// context error; items that never started are left with Done unset.
+func collectBatch[T any](ctx context.Context, n int, opts BatchOpts, fn func(ctx context.Context, index int) (T, error)) ([]batchResult[T], error) {
+	results := make([]batchResult[T], n)
+
+	err := runBatch(ctx, n, opts, func(ctx context.Context, index int) {
+		value, err := fn(ctx, index)
+		results[index] = batchResult[T]{Value: value, Err: err, Done: true}
+	})
+
+	return results, err
+}
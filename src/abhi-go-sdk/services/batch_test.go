@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+	"go.uber.org/goleak"
+)
+
+func TestCollectBatchReturnsPartialResultsOnCancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var completed int32
+	results, err := collectBatch(ctx, 20, BatchOpts{Concurrency: 2}, func(ctx context.Context, index int) (int, error) {
+		// Cancel once a few items have finished; in-flight items observe the cancellation
+		if atomic.AddInt32(&completed, 1) == 5 {
+			cancel()
+		}
+		select {
+		case <-time.After(time.Millisecond):
+			return index * 10, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	})
+
+	if !stderrors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled, got %v", err)
+	}
+	if len(results) != 20 {
+		t.Fatalf("Expected a result slot per item, got %d", len(results))
+	}
+
+	succeeded, skipped := 0, 0
+	for i, result := range results {
+		switch {
+		case !result.Done:
+			skipped++
+		case result.Err == nil:
+			succeeded++
+			if result.Value != i*10 {
+				t.Errorf("Item %d: expected value %d, got %d", i, i*10, result.Value)
+			}
+		}
+	}
+
+	if succeeded == 0 {
+		t.Error("Expected results completed before cancellation to be returned")
+	}
+	if skipped == 0 {
+		t.Error("Expected items after cancellation to be skipped")
+	}
+}
+
+func TestCreateAdvancesBatchReturnsPartialResultsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 3 {
+			cancel()
+		}
+		writeData(w, models.Transaction{ID: "txn"})
+	})
+
+	items := make([]models.TransactionRequest, 10)
+	for i := range items {
+		items[i] = models.TransactionRequest{EmployeeID: "emp", Amount: 100}
+	}
+
+	results, err := NewTransactionService(c).CreateAdvancesBatch(ctx, items, BatchOpts{Concurrency: 1})
+	if !stderrors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the batch error to include the cancellation, got %v", err)
+	}
+
+	created := 0
+	for _, result := range results {
+		if result.Transaction != nil {
+			created++
+		}
+	}
+	if created < 2 || created == len(items) {
+		t.Errorf("Expected partial results, got %d of %d created", created, len(items))
+	}
+}
+
+// peakTracker records the highest number of concurrent calls between enter and leave
+type peakTracker struct {
+	inFlight, peak int32
+}
+
+func (p *peakTracker) enter() {
+	current := atomic.AddInt32(&p.inFlight, 1)
+	for {
+		observed := atomic.LoadInt32(&p.peak)
+		if current <= observed || atomic.CompareAndSwapInt32(&p.peak, observed, current) {
+			return
+		}
+	}
+}
+
+func (p *peakTracker) leave() {
+	atomic.AddInt32(&p.inFlight, -1)
+}
+
+func TestRunBatchCapsConcurrency(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var tracker peakTracker
+	var ran int32
+	err := runBatch(context.Background(), 20, BatchOpts{Concurrency: 3}, func(ctx context.Context, index int) {
+		tracker.enter()
+		defer tracker.leave()
+		atomic.AddInt32(&ran, 1)
+		time.Sleep(5 * time.Millisecond)
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ran != 20 {
+		t.Errorf("Expected every item to run, got %d", ran)
+	}
+	if tracker.peak > 3 {
+		t.Errorf("Expected at most 3 items in flight, observed %d", tracker.peak)
+	} else if tracker.peak < 2 {
+		t.Errorf("Expected items to run concurrently up to the limit, observed %d", tracker.peak)
+	}
+}
+
+func TestRunBatchDefaultsToOneWorker(t *testing.T) {
+	var tracker peakTracker
+	runBatch(context.Background(), 5, BatchOpts{}, func(ctx context.Context, index int) {
+		tracker.enter()
+		defer tracker.leave()
+		time.Sleep(time.Millisecond)
+	})
+
+	if tracker.peak != 1 {
+		t.Errorf("Expected items to run one at a time, observed %d in flight", tracker.peak)
+	}
+}
+
+func TestRunBatchStartsNothingAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var ran int32
+	err := runBatch(ctx, 10, BatchOpts{Concurrency: 4}, func(ctx context.Context, index int) {
+		atomic.AddInt32(&ran, 1)
+	})
+
+	if !stderrors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled, got %v", err)
+	}
+	if ran != 0 {
+		t.Errorf("Expected no items to start, got %d", ran)
+	}
+}
+
+func TestCreateAdvancesBatchCapsConcurrency(t *testing.T) {
+	var tracker peakTracker
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		tracker.enter()
+		defer tracker.leave()
+		time.Sleep(10 * time.Millisecond)
+		writeData(w, models.Transaction{ID: "txn"})
+	})
+
+	items := make([]models.TransactionRequest, 8)
+	for i := range items {
+		items[i] = models.TransactionRequest{EmployeeID: "emp", Amount: 100}
+	}
+
+	if _, err := NewTransactionService(c).CreateAdvancesBatch(context.Background(), items, BatchOpts{Concurrency: 2}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if tracker.peak > 2 {
+		t.Errorf("Expected at most 2 requests in flight, observed %d", tracker.peak)
+	}
+}
+
+func TestCreateAdvancesBatchAggregatesFailures(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req models.TransactionRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.HasPrefix(req.EmployeeID, "bad") {
+			writeError(w, http.StatusBadRequest, "Employee not eligible")
+			return
+		}
+		writeData(w, models.Transaction{ID: "txn-" + req.EmployeeID, EmployeeID: req.EmployeeID})
+	})
+
+	items := []models.TransactionRequest{
+		{EmployeeID: "emp-0", Amount: 100},
+		{EmployeeID: "bad-1", Amount: 100},
+		{EmployeeID: "emp-2", Amount: 100},
+		{EmployeeID: "bad-3", Amount: 100},
+		{EmployeeID: "emp-4", Amount: 100},
+	}
+
+	results, err := NewTransactionService(c).CreateAdvancesBatch(context.Background(), items, BatchOpts{Concurrency: 3})
+
+	var batchErr *errors.BatchError
+	if !stderrors.As(err, &batchErr) {
+		t.Fatalf("Expected a BatchError, got %T: %v", err, err)
+	}
+	if batchErr.Total != 5 || len(batchErr.Errors) != 2 || batchErr.Errors[1] == nil || batchErr.Errors[3] == nil {
+		t.Errorf("Expected items 1 and 3 to fail out of 5, got %+v", batchErr.Errors)
+	}
+	if err.Error() != "Batch error: 2 of 5 items failed" {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected the item errors to unwrap to the API error, got %v", err)
+	}
+
+	for i, result := range results {
+		if result.Index != i || result.Request.EmployeeID != items[i].EmployeeID || result.Request.Type != "advance" {
+			t.Errorf("Item %d: unexpected result %+v", i, result)
+		}
+		failed := strings.HasPrefix(items[i].EmployeeID, "bad")
+		if failed != (result.Err != nil) || failed != (result.Transaction == nil) {
+			t.Errorf("Item %d: expected failed=%v, got transaction %v and error %v", i, failed, result.Transaction, result.Err)
+		}
+		if !failed && result.Transaction.ID != "txn-"+items[i].EmployeeID {
+			t.Errorf("Item %d: expected its own transaction, got %s", i, result.Transaction.ID)
+		}
+	}
+}
+
+func TestCreateAdvancesBatchSendsIdempotencyKeys(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string][]string{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["IdempotencyKey"]; ok {
+			t.Error("Expected the idempotency key to stay out of the body")
+		}
+
+		employeeID, _ := body["employeeId"].(string)
+		mu.Lock()
+		keys[employeeID] = r.Header.Values("Idempotency-Key")
+		mu.Unlock()
+		writeData(w, models.Transaction{ID: "txn"})
+	})
+
+	items := []models.TransactionRequest{
+		{EmployeeID: "emp-1", Amount: 100, IdempotencyKey: "payroll-2024-05-emp-1"},
+		{EmployeeID: "emp-2", Amount: 100, IdempotencyKey: "payroll-2024-05-emp-2"},
+		{EmployeeID: "emp-3", Amount: 100},
+	}
+
+	if _, err := NewTransactionService(c).CreateAdvancesBatch(context.Background(), items, BatchOpts{Concurrency: 3}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, item := range items {
+		sent := keys[item.EmployeeID]
+		if item.IdempotencyKey == "" {
+			if len(sent) != 0 {
+				t.Errorf("%s: expected no idempotency key, got %v", item.EmployeeID, sent)
+			}
+			continue
+		}
+		if len(sent) != 1 || sent[0] != item.IdempotencyKey {
+			t.Errorf("%s: expected idempotency key %q, got %v", item.EmployeeID, item.IdempotencyKey, sent)
+		}
+	}
+}
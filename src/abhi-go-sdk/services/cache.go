@@ -0,0 +1,69 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache is an in-memory cache whose entries expire a fixed time after they are stored.
+// A zero TTL disables caching.
+type ttlCache[V any] struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry[V]
+	now     func() time.Time
+}
+
+// cacheEntry is a cached value and the time it expires
+type cacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// newTTLCache creates a cache whose entries live for ttl
+func newTTLCache[V any](ttl time.Duration) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry[V]),
+		now:     time.Now,
+	}
+}
+
+// get returns the unexpired value stored under key
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// set stores value under key, doing nothing if caching is disabled
+func (c *ttlCache[V]) set(key string, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	c.entries[key] = cacheEntry[V]{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+// setTTL changes the lifetime of entries stored from now on
+func (c *ttlCache[V]) setTTL(ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.ttl = ttl
+}
+
+// clear removes every entry
+func (c *ttlCache[V]) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries = make(map[string]cacheEntry[V])
+}
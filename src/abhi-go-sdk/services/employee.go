@@ -1,18 +1,28 @@
 package services
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
 	"abhi-go-sdk/models"
 )
 
 // EmployeeService handles employee-related API operations
 type EmployeeService struct {
-	client *client.Client
+	client          *client.Client
+	pageConcurrency int
 }
 
 // NewEmployeeService creates a new employee service
@@ -22,6 +32,12 @@ func NewEmployeeService(client *client.Client) *EmployeeService {
 	}
 }
 
+// WithPageConcurrency makes GetAll fetch pages with up to workers requests in flight; see CollectAll.
+func (s *EmployeeService) WithPageConcurrency(workers int) *EmployeeService {
+	s.pageConcurrency = workers
+	return s
+}
+
 // List retrieves a paginated list of employees
 func (s *EmployeeService) List(ctx context.Context, opts *models.EmployeeListOptions) (*models.EmployeeListResponse, error) {
 	query := url.Values{}
@@ -42,6 +58,9 @@ func (s *EmployeeService) List(ctx context.Context, opts *models.EmployeeListOpt
 		if opts.Status != "" {
 			query.Set("status", opts.Status)
 		}
+		if opts.OrganizationID != "" {
+			query.Set("organizationId", opts.OrganizationID)
+		}
 	}
 
 	var result models.EmployeeListResponse
@@ -59,6 +78,20 @@ func (s *EmployeeService) GetAll(ctx context.Context) ([]models.Employee, error)
 	page := 1
 	limit := 100
 
+	if s.pageConcurrency > 1 {
+		employees, err := CollectAll(ctx, func(ctx context.Context, page int) ([]models.Employee, int, error) {
+			response, err := s.List(ctx, &models.EmployeeListOptions{Page: page, Limit: limit})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Results, response.Total, nil
+		}, limit, s.pageConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get employees: %w", err)
+		}
+		return employees, nil
+	}
+
 	for {
 		opts := &models.EmployeeListOptions{
 			Page:  page,
@@ -82,6 +115,57 @@ func (s *EmployeeService) GetAll(ctx context.Context) ([]models.Employee, error)
 	return allEmployees, nil
 }
 
+// Iterate streams employees matching opts page by page, so large exports don't have to be held
+// in memory. Pages hold opts.Limit employees (default 100), starting from opts.Page if set.
+// Both channels are closed when all employees have been sent or iteration stops; at most one
+// error is sent, including the context's error if it's cancelled. Callers that stop reading
+// early must cancel ctx to release the iterating goroutine.
+func (s *EmployeeService) Iterate(ctx context.Context, opts *models.EmployeeListOptions) (<-chan models.Employee, <-chan error) {
+	employees := make(chan models.Employee)
+	errs := make(chan error, 1)
+
+	pageOpts := models.EmployeeListOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	if pageOpts.Limit <= 0 {
+		pageOpts.Limit = 100
+	}
+	if pageOpts.Page <= 0 {
+		pageOpts.Page = 1
+	}
+
+	go func() {
+		defer close(employees)
+		defer close(errs)
+
+		for {
+			response, err := s.List(ctx, &pageOpts)
+			if err != nil {
+				errs <- fmt.Errorf("failed to get employees page %d: %w", pageOpts.Page, err)
+				return
+			}
+
+			for _, employee := range response.Results {
+				select {
+				case employees <- employee:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			// Check if we have more pages
+			if len(response.Results) < pageOpts.Limit {
+				return
+			}
+			pageOpts.Page++
+		}
+	}()
+
+	return employees, errs
+}
+
 // GetByID retrieves a single employee by ID
 func (s *EmployeeService) GetByID(ctx context.Context, employeeID string) (*models.Employee, error) {
 	var result models.Employee
@@ -124,7 +208,7 @@ func (s *EmployeeService) GetByEmployeeCode(ctx context.Context, employeeCode st
 // Create adds new employees to the system
 func (s *EmployeeService) Create(ctx context.Context, employees []models.Employee) error {
 	request := models.EmployeesRequest{
-		Employees: employees,
+		Employees: normalizeEmployeePhones(employees),
 	}
 
 	err := s.client.POST(ctx, "/employees", request, nil)
@@ -135,15 +219,217 @@ func (s *EmployeeService) Create(ctx context.Context, employees []models.Employe
 	return nil
 }
 
+// CreateWithResults adds new employees and reports the outcome of each, aligned to the input.
+// When the API rejects the batch with an aggregate error, the employees named in the error are
+// marked with their messages and the error is returned alongside the results; the others are
+// left unsuccessful without a message, since the batch was not applied.
+func (s *EmployeeService) CreateWithResults(ctx context.Context, employees []models.Employee) ([]models.EmployeeCreateResult, error) {
+	results := make([]models.EmployeeCreateResult, len(employees))
+	for i, employee := range employees {
+		results[i] = models.EmployeeCreateResult{Index: i, EmployeeCode: employee.EmployeeCode}
+	}
+
+	request := models.EmployeesRequest{
+		Employees: normalizeEmployeePhones(employees),
+	}
+
+	var response models.EmployeeCreateResponse
+	err := s.client.POST(ctx, "/employees", request, &response)
+	if err != nil {
+		apiErr, ok := err.(*errors.APIError)
+		if !ok {
+			return nil, fmt.Errorf("failed to create employees: %w", err)
+		}
+		markFailedEmployees(results, apiErr)
+		return results, fmt.Errorf("failed to create employees: %w", err)
+	}
+
+	// An API that doesn't report rows accepted the whole batch
+	if len(response.Results) == 0 {
+		for i := range results {
+			results[i].Success = true
+		}
+		return results, nil
+	}
+
+	applyCreateResults(results, response.Results)
+	return results, nil
+}
+
+// applyCreateResults copies reported rows into results, matching by index and falling back to
+// employee code when the index is missing or out of range
+func applyCreateResults(results []models.EmployeeCreateResult, reported []models.EmployeeCreateResult) {
+	byCode := make(map[string]int, len(results))
+	for i, result := range results {
+		byCode[result.EmployeeCode] = i
+	}
+
+	for _, row := range reported {
+		i := row.Index
+		if i < 0 || i >= len(results) || (row.EmployeeCode != "" && row.EmployeeCode != results[i].EmployeeCode) {
+			var found bool
+			if i, found = byCode[row.EmployeeCode]; !found {
+				continue
+			}
+		}
+		results[i].Success = row.Success
+		results[i].Error = row.Error
+	}
+}
+
+// markFailedEmployees marks the employees named by an aggregate create error. Structured rows
+// in the error data are preferred; otherwise employee codes are picked out of the details and
+// message text.
+func markFailedEmployees(results []models.EmployeeCreateResult, apiErr *errors.APIError) {
+	if rows, ok := apiErr.Data["results"]; ok {
+		var reported []models.EmployeeCreateResult
+		if data, err := json.Marshal(rows); err == nil && json.Unmarshal(data, &reported) == nil {
+			applyCreateResults(results, reported)
+			return
+		}
+	}
+
+	message := apiErr.Details
+	if message == "" {
+		message = apiErr.Message
+	}
+
+	// Split on separators that never appear in employee codes, so EMP1 doesn't match EMP10
+	mentioned := make(map[string]bool)
+	for _, token := range strings.FieldsFunc(apiErr.Details+"\n"+apiErr.Message, func(r rune) bool {
+		return strings.ContainsRune(" \t\n,;:()[]{}\"'", r)
+	}) {
+		mentioned[token] = true
+	}
+
+	for i := range results {
+		if mentioned[results[i].EmployeeCode] {
+			results[i].Error = message
+		}
+	}
+}
+
+// normalizePhone returns phone in E.164 form if it's a valid UAE mobile number, leaving other
+// values for the uaephone validation tag to reject
+func normalizePhone(phone string) string {
+	if normalized, err := models.NormalizeUAEPhone(phone); err == nil {
+		return normalized
+	}
+	return phone
+}
+
+// normalizeEmployeePhones returns a copy of employees with phone numbers in E.164 form
+func normalizeEmployeePhones(employees []models.Employee) []models.Employee {
+	normalized := make([]models.Employee, len(employees))
+	for i, employee := range employees {
+		employee.Phone = normalizePhone(employee.Phone)
+		normalized[i] = employee
+	}
+	return normalized
+}
+
 // CreateSingle adds a single employee to the system
 func (s *EmployeeService) CreateSingle(ctx context.Context, employee models.Employee) error {
 	return s.Create(ctx, []models.Employee{employee})
 }
 
+// CreateIdempotent adds the employees that aren't already on the roster, matching by employee
+// code, so a retried or re-run import is a no-op for employees created before. Each create
+// carries an Idempotency-Key derived from the employee code, and a create rejected with a
+// conflict is also reported as already existing. Results are aligned to the input; individual
+// failures don't stop the others and are aggregated into an *errors.BatchError.
+func (s *EmployeeService) CreateIdempotent(ctx context.Context, employees []models.Employee, opts BatchOpts) ([]models.EmployeeCreateResult, error) {
+	codes := make(map[string]bool, len(employees))
+	for _, employee := range employees {
+		if employee.EmployeeCode == "" {
+			return nil, &errors.ValidationError{
+				Field:   "employeeCode",
+				Message: "employee code is required for idempotent creates",
+			}
+		}
+		if codes[employee.EmployeeCode] {
+			return nil, &errors.ValidationError{
+				Field:   "employeeCode",
+				Message: "duplicate employee code",
+				Value:   employee.EmployeeCode,
+			}
+		}
+		codes[employee.EmployeeCode] = true
+	}
+
+	current, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current roster: %w", err)
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, employee := range current {
+		existing[employee.EmployeeCode] = true
+	}
+
+	results := make([]models.EmployeeCreateResult, len(employees))
+	for i, employee := range employees {
+		results[i] = models.EmployeeCreateResult{Index: i, EmployeeCode: employee.EmployeeCode}
+		if existing[employee.EmployeeCode] {
+			results[i].Success = true
+			results[i].AlreadyExisted = true
+		}
+	}
+
+	normalized := normalizeEmployeePhones(employees)
+	outcomes, ctxErr := collectBatch(ctx, len(employees), opts, func(ctx context.Context, index int) (bool, error) {
+		if results[index].AlreadyExisted {
+			return true, nil
+		}
+
+		employee := normalized[index]
+		request := models.EmployeesRequest{Employees: []models.Employee{employee}}
+		err := s.client.POSTIdempotent(ctx, "/employees", request, nil, "employee-create-"+employee.EmployeeCode)
+		if apiErr, ok := err.(*errors.APIError); ok && apiErr.IsConflict() {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to create employee %s: %w", employee.EmployeeCode, err)
+		}
+		return false, nil
+	})
+
+	batchErr := errors.NewBatchError(len(employees))
+	for i, outcome := range outcomes {
+		err := outcome.Err
+		if !outcome.Done {
+			err = ctxErr
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			batchErr.Add(i, err)
+			continue
+		}
+		results[i].Success = true
+		results[i].AlreadyExisted = outcome.Value
+	}
+
+	if batchErr.HasErrors() {
+		return results, batchErr
+	}
+
+	return results, nil
+}
+
+// CreateSingleIdempotent adds a single employee unless one with the same employee code already
+// exists, reporting which of the two happened
+func (s *EmployeeService) CreateSingleIdempotent(ctx context.Context, employee models.Employee) (*models.EmployeeCreateResult, error) {
+	results, err := s.CreateIdempotent(ctx, []models.Employee{employee}, BatchOpts{})
+	if len(results) == 0 {
+		return nil, err
+	}
+	return &results[0], err
+}
+
 // Update updates existing employees
 func (s *EmployeeService) Update(ctx context.Context, employees []models.Employee) error {
 	request := models.EmployeesRequest{
-		Employees: employees,
+		Employees: normalizeEmployeePhones(employees),
 	}
 
 	err := s.client.PUT(ctx, "/employees", request, nil)
@@ -159,6 +445,180 @@ func (s *EmployeeService) UpdateSingle(ctx context.Context, employee models.Empl
 	return s.Update(ctx, []models.Employee{employee})
 }
 
+// immutableEmployeeFields lists Employee JSON keys that are assigned by the API
+var immutableEmployeeFields = map[string]bool{"id": true, "createdAt": true, "updatedAt": true}
+
+// updatableEmployeeFields returns the Employee JSON keys a partial update may set
+func updatableEmployeeFields() map[string]bool {
+	fields := make(map[string]bool)
+	employeeType := reflect.TypeOf(models.Employee{})
+	for i := 0; i < employeeType.NumField(); i++ {
+		name, _, _ := strings.Cut(employeeType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" && !immutableEmployeeFields[name] {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// UpdateFields updates only the given fields of an employee, keyed by their JSON names, so
+// fields the caller didn't load are left untouched
+func (s *EmployeeService) UpdateFields(ctx context.Context, employeeID string, fields map[string]interface{}) error {
+	if employeeID == "" {
+		return &errors.ValidationError{
+			Field:   "employeeId",
+			Message: "Employee ID is required",
+		}
+	}
+	if len(fields) == 0 {
+		return &errors.ValidationError{
+			Field:   "fields",
+			Message: "At least one field is required",
+		}
+	}
+
+	updatable := updatableEmployeeFields()
+	for key := range fields {
+		if !updatable[key] {
+			return &errors.ValidationError{
+				Field:   key,
+				Message: "Unknown or read-only employee field",
+			}
+		}
+	}
+
+	// Partial updates skip struct validation, so check and normalize the phone here
+	if phone, ok := fields["phone"].(string); ok && phone != "" {
+		normalized, err := models.NormalizeUAEPhone(phone)
+		if err != nil {
+			return &errors.ValidationError{
+				Field:   "phone",
+				Message: err.Error(),
+				Value:   phone,
+			}
+		}
+		patch := make(map[string]interface{}, len(fields))
+		for key, value := range fields {
+			patch[key] = value
+		}
+		patch["phone"] = normalized
+		fields = patch
+	}
+
+	endpoint := fmt.Sprintf("/employees/%s", employeeID)
+	err := s.client.PATCH(ctx, endpoint, fields, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update employee %s: %w", employeeID, err)
+	}
+
+	return nil
+}
+
+// UpdateSalary updates only an employee's net salary
+func (s *EmployeeService) UpdateSalary(ctx context.Context, employeeID string, netSalary models.Money) error {
+	if err := models.ValidateNetSalary(netSalary); err != nil {
+		return &errors.ValidationError{
+			Field:   "netSalary",
+			Message: err.Error(),
+			Value:   strconv.FormatFloat(netSalary.Float64(), 'f', -1, 64),
+		}
+	}
+	return s.UpdateFields(ctx, employeeID, map[string]interface{}{"netSalary": netSalary})
+}
+
+// UpdateDepartment updates only an employee's department
+func (s *EmployeeService) UpdateDepartment(ctx context.Context, employeeID, department string) error {
+	if strings.TrimSpace(department) == "" {
+		return &errors.ValidationError{
+			Field:   "department",
+			Message: "Department is required",
+		}
+	}
+	return s.UpdateFields(ctx, employeeID, map[string]interface{}{"department": department})
+}
+
+// UpdateSalaries sets the net salaries of many employees, keyed by employee code or ID. Every
+// salary is validated first and invalid or unknown entries are reported as failures without
+// being sent. The others are applied with bounded concurrency; each update sets an absolute
+// value, so re-running the job after a partial failure is safe. The report lists the outcome
+// of every key, and an error is returned alongside it if any update failed.
+func (s *EmployeeService) UpdateSalaries(ctx context.Context, updates map[string]string) (*BatchReport, error) {
+	report := newBatchReport(len(updates))
+
+	keys := make([]string, 0, len(updates))
+	for key := range updates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	salaries := make(map[string]models.Money, len(updates))
+	for _, key := range keys {
+		salary, err := models.ParseNetSalary(updates[key])
+		if err != nil {
+			report.Failed[key] = &errors.ValidationError{
+				Field:   "netSalary",
+				Message: err.Error(),
+				Value:   updates[key],
+			}
+			continue
+		}
+		salaries[key] = salary
+	}
+
+	current, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current roster: %w", err)
+	}
+
+	ids := make(map[string]string, 2*len(current))
+	for _, employee := range current {
+		ids[employee.ID] = employee.ID
+	}
+	for _, employee := range current {
+		if _, ok := ids[employee.EmployeeCode]; !ok && employee.EmployeeCode != "" {
+			ids[employee.EmployeeCode] = employee.ID
+		}
+	}
+
+	var pending []string
+	for _, key := range keys {
+		if _, ok := salaries[key]; !ok {
+			continue
+		}
+		if _, ok := ids[key]; !ok {
+			report.Failed[key] = fmt.Errorf("employee %s not found", key)
+			continue
+		}
+		pending = append(pending, key)
+	}
+
+	outcomes, ctxErr := collectBatch(ctx, len(pending), BatchOpts{Concurrency: bulkWriteConcurrency}, func(ctx context.Context, index int) (struct{}, error) {
+		key := pending[index]
+		return struct{}{}, s.UpdateSalary(ctx, ids[key], salaries[key])
+	})
+
+	for i, outcome := range outcomes {
+		key := pending[i]
+		switch {
+		case !outcome.Done:
+			report.Failed[key] = ctxErr
+		case outcome.Err != nil:
+			report.Failed[key] = outcome.Err
+		default:
+			report.Succeeded = append(report.Succeeded, key)
+		}
+	}
+
+	if ctxErr != nil {
+		return report, fmt.Errorf("salary update interrupted: %w", ctxErr)
+	}
+	if len(report.Failed) > 0 {
+		return report, fmt.Errorf("salary update completed with %d of %d updates failed", len(report.Failed), report.Total)
+	}
+
+	return report, nil
+}
+
 // Delete removes an employee from the system
 func (s *EmployeeService) Delete(ctx context.Context, employeeID string) error {
 	endpoint := fmt.Sprintf("/employees/%s", employeeID)
@@ -171,6 +631,24 @@ func (s *EmployeeService) Delete(ctx context.Context, employeeID string) error {
 	return nil
 }
 
+// Deactivate marks an employee as inactive, keeping their record and transaction history
+func (s *EmployeeService) Deactivate(ctx context.Context, employeeID string) error {
+	if employeeID == "" {
+		return &errors.ValidationError{
+			Field:   "employeeId",
+			Message: "Employee ID is required",
+		}
+	}
+
+	endpoint := fmt.Sprintf("/employees/%s", employeeID)
+	err := s.client.PATCH(ctx, endpoint, map[string]string{"status": "inactive"}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate employee %s: %w", employeeID, err)
+	}
+
+	return nil
+}
+
 // Search searches for employees based on criteria
 func (s *EmployeeService) Search(ctx context.Context, searchTerm string, limit int) ([]models.Employee, error) {
 	if limit <= 0 {
@@ -205,6 +683,132 @@ func (s *EmployeeService) GetByDepartment(ctx context.Context, department string
 	return result.Results, nil
 }
 
+// GetEligibleForAdvance retrieves active employees whose available advance amount is positive and
+// at least minAvailable. Employees are listed page by page and each page's balances are checked
+// concurrently, bounded by lookupConcurrency and the client's rate limiter. If any balance check
+// fails, the eligible employees found are returned along with an *errors.LookupError. If ctx is
+// cancelled, the eligible employees found so far are returned along with the context error.
+func (s *EmployeeService) GetEligibleForAdvance(ctx context.Context, minAvailable float64) ([]models.EligibleEmployee, error) {
+	transactions := NewTransactionService(s.client)
+	lookupErr := errors.NewLookupError(0)
+	var eligible []models.EligibleEmployee
+	page := 1
+	limit := 100
+
+	for {
+		response, err := s.List(ctx, &models.EmployeeListOptions{
+			Page:   page,
+			Limit:  limit,
+			Status: "active",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active employees page %d: %w", page, err)
+		}
+
+		employees := response.Results
+		outcomes, ctxErr := collectBatch(ctx, len(employees), BatchOpts{Concurrency: lookupConcurrency}, func(ctx context.Context, index int) (float64, error) {
+			balance, err := transactions.GetEmployeeMonthlyBalance(ctx, employees[index].ID, 0, 0)
+			if err != nil {
+				return 0, err
+			}
+			return balance.Balance.AvailableAmount, nil
+		})
+
+		lookupErr.Total += len(employees)
+		for i, outcome := range outcomes {
+			if !outcome.Done {
+				continue
+			}
+			if outcome.Err != nil {
+				lookupErr.Errors[employees[i].ID] = outcome.Err
+				continue
+			}
+			if available := outcome.Value; available > 0 && available >= minAvailable {
+				eligible = append(eligible, models.EligibleEmployee{
+					Employee:        employees[i],
+					AvailableAmount: available,
+				})
+			}
+		}
+
+		if ctxErr != nil {
+			return eligible, fmt.Errorf("failed to check employee balances: %w", ctxErr)
+		}
+
+		// Check if we have more pages
+		if len(employees) < limit {
+			break
+		}
+		page++
+	}
+
+	if lookupErr.HasErrors() {
+		return eligible, lookupErr
+	}
+
+	return eligible, nil
+}
+
+// GetEligibleByTenure retrieves active employees employed for at least minMonths calendar
+// months as of today. Employees whose date of joining can't be parsed are left out and
+// reported in an *errors.LookupError returned with the eligible employees.
+func (s *EmployeeService) GetEligibleByTenure(ctx context.Context, minMonths int) ([]models.Employee, error) {
+	lookupErr := errors.NewLookupError(0)
+	var eligible []models.Employee
+	page := 1
+	limit := 100
+	now := time.Now()
+
+	for {
+		response, err := s.List(ctx, &models.EmployeeListOptions{
+			Page:   page,
+			Limit:  limit,
+			Status: "active",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get active employees page %d: %w", page, err)
+		}
+
+		lookupErr.Total += len(response.Results)
+		for _, employee := range response.Results {
+			ok, err := employee.IsEligibleByTenure(minMonths, now)
+			if err != nil {
+				lookupErr.Errors[employee.ID] = err
+				continue
+			}
+			if ok {
+				eligible = append(eligible, employee)
+			}
+		}
+
+		// Check if we have more pages
+		if len(response.Results) < limit {
+			break
+		}
+		page++
+	}
+
+	if lookupErr.HasErrors() {
+		return eligible, lookupErr
+	}
+	return eligible, nil
+}
+
+// countByOrganization returns the total and active employee counts for an organization
+func (s *EmployeeService) countByOrganization(ctx context.Context, organizationID string) (total, active int, err error) {
+	all, err := s.List(ctx, &models.EmployeeListOptions{OrganizationID: organizationID, Limit: 1})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	activeOnly, err := s.List(ctx, &models.EmployeeListOptions{OrganizationID: organizationID, Status: "active", Limit: 1})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return all.Total, activeOnly.Total, nil
+}
+
 // ValidateEmployee validates employee data before creation/update
 func (s *EmployeeService) ValidateEmployee(employee models.Employee) error {
 	// This would typically use the validator from the client
@@ -215,12 +819,434 @@ func (s *EmployeeService) ValidateEmployee(employee models.Employee) error {
 	if employee.Email == "" {
 		return fmt.Errorf("email is required")
 	}
-	if employee.NetSalary == "" {
+	if employee.NetSalary == 0 {
 		return fmt.Errorf("net salary is required")
 	}
+	if _, err := employee.NetSalaryAmount(); err != nil {
+		return fmt.Errorf("invalid net salary: %w", err)
+	}
 	if employee.BankID == "" {
 		return fmt.Errorf("bank ID is required")
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// Roster Sync
+
+// SyncActionType identifies the kind of change applied during a roster sync
+type SyncActionType string
+
+const (
+	SyncActionCreate     SyncActionType = "create"
+	SyncActionUpdate     SyncActionType = "update"
+	SyncActionDeactivate SyncActionType = "deactivate"
+)
+
+// SyncOpts configures a roster sync
+type SyncOpts struct {
+	Concurrency       int  // Maximum number of concurrent API calls (defaults to 1)
+	DryRun            bool // Plan the changes without applying them
+	DeactivateMissing bool // Deactivate employees missing from the desired roster; only set it for a complete roster
+}
+
+// SyncAction describes a single change planned or applied by a roster sync
+type SyncAction struct {
+	Type         SyncActionType
+	EmployeeCode string
+	EmployeeID   string
+	Changes      []string // Changed JSON fields, for updates
+	Err          error
+}
+
+// SyncReport describes the outcome of a roster sync
+type SyncReport struct {
+	DryRun    bool
+	Actions   []SyncAction
+	Unchanged int
+}
+
+// Count returns the number of actions of the given type
+func (r *SyncReport) Count(actionType SyncActionType) int {
+	count := 0
+	for _, action := range r.Actions {
+		if action.Type == actionType {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed returns the actions that could not be applied
+func (r *SyncReport) Failed() []SyncAction {
+	var failed []SyncAction
+	for _, action := range r.Actions {
+		if action.Err != nil {
+			failed = append(failed, action)
+		}
+	}
+	return failed
+}
+
+// SyncRoster makes Abhi's employee roster match the desired roster. Employees are matched
+// by employee code: missing employees are created and changed employees are updated.
+// Employees absent from the desired roster are left untouched unless opts.DeactivateMissing
+// is set, in which case they are deactivated rather than deleted; employees that are already
+// inactive are skipped. Creates carry an
+// Idempotency-Key derived from the employee code so re-running a sync is safe.
+func (s *EmployeeService) SyncRoster(ctx context.Context, desired []models.Employee, opts SyncOpts) (*SyncReport, error) {
+	desiredCodes := make(map[string]bool, len(desired))
+	for _, employee := range desired {
+		if employee.EmployeeCode == "" {
+			return nil, fmt.Errorf("employee code is required for every employee in the desired roster")
+		}
+		if desiredCodes[employee.EmployeeCode] {
+			return nil, fmt.Errorf("duplicate employee code %s in desired roster", employee.EmployeeCode)
+		}
+		desiredCodes[employee.EmployeeCode] = true
+	}
+
+	current, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current roster: %w", err)
+	}
+
+	currentByCode := make(map[string]models.Employee, len(current))
+	for _, employee := range current {
+		currentByCode[employee.EmployeeCode] = employee
+	}
+
+	// Plan the changes
+	report := &SyncReport{DryRun: opts.DryRun}
+	var pending []models.Employee
+
+	for _, employee := range desired {
+		existing, ok := currentByCode[employee.EmployeeCode]
+		if !ok {
+			report.Actions = append(report.Actions, SyncAction{Type: SyncActionCreate, EmployeeCode: employee.EmployeeCode})
+			pending = append(pending, employee)
+			continue
+		}
+
+		changes := diffEmployees(existing, employee)
+		if len(changes) == 0 {
+			report.Unchanged++
+			continue
+		}
+
+		employee.ID = existing.ID
+		report.Actions = append(report.Actions, SyncAction{
+			Type:         SyncActionUpdate,
+			EmployeeCode: employee.EmployeeCode,
+			EmployeeID:   existing.ID,
+			Changes:      changes,
+		})
+		pending = append(pending, employee)
+	}
+
+	if opts.DeactivateMissing {
+		for _, employee := range current {
+			if !desiredCodes[employee.EmployeeCode] && employee.Status != "inactive" {
+				report.Actions = append(report.Actions, SyncAction{
+					Type:         SyncActionDeactivate,
+					EmployeeCode: employee.EmployeeCode,
+					EmployeeID:   employee.ID,
+				})
+				pending = append(pending, employee)
+			}
+		}
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	// Apply the changes
+	ctxErr := runBatch(ctx, len(report.Actions), BatchOpts{Concurrency: opts.Concurrency}, func(ctx context.Context, index int) {
+		action := &report.Actions[index]
+		employee := pending[index]
+
+		switch action.Type {
+		case SyncActionCreate:
+			request := models.EmployeesRequest{Employees: []models.Employee{employee}}
+			if err := s.client.POSTIdempotent(ctx, "/employees", request, nil, "employee-create-"+employee.EmployeeCode); err != nil {
+				action.Err = fmt.Errorf("failed to create employee %s: %w", employee.EmployeeCode, err)
+			}
+		case SyncActionUpdate:
+			action.Err = s.UpdateSingle(ctx, employee)
+		case SyncActionDeactivate:
+			action.Err = s.Deactivate(ctx, employee.ID)
+		}
+	})
+	if ctxErr != nil {
+		return report, fmt.Errorf("roster sync interrupted: %w", ctxErr)
+	}
+
+	if failed := len(report.Failed()); failed > 0 {
+		return report, fmt.Errorf("roster sync completed with %d of %d actions failed", failed, len(report.Actions))
+	}
+
+	return report, nil
+}
+
+// diffEmployees returns the JSON names of the roster fields that differ between two employees
+func diffEmployees(current, desired models.Employee) []string {
+	var changes []string
+	compare := func(field, a, b string) {
+		if a != b {
+			changes = append(changes, field)
+		}
+	}
+
+	compare("firstName", current.FirstName, desired.FirstName)
+	compare("lastName", current.LastName, desired.LastName)
+	compare("department", current.Department, desired.Department)
+	compare("designation", current.Designation, desired.Designation)
+	compare("phone", normalizePhone(current.Phone), normalizePhone(desired.Phone))
+	compare("email", strings.ToLower(current.Email), strings.ToLower(desired.Email))
+	compare("dob", current.DOB, desired.DOB)
+	compare("dateOfJoining", current.DateOfJoining, desired.DateOfJoining)
+	compare("accountTitle", current.AccountTitle, desired.AccountTitle)
+	compare("accountNumber", current.AccountNumber, desired.AccountNumber)
+	compare("emiratesId", current.EmiratesID, desired.EmiratesID)
+	compare("gender", strings.ToLower(current.Gender), strings.ToLower(desired.Gender))
+	compare("bankId", strings.ToLower(current.BankID), strings.ToLower(desired.BankID))
+	if current.NetSalary.MinorUnits(models.DefaultCurrency) != desired.NetSalary.MinorUnits(models.DefaultCurrency) {
+		changes = append(changes, "netSalary")
+	}
+	if current.PayrollStartDay != desired.PayrollStartDay {
+		changes = append(changes, "payrollStartDay")
+	}
+
+	return changes
+}
+
+// Import Validation
+
+// ValidateImport parses an employee import file in CSV (with a header row of JSON field names,
+// e.g. employeeCode) or JSONL format and validates every row without creating anything. Rows are
+// checked against the Employee validation tags and business rules: Emirates ID format, dates,
+// net salary, duplicate employee codes, and that the bank exists. An error is returned only if
+// the file can't be read or the banks can't be fetched.
+func (s *EmployeeService) ValidateImport(ctx context.Context, r io.Reader, format string) (*models.ImportValidationReport, error) {
+	rows, err := parseImportRows(r, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse import file: %w", err)
+	}
+
+	banks, err := NewMiscService(s.client).GetAllBanks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get banks for import validation: %w", err)
+	}
+	bankIDs := make(map[string]bool, len(banks))
+	for _, bank := range banks {
+		bankIDs[bank.ID] = true
+	}
+
+	report := &models.ImportValidationReport{TotalRows: len(rows)}
+	seenCodes := make(map[string]int)
+	today := time.Now()
+
+	for i, row := range rows {
+		result := models.ImportRowResult{Row: i + 1, Errors: row.errs}
+
+		if row.errs == nil {
+			employee := row.employee
+			result.EmployeeCode = employee.EmployeeCode
+
+			if err := s.client.ValidateStruct(employee); err != nil {
+				result.Errors = append(result.Errors, validationMessages(err)...)
+			}
+			result.Errors = append(result.Errors, employeeBusinessRuleErrors(employee, bankIDs, today)...)
+
+			if employee.EmployeeCode != "" {
+				if first, ok := seenCodes[employee.EmployeeCode]; ok {
+					result.Errors = append(result.Errors, fmt.Sprintf("duplicate employee code %s, first seen on row %d", employee.EmployeeCode, first))
+				} else {
+					seenCodes[employee.EmployeeCode] = result.Row
+				}
+			}
+		}
+
+		result.Valid = len(result.Errors) == 0
+		if result.Valid {
+			report.ValidRows++
+		} else {
+			report.InvalidRows++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	return report, nil
+}
+
+// importRow is a parsed import row, or the errors that prevented parsing it
+type importRow struct {
+	employee models.Employee
+	errs     []string
+}
+
+// parseImportRows parses every row of an import file in the given format
+func parseImportRows(r io.Reader, format string) ([]importRow, error) {
+	switch strings.ToLower(format) {
+	case models.ImportFormatCSV:
+		return parseCSVRows(r)
+	case models.ImportFormatJSONL, "ndjson":
+		return parseJSONLRows(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+// parseCSVRows parses CSV rows whose header names the Employee JSON fields
+func parseCSVRows(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); !ok {
+				return nil, err
+			}
+			rows = append(rows, importRow{errs: []string{err.Error()}})
+			continue
+		}
+
+		fields := make(map[string]interface{}, len(header))
+		var errs []string
+		for i, column := range header {
+			if i >= len(record) {
+				break
+			}
+			column = strings.TrimSpace(column)
+			value := strings.TrimSpace(record[i])
+
+			// payrollStartDay is the only numeric field
+			if column == "payrollStartDay" && value != "" {
+				day, err := strconv.Atoi(value)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("payrollStartDay %q is not a number", value))
+					continue
+				}
+				fields[column] = day
+				continue
+			}
+			fields[column] = value
+		}
+		if len(record) != len(header) {
+			errs = append(errs, fmt.Sprintf("expected %d columns, got %d", len(header), len(record)))
+		}
+
+		var employee models.Employee
+		if data, err := json.Marshal(fields); err != nil {
+			errs = append(errs, err.Error())
+		} else if err := json.Unmarshal(data, &employee); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if len(errs) > 0 {
+			rows = append(rows, importRow{errs: errs})
+			continue
+		}
+		rows = append(rows, importRow{employee: employee})
+	}
+}
+
+// parseJSONLRows parses one JSON employee object per line, skipping blank lines
+func parseJSONLRows(r io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []importRow
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var employee models.Employee
+		if err := json.Unmarshal([]byte(line), &employee); err != nil {
+			rows = append(rows, importRow{errs: []string{fmt.Sprintf("invalid JSON: %v", err)}})
+			continue
+		}
+		rows = append(rows, importRow{employee: employee})
+	}
+
+	return rows, scanner.Err()
+}
+
+// employeeBusinessRuleErrors checks rules the validation tags can't express
+func employeeBusinessRuleErrors(employee models.Employee, bankIDs map[string]bool, today time.Time) []string {
+	var errs []string
+
+	if employee.EmiratesID != "" {
+		if err := models.ValidateEmiratesID(employee.EmiratesID); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	var dob, joined time.Time
+	var err error
+	if employee.DOB != "" {
+		if dob, err = time.Parse(models.DateLayout, employee.DOB); err != nil {
+			errs = append(errs, fmt.Sprintf("dob %q must be a YYYY-MM-DD date", employee.DOB))
+		} else if dob.After(today) {
+			errs = append(errs, fmt.Sprintf("dob %s is in the future", employee.DOB))
+		}
+	}
+	if employee.DateOfJoining != "" {
+		if joined, err = time.Parse(models.DateLayout, employee.DateOfJoining); err != nil {
+			errs = append(errs, fmt.Sprintf("dateOfJoining %q must be a YYYY-MM-DD date", employee.DateOfJoining))
+		} else if joined.After(today) {
+			errs = append(errs, fmt.Sprintf("dateOfJoining %s is in the future", employee.DateOfJoining))
+		}
+	}
+	if !dob.IsZero() && !joined.IsZero() && !joined.After(dob) {
+		errs = append(errs, "dateOfJoining must be after dob")
+	}
+
+	if employee.NetSalary != 0 {
+		if _, err := employee.NetSalaryAmount(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if employee.BankID != "" && !bankIDs[employee.BankID] {
+		errs = append(errs, fmt.Sprintf("bank %s does not exist", employee.BankID))
+	}
+
+	return errs
+}
+
+// validationMessages splits a struct validation error into one message per field, leaving
+// out net salary and Emirates ID failures, which employeeBusinessRuleErrors reports with a
+// clearer reason
+func validationMessages(err error) []string {
+	validationErr, ok := err.(*errors.ValidationError)
+	if !ok {
+		return []string{err.Error()}
+	}
+
+	var messages []string
+	for _, line := range strings.Split(validationErr.Message, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Contains(line, "'netsalary' tag") || strings.Contains(line, "'emiratesid' tag") {
+			continue
+		}
+		messages = append(messages, line)
+	}
+	return messages
+}
@@ -0,0 +1,747 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestGetEligibleForAdvance(t *testing.T) {
+	available := map[string]float64{"e1": 1200, "e2": 0, "e3": 150}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/employees" {
+			if r.URL.Query().Get("status") != "active" {
+				t.Error("Expected only active employees to be listed")
+			}
+			writeData(w, models.EmployeeListResponse{
+				Total:   4,
+				Results: []models.Employee{{ID: "e1"}, {ID: "e2"}, {ID: "e3"}, {ID: "e4"}},
+			})
+			return
+		}
+
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/transactions/employee/"), "/balance")
+		amount, ok := available[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "balance not found")
+			return
+		}
+		writeData(w, models.MonthlyBalanceResponse{
+			EmployeeID: id,
+			Balance:    models.MonthlyBalance{AvailableAmount: amount},
+		})
+	})
+
+	eligible, err := NewEmployeeService(c).GetEligibleForAdvance(context.Background(), 200)
+
+	var lookupErr *errors.LookupError
+	if !stderrors.As(err, &lookupErr) {
+		t.Fatalf("Expected a LookupError, got %v", err)
+	}
+	if _, ok := lookupErr.Errors["e4"]; !ok || len(lookupErr.Errors) != 1 {
+		t.Errorf("Expected only e4 to fail, got %v", lookupErr.Errors)
+	}
+
+	if len(eligible) != 1 {
+		t.Fatalf("Expected 1 eligible employee, got %d", len(eligible))
+	}
+	if eligible[0].Employee.ID != "e1" || eligible[0].AvailableAmount != 1200 {
+		t.Errorf("Unexpected eligible employee: %+v", eligible[0])
+	}
+}
+
+func TestValidateEmployeeNetSalary(t *testing.T) {
+	service := NewEmployeeService(nil)
+	base := models.Employee{EmployeeCode: "E001", Email: "ali@example.com", BankID: "bank-1"}
+
+	for _, salary := range []models.Money{0, -500, 8000.125} {
+		employee := base
+		employee.NetSalary = salary
+		if err := service.ValidateEmployee(employee); err == nil {
+			t.Errorf("%v: expected a validation error", salary)
+		}
+	}
+
+	base.NetSalary = 8000.50
+	if err := service.ValidateEmployee(base); err != nil {
+		t.Errorf("Expected valid salary to pass, got %v", err)
+	}
+}
+
+func TestCreateSingleRejectsInvalidNetSalary(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected the request to be rejected before it is sent")
+	})
+
+	employee := models.Employee{
+		EmployeeCode: "E001", FirstName: "Ali", LastName: "Hassan", Department: "Ops", Designation: "Clerk",
+		Email: "ali@example.com", DOB: "1990-01-01", DateOfJoining: "2020-01-01", AccountTitle: "Ali Hassan",
+		AccountNumber: "123", NetSalary: -500, EmiratesID: "784-1990-1234567-6", Gender: "Male",
+		BankID: "8f14e45f-ceea-4a67-9d8e-5d6f1c1b2a3c", PayrollStartDay: 1,
+	}
+
+	err := NewEmployeeService(c).CreateSingle(context.Background(), employee)
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || !strings.Contains(validationErr.Message, "NetSalary") {
+		t.Errorf("Expected a NetSalary validation error, got %v", err)
+	}
+}
+
+func TestValidateImportCSV(t *testing.T) {
+	const bankID = "8f14e45f-ceea-4a67-9d8e-5d6f1c1b2a3c"
+	var employeeRequests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/banks" {
+			employeeRequests++
+			return
+		}
+		writeData(w, models.BankListResponse{Total: 1, Results: []models.Bank{{ID: bankID, Name: "Emirates NBD"}}})
+	})
+
+	file := strings.Join([]string{
+		"employeeCode,firstName,lastName,department,designation,email,dob,dateOfJoining,accountTitle,accountNumber,netSalary,emiratesId,gender,bankId,payrollStartDay",
+		"E001,Ali,Hassan,Ops,Clerk,ali@example.com,1990-01-01,2020-01-01,Ali Hassan,123,8000,784-1990-1234567-6,Male," + bankID + ",1",
+		"E002,Sara,Khan,Ops,Clerk,sara@example.com,2030-01-01,2020-13-01,Sara Khan,456,8000.123,12345,Female,4a2f9c1e-7b3d-4e8a-9f6c-2d1b0a9e8c7f,1",
+		"E001,Omar,Ali,Ops,Clerk,omar@example.com,1985-05-05,2019-02-01,Omar Ali,789,9000,784-1985-7654321-3,Male," + bankID + ",x",
+		"E003,Noor,Saleh,Ops,Clerk,noor@example.com,1992-03-03,2021-04-04,Noor Saleh,321,7000,784-1992-1111111-9,Female," + bankID + ",1",
+	}, "\n")
+
+	report, err := NewEmployeeService(c).ValidateImport(context.Background(), strings.NewReader(file), "csv")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if employeeRequests != 0 {
+		t.Errorf("Expected nothing to be sent to the employee API, got %d requests", employeeRequests)
+	}
+
+	if report.TotalRows != 4 || report.ValidRows != 2 || report.InvalidRows != 2 || !report.HasErrors() {
+		t.Fatalf("Unexpected summary: total %d, valid %d, invalid %d", report.TotalRows, report.ValidRows, report.InvalidRows)
+	}
+	if !report.Rows[0].Valid || !report.Rows[3].Valid {
+		t.Errorf("Expected rows 1 and 4 to be valid, got %+v and %+v", report.Rows[0], report.Rows[3])
+	}
+
+	reasons := strings.Join(report.Rows[1].Errors, "; ")
+	for _, expected := range []string{"emirates ID", "dob 2030-01-01 is in the future", "dateOfJoining", "net salary", "does not exist"} {
+		if !strings.Contains(reasons, expected) {
+			t.Errorf("Expected row 2 errors to mention %q, got %s", expected, reasons)
+		}
+	}
+	if len(report.Rows[2].Errors) != 1 || !strings.Contains(report.Rows[2].Errors[0], "payrollStartDay") {
+		t.Errorf("Expected row 3 to fail parsing payrollStartDay, got %v", report.Rows[2].Errors)
+	}
+}
+
+func TestValidateImportJSONL(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.BankListResponse{})
+	})
+
+	file := `{"employeeCode":"E001","firstName":"Ali"}
+
+not json
+{"employeeCode":"E001","firstName":"Ali"}`
+
+	report, err := NewEmployeeService(c).ValidateImport(context.Background(), strings.NewReader(file), "jsonl")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.TotalRows != 3 || report.InvalidRows != 3 {
+		t.Fatalf("Expected 3 invalid rows, got %+v", report)
+	}
+	if !strings.Contains(report.Rows[1].Errors[0], "invalid JSON") {
+		t.Errorf("Expected a JSON error on row 2, got %v", report.Rows[1].Errors)
+	}
+	if !strings.Contains(strings.Join(report.Rows[2].Errors, "; "), "duplicate employee code E001") {
+		t.Errorf("Expected a duplicate code error on row 3, got %v", report.Rows[2].Errors)
+	}
+
+	if _, err := NewEmployeeService(c).ValidateImport(context.Background(), strings.NewReader(file), "xlsx"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+func TestIterateStreamsPagesInOrder(t *testing.T) {
+	var pages []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected limit 2, got %s", r.URL.Query().Get("limit"))
+		}
+
+		results := map[string][]models.Employee{
+			"1": {{ID: "e1"}, {ID: "e2"}},
+			"2": {{ID: "e3"}, {ID: "e4"}},
+			"3": {{ID: "e5"}},
+		}[page]
+		writeData(w, models.EmployeeListResponse{Total: 5, Results: results})
+	})
+
+	employees, errs := NewEmployeeService(c).Iterate(context.Background(), &models.EmployeeListOptions{Limit: 2})
+
+	var ids []string
+	for employee := range employees {
+		ids = append(ids, employee.ID)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(ids, ",") != "e1,e2,e3,e4,e5" {
+		t.Errorf("Expected all employees in order, got %v", ids)
+	}
+	if strings.Join(pages, ",") != "1,2,3" {
+		t.Errorf("Expected pages 1,2,3, got %v", pages)
+	}
+	if _, open := <-errs; open {
+		t.Error("Expected the error channel to be closed")
+	}
+}
+
+func TestIterateStopsOnCancel(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.EmployeeListResponse{Results: []models.Employee{{ID: "e1"}, {ID: "e2"}}})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	employees, errs := NewEmployeeService(c).Iterate(ctx, &models.EmployeeListOptions{Limit: 2})
+
+	<-employees
+	cancel()
+
+	for range employees {
+	}
+	if err := <-errs; !stderrors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled, got %v", err)
+	}
+}
+
+func newCreateTestEmployee(code string) models.Employee {
+	return models.Employee{
+		EmployeeCode: code, FirstName: "Ali", LastName: "Hassan", Department: "Ops", Designation: "Clerk",
+		Email: "ali@example.com", DOB: "1990-01-01", DateOfJoining: "2020-01-01", AccountTitle: "Ali Hassan",
+		AccountNumber: "123", NetSalary: 8000, EmiratesID: "784-1990-1234567-6", Gender: "Male",
+		BankID: "8f14e45f-ceea-4a67-9d8e-5d6f1c1b2a3c", PayrollStartDay: 1,
+	}
+}
+
+func TestCreateWithResultsMixedResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"index": 0, "employeeCode": "E001", "success": true},
+				{"index": 2, "employeeCode": "E003", "success": true},
+				{"index": 1, "employeeCode": "E002", "success": false, "error": "duplicate email"},
+			},
+		})
+	})
+
+	employees := []models.Employee{newCreateTestEmployee("E001"), newCreateTestEmployee("E002"), newCreateTestEmployee("E003")}
+	results, err := NewEmployeeService(c).CreateWithResults(context.Background(), employees)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, code := range []string{"E001", "E002", "E003"} {
+		if results[i].Index != i || results[i].EmployeeCode != code {
+			t.Errorf("Result %d not aligned to input: %+v", i, results[i])
+		}
+	}
+	if !results[0].Success || !results[2].Success {
+		t.Errorf("Expected E001 and E003 to succeed, got %+v", results)
+	}
+	if results[1].Success || results[1].Error != "duplicate email" {
+		t.Errorf("Expected E002 to fail with its message, got %+v", results[1])
+	}
+}
+
+func TestCreateWithResultsAggregateError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Validation failed",
+			Details:    "employees E10, E3 have invalid bank accounts",
+		})
+	})
+
+	employees := []models.Employee{newCreateTestEmployee("E1"), newCreateTestEmployee("E10"), newCreateTestEmployee("E3")}
+	results, err := NewEmployeeService(c).CreateWithResults(context.Background(), employees)
+
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) {
+		t.Fatalf("Expected the API error to be returned, got %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected results aligned to the input, got %d", len(results))
+	}
+	if results[0].Success || results[0].Error != "" {
+		t.Errorf("Expected E1 to be unmarked, got %+v", results[0])
+	}
+	for _, i := range []int{1, 2} {
+		if results[i].Success || !strings.Contains(results[i].Error, "invalid bank accounts") {
+			t.Errorf("Expected %s to carry the error details, got %+v", results[i].EmployeeCode, results[i])
+		}
+	}
+}
+
+func TestCreateWithResultsAggregateErrorCodesInMessage(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "employees E10, E3 have invalid bank accounts",
+		})
+	})
+
+	employees := []models.Employee{newCreateTestEmployee("E1"), newCreateTestEmployee("E10"), newCreateTestEmployee("E3")}
+	results, err := NewEmployeeService(c).CreateWithResults(context.Background(), employees)
+
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) {
+		t.Fatalf("Expected the API error to be returned, got %v", err)
+	}
+	if results[0].Error != "" {
+		t.Errorf("Expected E1 to be unmarked, got %+v", results[0])
+	}
+	for _, i := range []int{1, 2} {
+		if results[i].Success || !strings.Contains(results[i].Error, "invalid bank accounts") {
+			t.Errorf("Expected %s to carry the error message, got %+v", results[i].EmployeeCode, results[i])
+		}
+	}
+}
+
+func TestUpdateFieldsSendsOnlyGivenKeys(t *testing.T) {
+	var method, path string
+	var body map[string]interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		writeData(w, nil)
+	})
+
+	err := NewEmployeeService(c).UpdateFields(context.Background(), "emp-1", map[string]interface{}{
+		"netSalary":   "9500.00",
+		"designation": "Supervisor",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if method != http.MethodPatch || path != "/employees/emp-1" {
+		t.Errorf("Expected PATCH /employees/emp-1, got %s %s", method, path)
+	}
+	if len(body) != 2 || body["netSalary"] != "9500.00" || body["designation"] != "Supervisor" {
+		t.Errorf("Expected only the given keys to be sent, got %v", body)
+	}
+}
+
+func TestUpdateSalaryAndDepartment(t *testing.T) {
+	var bodies []map[string]interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		writeData(w, nil)
+	})
+	service := NewEmployeeService(c)
+
+	if err := service.UpdateSalary(context.Background(), "emp-1", 8000.50); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := service.UpdateDepartment(context.Background(), "emp-1", "Finance"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(bodies) != 2 || len(bodies[0]) != 1 || bodies[0]["netSalary"] != "8000.5" ||
+		len(bodies[1]) != 1 || bodies[1]["department"] != "Finance" {
+		t.Errorf("Expected single-key bodies, got %v", bodies)
+	}
+}
+
+func TestUpdateFieldsRejectsUnknownKeys(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected the request to be rejected before it is sent")
+	})
+	service := NewEmployeeService(c)
+
+	for _, key := range []string{"salary", "id", "createdAt"} {
+		err := service.UpdateFields(context.Background(), "emp-1", map[string]interface{}{key: "x"})
+		var validationErr *errors.ValidationError
+		if !stderrors.As(err, &validationErr) || validationErr.Field != key {
+			t.Errorf("%s: expected a validation error, got %v", key, err)
+		}
+	}
+
+	err := service.UpdateSalary(context.Background(), "emp-1", -10)
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || validationErr.Field != "netSalary" {
+		t.Errorf("Expected a netSalary validation error, got %v", err)
+	}
+}
+
+func TestGetEligibleByTenure(t *testing.T) {
+	today := time.Now()
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("status") != "active" {
+			t.Error("Expected only active employees to be listed")
+		}
+		writeData(w, models.EmployeeListResponse{Results: []models.Employee{
+			{ID: "e1", DateOfJoining: today.AddDate(-1, 0, 0).Format(models.DateLayout)},
+			{ID: "e2", DateOfJoining: today.Format(models.DateLayout)},
+			{ID: "e3", DateOfJoining: today.AddDate(0, 1, 0).Format(models.DateLayout)},
+			{ID: "e4", DateOfJoining: "unknown"},
+			{ID: "e5", DateOfJoining: today.AddDate(0, -3, 0).Format(models.DateLayout)},
+		}})
+	})
+
+	eligible, err := NewEmployeeService(c).GetEligibleByTenure(context.Background(), 3)
+
+	var lookupErr *errors.LookupError
+	if !stderrors.As(err, &lookupErr) || len(lookupErr.Errors) != 1 || lookupErr.Errors["e4"] == nil {
+		t.Fatalf("Expected a lookup error for e4, got %v", err)
+	}
+	if len(eligible) != 2 || eligible[0].ID != "e1" || eligible[1].ID != "e5" {
+		t.Errorf("Expected e1 and e5 to be eligible, got %+v", eligible)
+	}
+}
+
+func TestCreateNormalizesPhone(t *testing.T) {
+	var request models.EmployeesRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&request)
+		writeData(w, nil)
+	})
+
+	employee := newCreateTestEmployee("E001")
+	employee.Phone = "050 123 4567"
+	if err := NewEmployeeService(c).CreateSingle(context.Background(), employee); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(request.Employees) != 1 || request.Employees[0].Phone != "+971501234567" {
+		t.Errorf("Expected the phone in E.164 form, got %+v", request.Employees)
+	}
+
+	employee.Phone = "+9715012345"
+	err := NewEmployeeService(c).CreateSingle(context.Background(), employee)
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || !strings.Contains(validationErr.Message, "Phone") {
+		t.Errorf("Expected a Phone validation error, got %v", err)
+	}
+}
+
+func TestCreateIdempotentRerunIsNoOp(t *testing.T) {
+	roster := []models.Employee{{ID: "e1", EmployeeCode: "E001"}}
+	var keys []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeData(w, models.EmployeeListResponse{Results: roster})
+			return
+		}
+
+		var request models.EmployeesRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if request.Employees[0].EmployeeCode == "E003" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(models.ErrorResponse{StatusCode: http.StatusConflict, Message: "employee already exists"})
+			return
+		}
+		roster = append(roster, request.Employees[0])
+		writeData(w, nil)
+	})
+
+	service := NewEmployeeService(c)
+	employees := []models.Employee{newCreateTestEmployee("E001"), newCreateTestEmployee("E002"), newCreateTestEmployee("E003")}
+
+	results, err := service.CreateIdempotent(context.Background(), employees, BatchOpts{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !results[0].AlreadyExisted || results[1].AlreadyExisted || !results[2].AlreadyExisted {
+		t.Errorf("Expected E001 and E003 to already exist and E002 to be created, got %+v", results)
+	}
+	for _, result := range results {
+		if !result.Success {
+			t.Errorf("Expected %s to succeed, got %+v", result.EmployeeCode, result)
+		}
+	}
+	if len(keys) != 2 || keys[0] != "employee-create-E002" || keys[1] != "employee-create-E003" {
+		t.Errorf("Expected creates keyed by employee code for E002 and E003, got %v", keys)
+	}
+
+	// Re-running the import creates nothing new
+	keys = nil
+	results, err = service.CreateIdempotent(context.Background(), employees[:2], BatchOpts{})
+	if err != nil {
+		t.Fatalf("Expected no error on re-run, got %v", err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("Expected no creates on re-run, got %v", keys)
+	}
+	if !results[0].AlreadyExisted || !results[1].AlreadyExisted {
+		t.Errorf("Expected every employee to already exist, got %+v", results)
+	}
+}
+
+func TestCreateIdempotentReportsFailures(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeData(w, models.EmployeeListResponse{})
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid bank")
+	})
+
+	service := NewEmployeeService(c)
+	result, err := service.CreateSingleIdempotent(context.Background(), newCreateTestEmployee("E001"))
+
+	var batchErr *errors.BatchError
+	if !stderrors.As(err, &batchErr) || len(batchErr.Errors) != 1 {
+		t.Fatalf("Expected a batch error, got %v", err)
+	}
+	if result == nil || result.Success || result.AlreadyExisted || result.Error == "" {
+		t.Errorf("Expected a failed result with an error, got %+v", result)
+	}
+
+	duplicate := []models.Employee{newCreateTestEmployee("E001"), newCreateTestEmployee("E001")}
+	if _, err := service.CreateIdempotent(context.Background(), duplicate, BatchOpts{}); err == nil {
+		t.Error("Expected an error for duplicate employee codes")
+	}
+}
+
+func TestUpdateSalaries(t *testing.T) {
+	var mu sync.Mutex
+	patched := map[string]models.Money{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeData(w, models.EmployeeListResponse{Results: []models.Employee{
+				{ID: "e1", EmployeeCode: "E001"},
+				{ID: "e2", EmployeeCode: "E002"},
+				{ID: "e3", EmployeeCode: "E003"},
+			}})
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/employees/")
+		if id == "e3" {
+			writeError(w, http.StatusInternalServerError, "update failed")
+			return
+		}
+		var fields map[string]models.Money
+		json.NewDecoder(r.Body).Decode(&fields)
+		mu.Lock()
+		patched[id] = fields["netSalary"]
+		mu.Unlock()
+		writeData(w, nil)
+	})
+
+	report, err := NewEmployeeService(c).UpdateSalaries(context.Background(), map[string]string{
+		"E001": "9500.50",
+		"e2":   "12000",
+		"E003": "8000",
+		"E004": "7000",
+		"E005": "-100",
+	})
+	if err == nil {
+		t.Fatal("Expected an error for the failed updates")
+	}
+
+	if report.Total != 5 || len(report.Succeeded) != 2 || report.Succeeded[0] != "E001" || report.Succeeded[1] != "e2" {
+		t.Errorf("Expected E001 and e2 to succeed, got %+v", report.Succeeded)
+	}
+	if failed := report.FailedKeys(); len(failed) != 3 || failed[0] != "E003" || failed[1] != "E004" || failed[2] != "E005" {
+		t.Errorf("Expected E003, E004 and E005 to fail, got %v", failed)
+	}
+	var validationErr *errors.ValidationError
+	if !stderrors.As(report.Failed["E005"], &validationErr) {
+		t.Errorf("Expected a validation error for the negative salary, got %v", report.Failed["E005"])
+	}
+	if patched["e1"] != 9500.50 || patched["e2"] != 12000 || len(patched) != 2 {
+		t.Errorf("Unexpected salary updates: %v", patched)
+	}
+}
+
+func TestServiceErrorsMatchSentinels(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/employees/missing":
+			writeError(w, http.StatusNotFound, "Employee does not exist")
+		default:
+			writeError(w, http.StatusConflict, "Duplicate employee code")
+		}
+	})
+	service := NewEmployeeService(c)
+
+	_, err := service.GetByID(context.Background(), "missing")
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if stderrors.Is(err, errors.ErrConflict) {
+		t.Errorf("Did not expect ErrConflict for %v", err)
+	}
+
+	_, err = service.GetByID(context.Background(), "other")
+	if !stderrors.Is(err, errors.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+}
+
+// rosterServer serves a current roster and records the writes made by a roster sync
+type rosterServer struct {
+	mutex  sync.Mutex
+	writes []string // "METHOD path key body"
+}
+
+func (rs *rosterServer) handler(current []models.Employee) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeData(w, models.EmployeeListResponse{Total: len(current), Results: current})
+			return
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		rs.mutex.Lock()
+		write := r.Method + " " + r.URL.Path
+		if key := r.Header.Get("Idempotency-Key"); key != "" {
+			write += " " + key
+		}
+		if status, ok := body["status"]; ok {
+			write += " status=" + status.(string)
+		}
+		rs.writes = append(rs.writes, write)
+		rs.mutex.Unlock()
+		writeData(w, nil)
+	}
+}
+
+func newSyncTestRoster() (current, desired []models.Employee) {
+	kept := newCreateTestEmployee("E001")
+	kept.ID = "e1"
+	leaver := newCreateTestEmployee("E003")
+	leaver.ID = "e3"
+
+	renamed := newCreateTestEmployee("E001")
+	renamed.FirstName = "Omar"
+	return []models.Employee{kept, leaver}, []models.Employee{renamed, newCreateTestEmployee("E002")}
+}
+
+func TestSyncRosterDryRunPlansWithoutWriting(t *testing.T) {
+	current, desired := newSyncTestRoster()
+	server := &rosterServer{}
+	c := newTestClient(t, server.handler(current))
+
+	report, err := NewEmployeeService(c).SyncRoster(context.Background(), desired, SyncOpts{DryRun: true, DeactivateMissing: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !report.DryRun || report.Count(SyncActionCreate) != 1 || report.Count(SyncActionUpdate) != 1 || report.Count(SyncActionDeactivate) != 1 {
+		t.Errorf("Expected one create, update and deactivation, got %+v", report.Actions)
+	}
+	for _, action := range report.Actions {
+		switch action.Type {
+		case SyncActionUpdate:
+			if action.EmployeeID != "e1" || len(action.Changes) != 1 || action.Changes[0] != "firstName" {
+				t.Errorf("Expected e1's first name to be updated, got %+v", action)
+			}
+		case SyncActionDeactivate:
+			if action.EmployeeID != "e3" {
+				t.Errorf("Expected e3 to be deactivated, got %+v", action)
+			}
+		}
+	}
+	if len(server.writes) != 0 {
+		t.Errorf("Expected a dry run not to write, got %v", server.writes)
+	}
+}
+
+func TestSyncRosterLeavesMissingEmployeesByDefault(t *testing.T) {
+	current, desired := newSyncTestRoster()
+	server := &rosterServer{}
+	c := newTestClient(t, server.handler(current))
+
+	report, err := NewEmployeeService(c).SyncRoster(context.Background(), desired, SyncOpts{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Count(SyncActionDeactivate) != 0 {
+		t.Errorf("Expected no deactivations without DeactivateMissing, got %+v", report.Actions)
+	}
+	for _, write := range server.writes {
+		if strings.Contains(write, "/employees/e3") {
+			t.Errorf("Expected the missing employee to be left untouched, got %s", write)
+		}
+	}
+}
+
+func TestSyncRosterApplyDeactivatesInsteadOfDeleting(t *testing.T) {
+	current, desired := newSyncTestRoster()
+	server := &rosterServer{}
+	c := newTestClient(t, server.handler(current))
+
+	report, err := NewEmployeeService(c).SyncRoster(context.Background(), desired, SyncOpts{DeactivateMissing: true, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Failed()) != 0 {
+		t.Errorf("Expected every action to succeed, got %+v", report.Failed())
+	}
+
+	expected := map[string]bool{
+		"POST /employees employee-create-E002": true,
+		"PUT /employees":                       true,
+		"PATCH /employees/e3 status=inactive":  true,
+	}
+	if len(server.writes) != len(expected) {
+		t.Fatalf("Expected writes %v, got %v", expected, server.writes)
+	}
+	for _, write := range server.writes {
+		if !expected[write] {
+			t.Errorf("Unexpected write %s", write)
+		}
+	}
+}
+
+func TestSyncRosterSkipsEmployeesAlreadyInactive(t *testing.T) {
+	current, desired := newSyncTestRoster()
+
+	// The roster as the API reports it after a first sync has been applied
+	synced := append([]models.Employee{}, desired...)
+	synced[0].ID = "e1"
+	synced[1].ID = "e2"
+	leaver := current[1]
+	leaver.Status = "inactive"
+	synced = append(synced, leaver)
+
+	server := &rosterServer{}
+	c := newTestClient(t, server.handler(synced))
+
+	report, err := NewEmployeeService(c).SyncRoster(context.Background(), desired, SyncOpts{DeactivateMissing: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Count(SyncActionDeactivate) != 0 || len(report.Actions) != 0 || report.Unchanged != 2 {
+		t.Errorf("Expected a second sync to change nothing, got %+v (unchanged %d)", report.Actions, report.Unchanged)
+	}
+	if len(server.writes) != 0 {
+		t.Errorf("Expected no writes, got %v", server.writes)
+	}
+}
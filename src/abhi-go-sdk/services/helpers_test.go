@@ -0,0 +1,48 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"abhi-go-sdk/client"
+	"abhi-go-sdk/models"
+)
+
+// newTestClient creates a client backed by a test server that handles login
+// and delegates all other requests to the given handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			writeData(w, map[string]interface{}{"token": "test-token"})
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return client.New(client.NewConfig(server.URL, "test", "pass"))
+}
+
+// writeData writes data wrapped in the standard API response envelope
+func writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.APIResponse{
+		StatusCode: http.StatusOK,
+		Message:    "Success",
+		Data:       data,
+	})
+}
+
+// writeError writes an error response with the given status code
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		StatusCode: statusCode,
+		Message:    message,
+	})
+}
@@ -5,23 +5,45 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
 	"abhi-go-sdk/models"
 )
 
 // MiscService handles miscellaneous API operations (banks, business types)
 type MiscService struct {
-	client *client.Client
+	client        *client.Client
+	banks         *ttlCache[models.BankListResponse]
+	businessTypes *ttlCache[models.BusinessTypeListResponse]
 }
 
 // NewMiscService creates a new miscellaneous service
 func NewMiscService(client *client.Client) *MiscService {
 	return &MiscService{
-		client: client,
+		client:        client,
+		banks:         newTTLCache[models.BankListResponse](0),
+		businessTypes: newTTLCache[models.BusinessTypeListResponse](0),
 	}
 }
 
+// WithCacheTTL caches bank and business type listings in memory for ttl, keyed by their query.
+// Reference data rarely changes, so repeated lookups during onboarding can skip the API. Zero,
+// the default, disables caching.
+func (s *MiscService) WithCacheTTL(ttl time.Duration) *MiscService {
+	s.banks.setTTL(ttl)
+	s.businessTypes.setTTL(ttl)
+	return s
+}
+
+// InvalidateCache discards all cached banks and business types
+func (s *MiscService) InvalidateCache() {
+	s.banks.clear()
+	s.businessTypes.clear()
+}
+
 // Banks Section
 
 // GetBanks retrieves a paginated list of banks
@@ -41,6 +63,15 @@ func (s *MiscService) GetBanks(ctx context.Context, opts *models.BankListOptions
 		if opts.Active != nil {
 			query.Set("active", strconv.FormatBool(*opts.Active))
 		}
+		if opts.SwiftCode != "" {
+			query.Set("swiftCode", opts.SwiftCode)
+		}
+	}
+
+	cacheKey := query.Encode()
+	if cached, ok := s.banks.get(cacheKey); ok {
+		cached.Results = append([]models.Bank(nil), cached.Results...)
+		return &cached, nil
 	}
 
 	var result models.BankListResponse
@@ -49,6 +80,10 @@ func (s *MiscService) GetBanks(ctx context.Context, opts *models.BankListOptions
 		return nil, fmt.Errorf("failed to get banks: %w", err)
 	}
 
+	cached := result
+	cached.Results = append([]models.Bank(nil), result.Results...)
+	s.banks.set(cacheKey, cached)
+
 	return &result, nil
 }
 
@@ -94,6 +129,56 @@ func (s *MiscService) GetBankByID(ctx context.Context, bankID string) (*models.B
 	return &result, nil
 }
 
+// GetBankBySwiftCode retrieves the bank with the given SWIFT/BIC code, ignoring case and
+// surrounding whitespace. The code is passed to the server as a filter and also matched in the
+// SDK, so servers that ignore the filter are paged through. It returns an error matching
+// errors.ErrNotFound if no bank has the code and errors.ErrAmbiguousMatch if several do.
+func (s *MiscService) GetBankBySwiftCode(ctx context.Context, swift string) (*models.Bank, error) {
+	code := strings.ToUpper(strings.TrimSpace(swift))
+	if code == "" {
+		return nil, &errors.ValidationError{
+			Field:   "swiftCode",
+			Message: "SWIFT code is required",
+			Value:   swift,
+		}
+	}
+
+	limit := 100
+	fetchPage := func(ctx context.Context, page int) ([]models.Bank, bool, error) {
+		response, err := s.GetBanks(ctx, &models.BankListOptions{
+			Page:      page,
+			Limit:     limit,
+			SwiftCode: code,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		var matches []models.Bank
+		for _, bank := range response.Results {
+			if strings.ToUpper(strings.TrimSpace(bank.SwiftCode)) == code {
+				matches = append(matches, bank)
+			}
+		}
+		return matches, len(response.Results) == limit, nil
+	}
+
+	// Two matches are enough to know the code is ambiguous
+	matches, err := CollectUntil(ctx, fetchPage, nil, 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank by SWIFT code %s: %w", code, err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no bank with SWIFT code %s: %w", code, errors.ErrNotFound)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("several banks have SWIFT code %s: %w", code, errors.ErrAmbiguousMatch)
+	}
+}
+
 // GetActiveBanks retrieves only active banks
 func (s *MiscService) GetActiveBanks(ctx context.Context) ([]models.Bank, error) {
 	active := true
@@ -179,12 +264,22 @@ func (s *MiscService) GetBusinessTypes(ctx context.Context, opts *models.Busines
 		}
 	}
 
+	cacheKey := query.Encode()
+	if cached, ok := s.businessTypes.get(cacheKey); ok {
+		cached.Results = append([]models.BusinessType(nil), cached.Results...)
+		return &cached, nil
+	}
+
 	var result models.BusinessTypeListResponse
 	err := s.client.GETWithQuery(ctx, "/business", query, &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get business types: %w", err)
 	}
 
+	cached := result
+	cached.Results = append([]models.BusinessType(nil), result.Results...)
+	s.businessTypes.set(cacheKey, cached)
+
 	return &result, nil
 }
 
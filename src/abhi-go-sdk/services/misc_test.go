@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestGetAllBanksCachedWithinTTL(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeData(w, models.BankListResponse{Total: 1, Results: []models.Bank{{ID: "bank-1", Name: "Emirates NBD"}}})
+	})
+
+	now := time.Now()
+	service := NewMiscService(c).WithCacheTTL(time.Minute)
+	service.banks.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		banks, err := service.GetAllBanks(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(banks) != 1 || banks[0].ID != "bank-1" {
+			t.Fatalf("Unexpected banks: %+v", banks)
+		}
+		banks[0].Name = "modified by caller"
+	}
+	if calls != 1 {
+		t.Errorf("Expected the second call to hit the cache, got %d requests", calls)
+	}
+
+	banks, _ := service.GetAllBanks(context.Background())
+	if banks[0].Name != "Emirates NBD" {
+		t.Errorf("Expected cached results to be unaffected by callers, got %q", banks[0].Name)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := service.GetAllBanks(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected a call after expiry to refetch, got %d requests", calls)
+	}
+}
+
+func TestBusinessTypesCacheKeyedByQueryAndInvalidated(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeData(w, models.BusinessTypeListResponse{Total: 1, Results: []models.BusinessType{{ID: "bt-1", Country: r.URL.Query().Get("country")}}})
+	})
+	service := NewMiscService(c).WithCacheTTL(time.Minute)
+
+	ae, err := service.GetBusinessTypesByCountry(context.Background(), "AE")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	sa, err := service.GetBusinessTypesByCountry(context.Background(), "SA")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ae[0].Country != "AE" || sa[0].Country != "SA" || calls != 2 {
+		t.Errorf("Expected separate cache entries per query, got %v, %v after %d requests", ae, sa, calls)
+	}
+
+	service.GetBusinessTypesByCountry(context.Background(), "AE")
+	if calls != 2 {
+		t.Errorf("Expected a cache hit, got %d requests", calls)
+	}
+
+	service.InvalidateCache()
+	service.GetBusinessTypesByCountry(context.Background(), "AE")
+	if calls != 3 {
+		t.Errorf("Expected a refetch after InvalidateCache, got %d requests", calls)
+	}
+}
+
+func TestMiscServiceCachingDisabledByDefault(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeData(w, models.BankListResponse{})
+	})
+	service := NewMiscService(c)
+
+	service.GetAllBanks(context.Background())
+	service.GetAllBanks(context.Background())
+	if calls != 2 {
+		t.Errorf("Expected every call to reach the API, got %d requests", calls)
+	}
+}
+
+func TestGetBankBySwiftCode(t *testing.T) {
+	// The server ignores the swiftCode filter and returns every bank
+	var filters []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("swiftCode"))
+		writeData(w, models.BankListResponse{Total: 4, Results: []models.Bank{
+			{ID: "bank-1", Name: "Emirates NBD", SwiftCode: "EBILAEAD"},
+			{ID: "bank-2", Name: "First Abu Dhabi Bank", SwiftCode: "NBADAEAA"},
+			{ID: "bank-3", Name: "Mashreq", SwiftCode: "BOMLAEAD"},
+			{ID: "bank-4", Name: "Mashreq Islamic", SwiftCode: "bomlaead "},
+		}})
+	})
+	service := NewMiscService(c)
+
+	t.Run("exact match", func(t *testing.T) {
+		bank, err := service.GetBankBySwiftCode(context.Background(), "NBADAEAA")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if bank.ID != "bank-2" {
+			t.Errorf("Expected bank-2, got %+v", bank)
+		}
+	})
+
+	t.Run("lowercase input", func(t *testing.T) {
+		filters = nil
+		bank, err := service.GetBankBySwiftCode(context.Background(), "  ebilaead ")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if bank.ID != "bank-1" {
+			t.Errorf("Expected bank-1, got %+v", bank)
+		}
+		if len(filters) != 1 || filters[0] != "EBILAEAD" {
+			t.Errorf("Expected the normalized code to be sent as a filter, got %v", filters)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := service.GetBankBySwiftCode(context.Background(), "ADCBAEAA")
+		if !stderrors.Is(err, errors.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("multiple matches", func(t *testing.T) {
+		_, err := service.GetBankBySwiftCode(context.Background(), "BOMLAEAD")
+		if !stderrors.Is(err, errors.ErrAmbiguousMatch) {
+			t.Errorf("Expected ErrAmbiguousMatch, got %v", err)
+		}
+	})
+
+	t.Run("empty code", func(t *testing.T) {
+		var validationErr *errors.ValidationError
+		if _, err := service.GetBankBySwiftCode(context.Background(), " "); !stderrors.As(err, &validationErr) {
+			t.Errorf("Expected a ValidationError, got %v", err)
+		}
+	})
+}
@@ -2,17 +2,21 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
 	"abhi-go-sdk/models"
 )
 
 // OrganizationService handles organization-related API operations
 type OrganizationService struct {
-	client *client.Client
+	client          *client.Client
+	pageConcurrency int
 }
 
 // NewOrganizationService creates a new organization service
@@ -22,10 +26,29 @@ func NewOrganizationService(client *client.Client) *OrganizationService {
 	}
 }
 
+// WithPageConcurrency makes GetAll fetch pages with up to workers requests in flight; see CollectAll.
+func (s *OrganizationService) WithPageConcurrency(workers int) *OrganizationService {
+	s.pageConcurrency = workers
+	return s
+}
+
 // List retrieves a paginated list of sub-organizations
 func (s *OrganizationService) List(ctx context.Context, opts *models.OrganizationListOptions) (*models.OrganizationListResponse, error) {
+	query := organizationListQuery(opts)
+
+	var result models.OrganizationListResponse
+	err := s.client.GETWithQuery(ctx, "/organizations", query, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return &result, nil
+}
+
+// organizationListQuery builds the query parameters for listing organizations
+func organizationListQuery(opts *models.OrganizationListOptions) url.Values {
 	query := url.Values{}
-	
+
 	if opts != nil {
 		if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
@@ -48,15 +71,12 @@ func (s *OrganizationService) List(ctx context.Context, opts *models.Organizatio
 		if opts.Order != "" {
 			query.Set("order", opts.Order)
 		}
+		if opts.Search != "" {
+			query.Set("search", opts.Search)
+		}
 	}
 
-	var result models.OrganizationListResponse
-	err := s.client.GETWithQuery(ctx, "/organizations", query, &result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list organizations: %w", err)
-	}
-
-	return &result, nil
+	return query
 }
 
 // GetAll retrieves all organizations with pagination handling
@@ -65,6 +85,20 @@ func (s *OrganizationService) GetAll(ctx context.Context) ([]models.Organization
 	page := 1
 	limit := 100
 
+	if s.pageConcurrency > 1 {
+		organizations, err := CollectAll(ctx, func(ctx context.Context, page int) ([]models.Organization, int, error) {
+			response, err := s.List(ctx, &models.OrganizationListOptions{Page: page, Limit: limit})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Results, response.Total, nil
+		}, limit, s.pageConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get organizations: %w", err)
+		}
+		return organizations, nil
+	}
+
 	for {
 		opts := &models.OrganizationListOptions{
 			Page:  page,
@@ -103,6 +137,8 @@ func (s *OrganizationService) GetByID(ctx context.Context, organizationID string
 
 // Create creates a new sub-organization
 func (s *OrganizationService) Create(ctx context.Context, req models.CreateOrganizationRequest) (*models.CreateOrganizationResponse, error) {
+	req.Phone = normalizePhone(req.Phone)
+
 	var result models.CreateOrganizationResponse
 	err := s.client.POST(ctx, "/organizations", req, &result)
 	if err != nil {
@@ -112,6 +148,195 @@ func (s *OrganizationService) Create(ctx context.Context, req models.CreateOrgan
 	return &result, nil
 }
 
+// Update applies a partial update to an organization and returns the updated organization
+func (s *OrganizationService) Update(ctx context.Context, id string, req models.UpdateOrganizationRequest) (*models.Organization, error) {
+	if req.IsEmpty() {
+		return nil, &errors.ValidationError{
+			Field:   "request",
+			Message: "At least one field is required",
+		}
+	}
+	if req.Phone != nil {
+		phone := normalizePhone(*req.Phone)
+		req.Phone = &phone
+	}
+
+	var result models.Organization
+	endpoint := fmt.Sprintf("/organizations/%s", id)
+
+	err := s.client.PATCH(ctx, endpoint, req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update organization %s: %w", id, err)
+	}
+
+	return &result, nil
+}
+
+// Activate marks an organization as active
+func (s *OrganizationService) Activate(ctx context.Context, id string) (*models.Organization, error) {
+	return s.setActive(ctx, id, true)
+}
+
+// Deactivate marks an organization as inactive. Inactive organizations are hidden from
+// listings unless ShowInactive is set.
+func (s *OrganizationService) Deactivate(ctx context.Context, id string) (*models.Organization, error) {
+	return s.setActive(ctx, id, false)
+}
+
+// setActive sets the active flag of an organization
+func (s *OrganizationService) setActive(ctx context.Context, id string, active bool) (*models.Organization, error) {
+	action := "deactivate"
+	if active {
+		action = "activate"
+	}
+
+	// Active is a pointer, so false is sent rather than omitted
+	var result models.Organization
+	endpoint := fmt.Sprintf("/organizations/%s", id)
+
+	err := s.client.PATCH(ctx, endpoint, models.UpdateOrganizationRequest{Active: &active}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s organization %s: %w", action, id, err)
+	}
+
+	return &result, nil
+}
+
+// UpdateCreditLimit requests a change to an organization's credit limit and returns the change record.
+// If the change needs approval it didn't get, the record is returned with an *errors.ApprovalRequiredError.
+// When the API rejects the change outright, the record is decoded from the error data if present.
+func (s *OrganizationService) UpdateCreditLimit(ctx context.Context, orgID string, newLimit float64, reason string) (*models.CreditLimitChange, error) {
+	if newLimit <= 0 {
+		return nil, &errors.ValidationError{
+			Field:   "creditLimit",
+			Message: "credit limit must be positive",
+			Value:   strconv.FormatFloat(newLimit, 'f', -1, 64),
+		}
+	}
+
+	req := models.UpdateCreditLimitRequest{
+		CreditLimit: newLimit,
+		Reason:      reason,
+	}
+
+	var result models.CreditLimitChange
+	endpoint := fmt.Sprintf("/organizations/%s/credit-limit", orgID)
+
+	err := s.client.PUT(ctx, endpoint, req, &result)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok && apiErr.IsApprovalRequired() {
+			approvalErr := &errors.ApprovalRequiredError{Resource: orgID, APIError: apiErr}
+			if change := creditLimitChangeFromError(apiErr); change != nil {
+				approvalErr.ChangeID = change.ID
+				approvalErr.Status = change.ApprovalStatus
+				return change, fmt.Errorf("failed to update credit limit for organization %s: %w", orgID, approvalErr)
+			}
+			err = approvalErr
+		}
+		return nil, fmt.Errorf("failed to update credit limit for organization %s: %w", orgID, err)
+	}
+
+	if !result.IsApplied() {
+		return &result, &errors.ApprovalRequiredError{
+			Resource: orgID,
+			ChangeID: result.ID,
+			Status:   result.ApprovalStatus,
+		}
+	}
+
+	return &result, nil
+}
+
+// creditLimitChangeFromError decodes the change record an approval error carries in its data,
+// returning nil if there is none
+func creditLimitChangeFromError(apiErr *errors.APIError) *models.CreditLimitChange {
+	if len(apiErr.Data) == 0 {
+		return nil
+	}
+
+	var change models.CreditLimitChange
+	data, err := json.Marshal(apiErr.Data)
+	if err != nil || json.Unmarshal(data, &change) != nil || change.ID == "" {
+		return nil
+	}
+	return &change
+}
+
+// GetUsers retrieves the admin, operator and support users of an organization. Passwords and
+// MFA secrets are never returned; use ResetUserPassword to issue a new password.
+func (s *OrganizationService) GetUsers(ctx context.Context, orgID string) ([]models.OrganizationUser, error) {
+	var result []models.OrganizationUser
+	endpoint := fmt.Sprintf("/organizations/%s/users", orgID)
+
+	err := s.client.GET(ctx, endpoint, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users for organization %s: %w", orgID, err)
+	}
+
+	for i := range result {
+		result[i].Password = ""
+		result[i].MFASecret = ""
+	}
+
+	return result, nil
+}
+
+// ResetUserPassword regenerates the password of an organization user and returns the new password.
+// The user must belong to the organization. A role, when the API reports one, must be one of
+// the known organization roles; users listed without a role can still be reset.
+func (s *OrganizationService) ResetUserPassword(ctx context.Context, orgID, username string) (string, error) {
+	users, err := s.GetUsers(ctx, orgID)
+	if err != nil {
+		return "", fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	var user *models.OrganizationUser
+	for i := range users {
+		if users[i].Username == username {
+			user = &users[i]
+			break
+		}
+	}
+	if user == nil {
+		return "", &errors.ValidationError{
+			Field:   "username",
+			Message: fmt.Sprintf("organization %s has no user %s", orgID, username),
+			Value:   username,
+		}
+	}
+	if user.Role != "" && !models.IsKnownOrganizationRole(user.Role) {
+		return "", &errors.ValidationError{
+			Field:   "role",
+			Message: fmt.Sprintf("user %s has unknown role %q", username, user.Role),
+			Value:   user.Role,
+		}
+	}
+
+	var result models.ResetPasswordResponse
+	endpoint := fmt.Sprintf("/organizations/%s/users/%s/reset-password", orgID, url.PathEscape(username))
+
+	err = s.client.POST(ctx, endpoint, nil, &result)
+	if err != nil {
+		return "", fmt.Errorf("failed to reset password for %s: %w", username, err)
+	}
+
+	return result.Password, nil
+}
+
+// CreateUnder creates a sub-organization after checking that parent is a parent organization,
+// as sub-organizations can't create sub-organizations of their own
+func (s *OrganizationService) CreateUnder(ctx context.Context, parent models.Organization, req models.CreateOrganizationRequest) (*models.CreateOrganizationResponse, error) {
+	if !parent.OrganizationType.IsParent() {
+		return nil, &errors.ValidationError{
+			Field:   "organizationType",
+			Message: fmt.Sprintf("organization %s is not a parent organization and can't create sub-organizations", parent.ID),
+			Value:   string(parent.OrganizationType),
+		}
+	}
+
+	return s.Create(ctx, req)
+}
+
 // GetActive retrieves only active organizations
 func (s *OrganizationService) GetActive(ctx context.Context, opts *models.OrganizationListOptions) (*models.OrganizationListResponse, error) {
 	if opts == nil {
@@ -186,34 +411,40 @@ func (s *OrganizationService) GetByDateRange(ctx context.Context, startDate, end
 	return result.Results, nil
 }
 
-// Search searches for organizations by name
+// Search returns up to limit organizations whose name contains searchTerm, ignoring case. The
+// term is passed to the server as the search parameter so filtering happens server-side; names
+// are also matched in the SDK, so against a server that ignores the parameter pages are fetched
+// until limit matches are found.
 func (s *OrganizationService) Search(ctx context.Context, searchTerm string, limit int) ([]models.Organization, error) {
 	if limit <= 0 {
 		limit = 50
 	}
 
-	// Get all organizations and filter by name
-	allOrgs, err := s.GetAll(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search organizations: %w", err)
-	}
+	term := strings.ToLower(searchTerm)
+	pageSize := 100
 
-	var matchedOrgs []models.Organization
-	count := 0
-	for _, org := range allOrgs {
-		if count >= limit {
-			break
+	fetchPage := func(ctx context.Context, page int) ([]models.Organization, bool, error) {
+		response, err := s.List(ctx, &models.OrganizationListOptions{
+			Page:   page,
+			Limit:  pageSize,
+			Search: searchTerm,
+		})
+		if err != nil {
+			return nil, false, err
 		}
-		// Simple case-insensitive name search
-		if len(org.Name) >= len(searchTerm) {
-			for i := 0; i <= len(org.Name)-len(searchTerm); i++ {
-				if org.Name[i:i+len(searchTerm)] == searchTerm {
-					matchedOrgs = append(matchedOrgs, org)
-					count++
-					break
-				}
+
+		var matches []models.Organization
+		for _, org := range response.Results {
+			if strings.Contains(strings.ToLower(org.Name), term) {
+				matches = append(matches, org)
 			}
 		}
+		return matches, len(response.Results) == pageSize, nil
+	}
+
+	matchedOrgs, err := CollectUntil(ctx, fetchPage, nil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search organizations: %w", err)
 	}
 
 	return matchedOrgs, nil
@@ -321,4 +552,122 @@ func (s *OrganizationService) GetStatistics(ctx context.Context) (map[string]int
 	}
 
 	return stats, nil
-}
\ No newline at end of file
+}
+
+// orgStatsResult is an organization as returned with includeStats, where
+// missing counts indicate the server doesn't support embedded statistics
+type orgStatsResult struct {
+	models.Organization
+	TotalEmployees  *int `json:"totalEmployees"`
+	ActiveEmployees *int `json:"activeEmployees"`
+}
+
+// orgStatsListResponse represents the response for organization list with statistics
+type orgStatsListResponse struct {
+	Total   int              `json:"total"`
+	Results []orgStatsResult `json:"results"`
+}
+
+// ListWithEmployeeCounts retrieves organizations together with their total and active
+// employee counts. Counts embedded by the server are used when available; otherwise they
+// are looked up concurrently, bounded by lookupConcurrency and the client's rate limiter. If ctx is
+// cancelled, the organizations are returned with the counts fetched so far and the context error.
+func (s *OrganizationService) ListWithEmployeeCounts(ctx context.Context, opts *models.OrganizationListOptions) ([]models.OrgWithStats, error) {
+	query := organizationListQuery(opts)
+	query.Set("includeStats", "true")
+
+	var response orgStatsListResponse
+	err := s.client.GETWithQuery(ctx, "/organizations", query, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations with employee counts: %w", err)
+	}
+
+	results := make([]models.OrgWithStats, len(response.Results))
+	var missing []int
+	for i, org := range response.Results {
+		results[i] = models.OrgWithStats{Organization: org.Organization}
+		if org.TotalEmployees == nil || org.ActiveEmployees == nil {
+			missing = append(missing, i)
+			continue
+		}
+		results[i].TotalEmployees = *org.TotalEmployees
+		results[i].ActiveEmployees = *org.ActiveEmployees
+	}
+
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	employees := NewEmployeeService(s.client)
+	type orgCounts struct{ total, active int }
+
+	outcomes, ctxErr := collectBatch(ctx, len(missing), BatchOpts{Concurrency: lookupConcurrency}, func(ctx context.Context, index int) (orgCounts, error) {
+		total, active, err := employees.countByOrganization(ctx, results[missing[index]].ID)
+		return orgCounts{total: total, active: active}, err
+	})
+
+	for i, outcome := range outcomes {
+		if outcome.Done && outcome.Err == nil {
+			results[missing[i]].TotalEmployees = outcome.Value.total
+			results[missing[i]].ActiveEmployees = outcome.Value.active
+		}
+	}
+	if ctxErr != nil {
+		return results, fmt.Errorf("failed to count employees: %w", ctxErr)
+	}
+
+	for i, outcome := range outcomes {
+		if outcome.Err != nil {
+			return nil, fmt.Errorf("failed to count employees for organization %s: %w", results[missing[i]].ID, outcome.Err)
+		}
+	}
+
+	return results, nil
+}
+
+// ListWithCreditUtilization retrieves organizations together with their credit utilization, the
+// total outstanding balance of each organization's employees. Utilization is fetched concurrently
+// with requests scoped to each organization, bounded by lookupConcurrency and the client's rate
+// limiter. An organization whose utilization can't be fetched keeps its Error set and is reported
+// in an *errors.LookupError alongside the other results. If ctx is cancelled, the organizations
+// are returned with the utilization fetched so far and the context error.
+func (s *OrganizationService) ListWithCreditUtilization(ctx context.Context, opts *models.OrganizationListOptions) ([]models.OrgCreditView, error) {
+	response, err := s.List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations with credit utilization: %w", err)
+	}
+
+	repayments := NewRepaymentService(s.client)
+	outcomes, ctxErr := collectBatch(ctx, len(response.Results), BatchOpts{Concurrency: lookupConcurrency}, func(ctx context.Context, index int) (float64, error) {
+		summary, err := repayments.GetOutstandingBalanceSummary(client.WithOrganizationID(ctx, response.Results[index].ID))
+		if err != nil {
+			return 0, err
+		}
+		return summary.TotalOutstanding.Float64(), nil
+	})
+
+	results := make([]models.OrgCreditView, len(response.Results))
+	lookupErr := errors.NewLookupError(len(response.Results))
+	for i, org := range response.Results {
+		outcome := outcomes[i]
+		if outcome.Done && outcome.Err == nil {
+			results[i] = models.NewOrgCreditView(org, outcome.Value)
+			continue
+		}
+
+		results[i] = models.OrgCreditView{Organization: org}
+		if outcome.Err != nil {
+			results[i].Error = outcome.Err.Error()
+			lookupErr.Errors[org.ID] = outcome.Err
+		}
+	}
+
+	if ctxErr != nil {
+		return results, fmt.Errorf("failed to fetch credit utilization: %w", ctxErr)
+	}
+	if lookupErr.HasErrors() {
+		return results, lookupErr
+	}
+
+	return results, nil
+}
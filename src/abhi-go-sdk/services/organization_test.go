@@ -0,0 +1,492 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestListWithEmployeeCountsUsesEmbeddedStats(t *testing.T) {
+	var employeeCalls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations":
+			if r.URL.Query().Get("includeStats") != "true" {
+				t.Error("Expected includeStats=true")
+			}
+			writeData(w, map[string]interface{}{
+				"total": 2,
+				"results": []map[string]interface{}{
+					{"id": "org-1", "name": "Alpha", "totalEmployees": 10, "activeEmployees": 8},
+					{"id": "org-2", "name": "Beta", "totalEmployees": 0, "activeEmployees": 0},
+				},
+			})
+		case "/employees":
+			atomic.AddInt32(&employeeCalls, 1)
+			writeData(w, models.EmployeeListResponse{})
+		}
+	})
+
+	orgs, err := NewOrganizationService(c).ListWithEmployeeCounts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(orgs) != 2 {
+		t.Fatalf("Expected 2 organizations, got %d", len(orgs))
+	}
+	if orgs[0].Name != "Alpha" || orgs[0].TotalEmployees != 10 || orgs[0].ActiveEmployees != 8 {
+		t.Errorf("Unexpected stats for first organization: %+v", orgs[0])
+	}
+	if calls := atomic.LoadInt32(&employeeCalls); calls != 0 {
+		t.Errorf("Expected no employee lookups, got %d", calls)
+	}
+}
+
+func TestListWithEmployeeCountsFallsBackToCounting(t *testing.T) {
+	totals := map[string]int{"org-1": 12, "org-2": 3}
+	actives := map[string]int{"org-1": 9, "org-2": 1}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations":
+			writeData(w, models.OrganizationListResponse{
+				Total:   2,
+				Results: []models.Organization{{ID: "org-1"}, {ID: "org-2"}},
+			})
+		case "/employees":
+			orgID := r.URL.Query().Get("organizationId")
+			if r.URL.Query().Get("status") == "active" {
+				writeData(w, models.EmployeeListResponse{Total: actives[orgID]})
+				return
+			}
+			writeData(w, models.EmployeeListResponse{Total: totals[orgID]})
+		}
+	})
+
+	orgs, err := NewOrganizationService(c).ListWithEmployeeCounts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, org := range orgs {
+		if org.TotalEmployees != totals[org.ID] || org.ActiveEmployees != actives[org.ID] {
+			t.Errorf("Unexpected stats for %s: total=%d active=%d", org.ID, org.TotalEmployees, org.ActiveEmployees)
+		}
+	}
+}
+
+func TestListWithEmployeeCountsReturnsLookupError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations":
+			writeData(w, models.OrganizationListResponse{Results: []models.Organization{{ID: "org-1"}}})
+		case "/employees":
+			writeError(w, http.StatusForbidden, "forbidden")
+		}
+	})
+
+	if _, err := NewOrganizationService(c).ListWithEmployeeCounts(context.Background(), nil); err == nil {
+		t.Fatal("Expected an error when employee counts can't be fetched")
+	}
+}
+
+func TestCreateUnderRequiresParentOrganization(t *testing.T) {
+	created := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		created++
+		writeData(w, models.CreateOrganizationResponse{Message: "created"})
+	})
+	service := NewOrganizationService(c)
+
+	req := models.CreateOrganizationRequest{
+		Name:            "Acme Branch",
+		Industry:        "tech",
+		BusinessTypeID:  "6f1c1a8e-1b2c-4d3e-8f4a-5b6c7d8e9f00",
+		Address:         "1 Main St",
+		City:            "Dubai",
+		ManagementAlias: "acme.branch",
+		CreditLimit:     1000,
+	}
+
+	_, err := service.CreateUnder(context.Background(), models.Organization{ID: "org-child", OrganizationType: models.OrganizationTypeChild}, req)
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || validationErr.Field != "organizationType" {
+		t.Fatalf("Expected an organizationType validation error, got %v", err)
+	}
+	if created != 0 {
+		t.Errorf("Expected no request for a sub-organization parent, got %d", created)
+	}
+
+	if _, err := service.CreateUnder(context.Background(), models.Organization{ID: "org-parent", OrganizationType: models.OrganizationTypeParent}, req); err != nil {
+		t.Fatalf("Expected no error for a parent organization, got %v", err)
+	}
+	if created != 1 {
+		t.Errorf("Expected 1 create request, got %d", created)
+	}
+}
+
+func TestUpdateCreditLimit(t *testing.T) {
+	var received models.UpdateCreditLimitRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/organizations/org-1/credit-limit" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		writeData(w, models.CreditLimitChange{
+			ID: "chg-1", OrganizationID: "org-1", PreviousLimit: 50000, NewLimit: received.CreditLimit,
+			Reason: received.Reason, ApprovalStatus: models.CreditLimitApproved,
+		})
+	})
+
+	change, err := NewOrganizationService(c).UpdateCreditLimit(context.Background(), "org-1", 75000, "headcount growth")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if received.CreditLimit != 75000 || received.Reason != "headcount growth" {
+		t.Errorf("Unexpected request body %+v", received)
+	}
+	if change.PreviousLimit != 50000 || change.NewLimit != 75000 {
+		t.Errorf("Expected limits 50000 -> 75000, got %v -> %v", change.PreviousLimit, change.NewLimit)
+	}
+}
+
+func TestUpdateCreditLimitRequiresApproval(t *testing.T) {
+	status := models.CreditLimitPending
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if status == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(models.ErrorResponse{
+				StatusCode: http.StatusForbidden,
+				Message:    "credit increases above 100000 need approval",
+				Code:       "APPROVAL_REQUIRED",
+			})
+			return
+		}
+		writeData(w, models.CreditLimitChange{ID: "chg-2", OrganizationID: "org-1", PreviousLimit: 50000, NewLimit: 200000, ApprovalStatus: status})
+	})
+	service := NewOrganizationService(c)
+
+	change, err := service.UpdateCreditLimit(context.Background(), "org-1", 200000, "")
+	var approvalErr *errors.ApprovalRequiredError
+	if !stderrors.As(err, &approvalErr) || approvalErr.ChangeID != "chg-2" || approvalErr.Status != models.CreditLimitPending {
+		t.Fatalf("Expected approval required error for the pending change, got %v", err)
+	}
+	if change == nil || change.ID != "chg-2" {
+		t.Errorf("Expected the pending change record to be returned, got %+v", change)
+	}
+
+	status = ""
+	if _, err := service.UpdateCreditLimit(context.Background(), "org-1", 200000, ""); !stderrors.Is(err, errors.ErrApprovalRequired) {
+		t.Errorf("Expected ErrApprovalRequired from the API error, got %v", err)
+	}
+}
+
+func TestUpdateCreditLimitDecodesChangeFromApprovalError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(models.ErrorResponse{
+			StatusCode: http.StatusForbidden,
+			Message:    "credit increases above 100000 need approval",
+			Code:       "APPROVAL_REQUIRED",
+			Data: models.ErrorData{
+				"id": "chg-3", "organizationId": "org-1", "previousLimit": 50000, "newLimit": 200000,
+				"approvalStatus": models.CreditLimitPending,
+			},
+		})
+	})
+
+	change, err := NewOrganizationService(c).UpdateCreditLimit(context.Background(), "org-1", 200000, "")
+	var approvalErr *errors.ApprovalRequiredError
+	if !stderrors.As(err, &approvalErr) || approvalErr.ChangeID != "chg-3" || approvalErr.Status != models.CreditLimitPending {
+		t.Fatalf("Expected approval required error for the change in the error data, got %v", err)
+	}
+	if !stderrors.Is(err, errors.ErrApprovalRequired) || approvalErr.APIError == nil {
+		t.Errorf("Expected the API error to be kept, got %v", err)
+	}
+	if change == nil || change.ID != "chg-3" || change.NewLimit != 200000 {
+		t.Errorf("Expected the change record to be returned, got %+v", change)
+	}
+}
+
+func TestUpdateCreditLimitRejectsNonPositiveLimit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request for an invalid limit")
+	})
+
+	_, err := NewOrganizationService(c).UpdateCreditLimit(context.Background(), "org-1", 0, "")
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || validationErr.Field != "creditLimit" {
+		t.Errorf("Expected a creditLimit validation error, got %v", err)
+	}
+}
+
+func TestGetUsersOmitsSecrets(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, []models.OrganizationUser{
+			{Username: "acme-admin", Role: "admin", Password: "leaked", MFASecret: "secret"},
+			{Username: "acme-operator", Role: "operator"},
+		})
+	})
+
+	users, err := NewOrganizationService(c).GetUsers(context.Background(), "org-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+	if users[0].Password != "" || users[0].MFASecret != "" {
+		t.Errorf("Expected secrets to be omitted, got %+v", users[0])
+	}
+}
+
+func TestResetUserPassword(t *testing.T) {
+	var resetPath string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			resetPath = r.URL.Path
+			writeData(w, models.ResetPasswordResponse{Username: "acme-support", Password: "n3w-p4ss"})
+			return
+		}
+		// The API omits the role of some users
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"statusCode":200,"data":[
+			{"username":"acme-support","role":"support"},
+			{"username":"acme-legacy","role":"auditor"},
+			{"username":"acme-norole"},
+			{"username":"acme-emptyrole","role":""}
+		]}`))
+	})
+	service := NewOrganizationService(c)
+
+	password, err := service.ResetUserPassword(context.Background(), "org-1", "acme-support")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if password != "n3w-p4ss" || resetPath != "/organizations/org-1/users/acme-support/reset-password" {
+		t.Errorf("Unexpected reset: password %q via %s", password, resetPath)
+	}
+
+	for _, username := range []string{"acme-norole", "acme-emptyrole"} {
+		if _, err := service.ResetUserPassword(context.Background(), "org-1", username); err != nil {
+			t.Errorf("%s: expected a user without a role to be reset, got %v", username, err)
+		}
+		if resetPath != "/organizations/org-1/users/"+username+"/reset-password" {
+			t.Errorf("%s: expected a reset request, got %s", username, resetPath)
+		}
+	}
+
+	var validationErr *errors.ValidationError
+	if _, err := service.ResetUserPassword(context.Background(), "org-1", "acme-legacy"); !stderrors.As(err, &validationErr) || validationErr.Field != "role" {
+		t.Errorf("Expected a role validation error, got %v", err)
+	}
+	if _, err := service.ResetUserPassword(context.Background(), "org-1", "nobody"); !stderrors.As(err, &validationErr) || validationErr.Field != "username" {
+		t.Errorf("Expected a username validation error, got %v", err)
+	}
+}
+
+func TestListWithCreditUtilization(t *testing.T) {
+	const (
+		alpha = "0b8f6a1e-2c3d-4e5f-8a9b-0c1d2e3f4a5b"
+		beta  = "1c9a7b2f-3d4e-4f6a-9b0c-1d2e3f4a5b6c"
+		gamma = "2d0b8c3a-4e5f-4a7b-8c1d-2e3f4a5b6c7d"
+	)
+	outstanding := map[string]float64{alpha: 25000, beta: 60000}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations":
+			writeData(w, models.OrganizationListResponse{
+				Total: 3,
+				Results: []models.Organization{
+					{ID: alpha, CreditLimit: 100000},
+					{ID: beta, CreditLimit: 50000},
+					{ID: gamma, CreditLimit: 10000},
+				},
+			})
+		default:
+			orgID := r.Header.Get("X-Organization-ID")
+			amount, ok := outstanding[orgID]
+			if !ok {
+				writeError(w, http.StatusInternalServerError, "balances unavailable")
+				return
+			}
+			writeData(w, models.OutstandingBalanceListResponse{
+				Summary: models.OutstandingBalanceSummary{TotalOutstanding: models.Amount(amount)},
+			})
+		}
+	})
+
+	views, err := NewOrganizationService(c).ListWithCreditUtilization(context.Background(), nil)
+
+	var lookupErr *errors.LookupError
+	if !stderrors.As(err, &lookupErr) || len(lookupErr.Errors) != 1 || lookupErr.Errors[gamma] == nil {
+		t.Fatalf("Expected a lookup error for the third organization, got %v", err)
+	}
+	if len(views) != 3 {
+		t.Fatalf("Expected 3 organizations, got %d", len(views))
+	}
+	if views[0].Utilized != 25000 || views[0].Available != 75000 || views[0].UtilizationRate != 0.25 {
+		t.Errorf("Unexpected credit view for the first organization: %+v", views[0])
+	}
+	if views[1].Available != -10000 || views[1].UtilizationRate != 1.2 {
+		t.Errorf("Expected the second organization to be over its limit, got %+v", views[1])
+	}
+	if views[2].Error == "" || views[2].Utilized != 0 {
+		t.Errorf("Expected the third organization to report its error, got %+v", views[2])
+	}
+}
+
+func TestSearchPassesTermToServer(t *testing.T) {
+	var searches []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		searches = append(searches, r.URL.Query().Get("search"))
+		writeData(w, models.OrganizationListResponse{
+			Total:   1,
+			Results: []models.Organization{{ID: "org-1", Name: "Tech Solutions"}},
+		})
+	})
+
+	orgs, err := NewOrganizationService(c).Search(context.Background(), "tech", 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(searches) != 1 || searches[0] != "tech" {
+		t.Errorf("Expected a single request with search=tech, got %v", searches)
+	}
+	if len(orgs) != 1 || orgs[0].ID != "org-1" {
+		t.Errorf("Expected org-1, got %+v", orgs)
+	}
+}
+
+func TestSearchFallbackIsCaseInsensitive(t *testing.T) {
+	// The server ignores the search parameter and returns every organization
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.OrganizationListResponse{
+			Total: 4,
+			Results: []models.Organization{
+				{ID: "org-1", Name: "ACME Trading"},
+				{ID: "org-2", Name: "Globex"},
+				{ID: "org-3", Name: "acme logistics"},
+				{ID: "org-4", Name: "Northwind Acme"},
+			},
+		})
+	})
+	service := NewOrganizationService(c)
+
+	orgs, err := service.Search(context.Background(), "Acme", 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var ids []string
+	for _, org := range orgs {
+		ids = append(ids, org.ID)
+	}
+	if len(ids) != 3 || ids[0] != "org-1" || ids[1] != "org-3" || ids[2] != "org-4" {
+		t.Errorf("Expected org-1, org-3 and org-4, got %v", ids)
+	}
+
+	orgs, err = service.Search(context.Background(), "acme", 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(orgs) != 2 {
+		t.Errorf("Expected the limit to cap results at 2, got %d", len(orgs))
+	}
+}
+
+func TestUpdateOrganizationSendsOnlySetFields(t *testing.T) {
+	var gotMethod, gotPath string
+	var body map[string]interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		writeData(w, models.Organization{ID: "org-1", Name: "Tech Solutions", City: "Abu Dhabi", Active: true})
+	})
+
+	name := "Tech Solutions"
+	payrollDay := 25
+	org, err := NewOrganizationService(c).Update(context.Background(), "org-1", models.UpdateOrganizationRequest{
+		Name:            &name,
+		PayrollStartDay: &payrollDay,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotMethod != http.MethodPatch || gotPath != "/organizations/org-1" {
+		t.Errorf("Expected PATCH /organizations/org-1, got %s %s", gotMethod, gotPath)
+	}
+	if len(body) != 2 || body["name"] != "Tech Solutions" || body["payrollStartDay"] != float64(25) {
+		t.Errorf("Expected only name and payrollStartDay to be sent, got %v", body)
+	}
+	if org.ID != "org-1" || org.Name != "Tech Solutions" {
+		t.Errorf("Unexpected organization: %+v", org)
+	}
+}
+
+func TestUpdateOrganizationValidation(t *testing.T) {
+	called := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	service := NewOrganizationService(c)
+
+	var validationErr *errors.ValidationError
+	if _, err := service.Update(context.Background(), "org-1", models.UpdateOrganizationRequest{}); !stderrors.As(err, &validationErr) {
+		t.Errorf("Expected a ValidationError for an empty update, got %v", err)
+	}
+
+	payrollDay := 40
+	if _, err := service.Update(context.Background(), "org-1", models.UpdateOrganizationRequest{PayrollStartDay: &payrollDay}); !stderrors.As(err, &validationErr) {
+		t.Errorf("Expected a ValidationError for an out-of-range payroll day, got %v", err)
+	}
+	if called {
+		t.Error("Expected no request to be sent")
+	}
+}
+
+func TestActivateAndDeactivateOrganization(t *testing.T) {
+	var bodies []map[string]interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		writeData(w, models.Organization{ID: "org-1", Active: body["active"] == true})
+	})
+	service := NewOrganizationService(c)
+
+	org, err := service.Deactivate(context.Background(), "org-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if org.Active {
+		t.Error("Expected the organization to be inactive")
+	}
+
+	org, err = service.Activate(context.Background(), "org-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !org.Active {
+		t.Error("Expected the organization to be active")
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(bodies))
+	}
+	if active, ok := bodies[0]["active"]; !ok || active != false || len(bodies[0]) != 1 {
+		t.Errorf("Expected deactivate to send only active=false, got %v", bodies[0])
+	}
+	if bodies[1]["active"] != true || len(bodies[1]) != 1 {
+		t.Errorf("Expected activate to send only active=true, got %v", bodies[1])
+	}
+}
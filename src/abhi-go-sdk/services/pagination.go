@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PageFetcher fetches one page of results, numbered from 1, and reports whether more pages follow
+type PageFetcher[T any] func(ctx context.Context, page int) (items []T, more bool, err error)
+
+// CollectUntil fetches pages in order until there are no more, the predicate reports that the
+// items collected so far are enough, or maxItems have been collected. Results past maxItems are
+// dropped; zero or a negative maxItems means no cap. A nil predicate never stops early.
+func CollectUntil[T any](ctx context.Context, fetchPage PageFetcher[T], predicate func(collected []T) (stop bool), maxItems int) ([]T, error) {
+	var collected []T
+
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		items, more, err := fetchPage(ctx, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+
+		collected = append(collected, items...)
+		if maxItems > 0 && len(collected) >= maxItems {
+			return collected[:maxItems], nil
+		}
+		if !more || (predicate != nil && predicate(collected)) {
+			return collected, nil
+		}
+	}
+}
+
+// TotalPageFetcher fetches one page of results, numbered from 1, along with the total number of
+// results across all pages as reported by the API
+type TotalPageFetcher[T any] func(ctx context.Context, page int) (items []T, total int, err error)
+
+// CollectAll fetches every page of pageSize results. The first page is fetched alone; the total
+// it reports determines the remaining pages, which are fetched with up to concurrency requests in
+// flight and returned in page order. Paging then continues one page at a time while pages come
+// back full, which covers an API that reports no total or results added since the first page.
+// The first failed page cancels the others and its error is returned, rather than the
+// cancellation errors of pages that were still in flight. Fetchers that call the API still wait
+// on the client's rate limiter for every page. A concurrency of one or less fetches the pages
+// one at a time.
+func CollectAll[T any](ctx context.Context, fetchPage TotalPageFetcher[T], pageSize, concurrency int) ([]T, error) {
+	first, total, err := fetchPage(ctx, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page 1: %w", err)
+	}
+
+	pages := [][]T{first}
+	if pageSize > 0 && len(first) == pageSize && total > pageSize {
+		pageCount := (total + pageSize - 1) / pageSize
+
+		fetchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Only the first failure is kept; pages in flight then fail with the cancellation
+		var failOnce sync.Once
+		var failedPage int
+		var failErr error
+
+		results, err := collectBatch(fetchCtx, pageCount-1, BatchOpts{Concurrency: concurrency}, func(ctx context.Context, index int) ([]T, error) {
+			items, _, err := fetchPage(ctx, index+2)
+			if err != nil {
+				failOnce.Do(func() {
+					failedPage, failErr = index+2, err
+					cancel()
+				})
+			}
+			return items, err
+		})
+		if failErr != nil {
+			return nil, fmt.Errorf("failed to fetch page %d: %w", failedPage, failErr)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			pages = append(pages, result.Value)
+		}
+	}
+
+	for pageSize > 0 && len(pages[len(pages)-1]) == pageSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		page := len(pages) + 1
+		items, _, err := fetchPage(ctx, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+		pages = append(pages, items)
+	}
+
+	var collected []T
+	for _, items := range pages {
+		collected = append(collected, items...)
+	}
+	return collected, nil
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/models"
+)
+
+func TestCollectUntilStopsOnPredicate(t *testing.T) {
+	var fetched []int
+	fetchPage := func(ctx context.Context, page int) ([]int, bool, error) {
+		fetched = append(fetched, page)
+		return []int{page*10 + 1, page*10 + 2}, true, nil
+	}
+
+	// Stop once an item from page 2 has been seen
+	items, err := CollectUntil(context.Background(), fetchPage, func(collected []int) bool {
+		return collected[len(collected)-1] > 20
+	}, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(fetched) != 2 {
+		t.Errorf("Expected to stop after page 2, fetched %v", fetched)
+	}
+	if len(items) != 4 || items[3] != 22 {
+		t.Errorf("Expected the items of both pages, got %v", items)
+	}
+}
+
+func TestCollectUntilCapsItems(t *testing.T) {
+	var fetched int
+	fetchPage := func(ctx context.Context, page int) ([]int, bool, error) {
+		fetched++
+		return []int{1, 2, 3}, true, nil
+	}
+
+	items, err := CollectUntil(context.Background(), fetchPage, nil, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 5 {
+		t.Errorf("Expected 5 items, got %d", len(items))
+	}
+	if fetched != 2 {
+		t.Errorf("Expected 2 pages to be fetched, got %d", fetched)
+	}
+}
+
+func TestFindTransactionsOver(t *testing.T) {
+	var pages []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, r.URL.Query().Get("page"))
+		if r.URL.Query().Get("status") != "completed" {
+			t.Error("Expected the status filter to be forwarded")
+		}
+
+		results := make([]models.EmployerTransaction, 100)
+		for i := range results {
+			results[i] = models.EmployerTransaction{ID: strconv.Itoa(page*1000 + i), Amount: 100}
+		}
+		results[50].Amount = 5000
+		writeData(w, models.EmployerTransactionResponse{Results: results})
+	})
+
+	transactions, err := NewTransactionService(c).FindTransactionsOver(context.Background(), 1000,
+		&models.EmployerTransactionListOptions{Status: "completed"}, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(transactions) != 2 || transactions[0].ID != "1050" || transactions[1].ID != "2050" {
+		t.Errorf("Expected the large transaction from each of the first two pages, got %+v", transactions)
+	}
+	if len(pages) != 2 {
+		t.Errorf("Expected to stop after 2 pages, fetched %v", pages)
+	}
+}
+
+func TestCollectAllFetchesPagesConcurrentlyInOrder(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	fetched := make(map[int]int)
+
+	fetchPage := func(ctx context.Context, page int) ([]int, int, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+
+		mu.Lock()
+		fetched[page]++
+		mu.Unlock()
+
+		// Later pages answer first, so results arrive out of order
+		time.Sleep(time.Duration(6-page) * 10 * time.Millisecond)
+
+		var items []int
+		for i := 1; i <= 3 && (page-1)*3+i <= 13; i++ {
+			items = append(items, (page-1)*3+i)
+		}
+		return items, 13, nil
+	}
+
+	items, err := CollectAll(context.Background(), fetchPage, 3, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 13 {
+		t.Fatalf("Expected 13 items, got %v", items)
+	}
+	for i, item := range items {
+		if item != i+1 {
+			t.Fatalf("Expected items in page order, got %v", items)
+		}
+	}
+	for page := 1; page <= 5; page++ {
+		if fetched[page] != 1 {
+			t.Errorf("Expected page %d to be fetched once, fetched %d times", page, fetched[page])
+		}
+	}
+	if len(fetched) != 5 {
+		t.Errorf("Expected exactly 5 pages to be fetched, got %v", fetched)
+	}
+	if maxInFlight < 2 || maxInFlight > 3 {
+		t.Errorf("Expected between 2 and 3 pages in flight, got %d", maxInFlight)
+	}
+}
+
+func TestCollectAllReturnsFailedPage(t *testing.T) {
+	errPage := stderrors.New("page unavailable")
+	fetchPage := func(ctx context.Context, page int) ([]int, int, error) {
+		if page == 3 {
+			return nil, 0, errPage
+		}
+		return []int{page, page}, 10, nil
+	}
+
+	_, err := CollectAll(context.Background(), fetchPage, 2, 4)
+	if !stderrors.Is(err, errPage) {
+		t.Fatalf("Expected the page error, got %v", err)
+	}
+	if err.Error() != "failed to fetch page 3: page unavailable" {
+		t.Errorf("Expected the failed page to be named, got %v", err)
+	}
+
+	// A later page fails while earlier pages are still in flight; they then fail with the
+	// cancellation, which must not mask the actual failure
+	fetchPage = func(ctx context.Context, page int) ([]int, int, error) {
+		switch {
+		case page == 4:
+			return nil, 0, errPage
+		case page > 1:
+			select {
+			case <-ctx.Done():
+				return nil, 0, ctx.Err()
+			case <-time.After(time.Second):
+				return []int{page, page}, 10, nil
+			}
+		}
+		return []int{page, page}, 10, nil
+	}
+
+	_, err = CollectAll(context.Background(), fetchPage, 2, 4)
+	if !stderrors.Is(err, errPage) {
+		t.Fatalf("Expected the page error rather than the cancellation, got %v", err)
+	}
+	if err.Error() != "failed to fetch page 4: page unavailable" {
+		t.Errorf("Expected the failed page to be named, got %v", err)
+	}
+}
+
+func TestCollectAllPagesSequentiallyWithoutTotal(t *testing.T) {
+	var fetched []int
+	fetchPage := func(ctx context.Context, page int) ([]int, int, error) {
+		fetched = append(fetched, page)
+		if page == 3 {
+			return []int{5}, 0, nil
+		}
+		return []int{page*2 - 1, page * 2}, 0, nil
+	}
+
+	items, err := CollectAll(context.Background(), fetchPage, 2, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 5 || len(fetched) != 3 {
+		t.Errorf("Expected 5 items from 3 pages, got %v from pages %v", items, fetched)
+	}
+}
+
+func TestGetAllEmployerTransactionsWithPageConcurrency(t *testing.T) {
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if r.URL.Query().Get("status") != "completed" {
+			t.Error("Expected the status filter to be forwarded")
+		}
+		time.Sleep(time.Duration(6-page) * 5 * time.Millisecond)
+
+		var results []models.EmployerTransaction
+		for i := (page-1)*100 + 1; i <= page*100 && i <= 450; i++ {
+			results = append(results, models.EmployerTransaction{ID: fmt.Sprintf("txn-%d", i)})
+		}
+		writeData(w, models.EmployerTransactionResponse{Total: 450, Results: results})
+	})
+
+	transactions, err := NewTransactionService(c).WithPageConcurrency(3).
+		GetAllEmployerTransactions(context.Background(), &models.EmployerTransactionListOptions{Status: "completed"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(transactions) != 450 {
+		t.Fatalf("Expected 450 transactions, got %d", len(transactions))
+	}
+	for i, transaction := range transactions {
+		if expected := fmt.Sprintf("txn-%d", i+1); transaction.ID != expected {
+			t.Fatalf("Expected %s at position %d, got %s", expected, i, transaction.ID)
+		}
+	}
+	if requests != 5 {
+		t.Errorf("Expected 5 page requests, got %d", requests)
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// PollOptions configures polling helpers. Intervals start at Interval, grow by Multiplier
+// after every poll up to MaxInterval, and are randomly shortened by up to Jitter so that
+// many concurrent waiters don't poll the API in lockstep.
+type PollOptions struct {
+	Interval    time.Duration // Initial delay between polls (default 2s)
+	MaxInterval time.Duration // Upper bound for the delay between polls (default 30s)
+	Multiplier  float64       // Growth factor applied after each poll (default 1.5, use 1 for a fixed interval)
+	Jitter      float64       // Fraction of each delay to randomize, 0-1 (default 0.2, negative disables)
+	Timeout     time.Duration // Overall time limit; zero relies on the context alone
+}
+
+// DefaultPollOptions returns the default polling configuration
+func DefaultPollOptions() PollOptions {
+	return PollOptions{
+		Interval:    2 * time.Second,
+		MaxInterval: 30 * time.Second,
+		Multiplier:  1.5,
+		Jitter:      0.2,
+	}
+}
+
+// withDefaults fills unset options with their defaults
+func (o PollOptions) withDefaults() PollOptions {
+	defaults := DefaultPollOptions()
+	if o.Interval <= 0 {
+		o.Interval = defaults.Interval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaults.MaxInterval
+	}
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = o.Interval
+	}
+	if o.Multiplier <= 0 {
+		o.Multiplier = defaults.Multiplier
+	}
+	if o.Jitter == 0 {
+		o.Jitter = defaults.Jitter
+	}
+	if o.Jitter < 0 {
+		o.Jitter = 0
+	}
+	if o.Jitter > 1 {
+		o.Jitter = 1
+	}
+	return o
+}
+
+// pollBackoff computes successive jittered, exponentially growing poll delays
+type pollBackoff struct {
+	opts    PollOptions
+	current time.Duration
+	rng     *rand.Rand
+}
+
+func newPollBackoff(opts PollOptions, rng *rand.Rand) *pollBackoff {
+	opts = opts.withDefaults()
+	return &pollBackoff{
+		opts:    opts,
+		current: opts.Interval,
+		rng:     rng,
+	}
+}
+
+// next returns the delay before the next poll and advances the backoff
+func (b *pollBackoff) next() time.Duration {
+	delay := b.current
+	if b.opts.Jitter > 0 {
+		delay -= time.Duration(b.opts.Jitter * b.rng.Float64() * float64(delay))
+	}
+
+	grown := time.Duration(float64(b.current) * b.opts.Multiplier)
+	if grown > b.opts.MaxInterval {
+		grown = b.opts.MaxInterval
+	}
+	b.current = grown
+
+	return delay
+}
+
+// Poll calls check until it reports done or returns an error, the timeout elapses or the
+// context is cancelled. The first check happens immediately.
+func Poll(ctx context.Context, opts PollOptions, check func(ctx context.Context) (bool, error)) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	backoff := newPollBackoff(opts, rand.New(rand.NewSource(time.Now().UnixNano())))
+
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(backoff.next())
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
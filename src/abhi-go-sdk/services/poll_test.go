@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestPollBackoffIntervalsVaryAndGrow(t *testing.T) {
+	opts := PollOptions{
+		Interval:    100 * time.Millisecond,
+		MaxInterval: time.Second,
+		Multiplier:  2,
+		Jitter:      0.5,
+	}
+	backoff := newPollBackoff(opts, rand.New(rand.NewSource(1)))
+
+	base := opts.Interval
+	var delays []time.Duration
+	for i := 0; i < 8; i++ {
+		delay := backoff.next()
+		delays = append(delays, delay)
+
+		// Jitter only shortens the delay, by at most half of the base
+		if delay > base || delay < base/2 {
+			t.Errorf("Poll %d: delay %v outside jittered bounds [%v, %v]", i, delay, base/2, base)
+		}
+
+		base *= 2
+		if base > opts.MaxInterval {
+			base = opts.MaxInterval
+		}
+	}
+
+	if delays[3] <= delays[0] {
+		t.Errorf("Expected delays to grow, got %v", delays)
+	}
+
+	// Once capped the base is constant, so any difference comes from jitter
+	capped := delays[4:]
+	varied := false
+	for _, delay := range capped[1:] {
+		if delay != capped[0] {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Errorf("Expected jittered delays to vary, got %v", capped)
+	}
+}
+
+func TestPollBackoffWithoutJitter(t *testing.T) {
+	backoff := newPollBackoff(PollOptions{Interval: 10 * time.Millisecond, Multiplier: 1, Jitter: -1}, rand.New(rand.NewSource(1)))
+
+	for i := 0; i < 3; i++ {
+		if delay := backoff.next(); delay != 10*time.Millisecond {
+			t.Errorf("Expected fixed 10ms delay, got %v", delay)
+		}
+	}
+}
+
+func TestPollStopsWhenDone(t *testing.T) {
+	calls := 0
+	err := Poll(context.Background(), PollOptions{Interval: time.Millisecond}, func(ctx context.Context) (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 checks, got %d", calls)
+	}
+}
+
+func TestPollTimeout(t *testing.T) {
+	start := time.Now()
+	err := Poll(context.Background(), PollOptions{Interval: 5 * time.Millisecond, Timeout: 50 * time.Millisecond}, func(ctx context.Context) (bool, error) {
+		return false, nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected poll to stop near the timeout, took %v", elapsed)
+	}
+}
+
+func TestPollContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+
+	err := Poll(ctx, PollOptions{Interval: time.Hour}, func(ctx context.Context) (bool, error) {
+		calls++
+		cancel()
+		return false, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 check, got %d", calls)
+	}
+}
@@ -2,17 +2,22 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
 	"abhi-go-sdk/models"
 )
 
 // RepaymentService handles repayment-related API operations
 type RepaymentService struct {
-	client *client.Client
+	client          *client.Client
+	pageConcurrency int
 }
 
 // NewRepaymentService creates a new repayment service
@@ -22,14 +27,178 @@ func NewRepaymentService(client *client.Client) *RepaymentService {
 	}
 }
 
-// Create creates a new repayment
+// WithPageConcurrency makes methods listing every repayment fetch offset pages with up to workers
+// in flight; see CollectAll. Cursor-paged results are still followed one page at a time.
+func (s *RepaymentService) WithPageConcurrency(workers int) *RepaymentService {
+	s.pageConcurrency = workers
+	return s
+}
+
+// Create creates a new repayment. A non-empty req.IdempotencyKey is sent as the Idempotency-Key
+// header so a retried request can't record the repayment twice.
 func (s *RepaymentService) Create(ctx context.Context, req models.CreateRepaymentRequest) (*models.RepaymentResponse, error) {
+	if currency := s.client.Currency(); currency != nil {
+		req.Amount = models.Money(req.Amount).RoundTo(*currency).Float64()
+	}
+
 	var result models.RepaymentResponse
-	err := s.client.POST(ctx, "/repayments", req, &result)
+	err := s.client.POSTIdempotent(ctx, "/repayments", req, &result, req.IdempotencyKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repayment: %w", err)
 	}
 
+	s.client.EmitEvent(ctx, client.LifecycleEvent{
+		Type:       client.EventRepaymentCreated,
+		ResourceID: result.Repayment.ID,
+		Amount:     req.Amount,
+	})
+
+	return &result, nil
+}
+
+// repaymentIdempotencyKeyPrefix namespaces Idempotency-Key values derived from reference numbers
+const repaymentIdempotencyKeyPrefix = "repayment-"
+
+// CreateIdempotent creates a repayment unless one with the same ClientRepaymentReferenceNumber
+// already exists, in which case the existing repayment is returned and nothing is created. When
+// req.IdempotencyKey is empty, a key derived from the reference number is sent so retries of the
+// create itself can't record the repayment twice either.
+func (s *RepaymentService) CreateIdempotent(ctx context.Context, req models.CreateRepaymentRequest) (*models.RepaymentResponse, error) {
+	reference := strings.TrimSpace(req.ClientRepaymentReferenceNumber)
+	if reference == "" {
+		return nil, &errors.ValidationError{
+			Field:   "clientRepaymentReferenceNumber",
+			Message: "a reference number is required to detect duplicate repayments",
+		}
+	}
+
+	existing, err := s.GetRepaymentByReference(ctx, reference)
+	if err == nil {
+		return &models.RepaymentResponse{
+			Repayment: *existing,
+			Message:   "Repayment already exists",
+			Status:    existing.Status,
+		}, nil
+	}
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check for an existing repayment: %w", err)
+	}
+
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = repaymentIdempotencyKeyPrefix + reference
+	}
+
+	return s.Create(ctx, req)
+}
+
+// CreateSchedule splits total into the given number of monthly installments for an employee and
+// creates a repayment for each, the first due on firstDueDate (YYYY-MM-DD). Installments are equal
+// in the client currency's precision and rounding increment (AED when unset), with any remainder
+// added to the last, so they sum exactly to total. Each installment gets a reference number
+// derived from the employee, first due date and installment number, also used as its
+// Idempotency-Key, so rerunning a failed schedule doesn't duplicate installments. If an
+// installment fails, the repayments created so far are returned with the error.
+func (s *RepaymentService) CreateSchedule(ctx context.Context, employeeID string, total float64, installments int, firstDueDate string) ([]models.RepaymentResponse, error) {
+	if total <= 0 {
+		return nil, &errors.ValidationError{
+			Field:   "total",
+			Message: "total must be greater than 0",
+			Value:   strconv.FormatFloat(total, 'f', -1, 64),
+		}
+	}
+	if installments <= 0 {
+		return nil, &errors.ValidationError{
+			Field:   "installments",
+			Message: "installments must be greater than 0",
+			Value:   strconv.Itoa(installments),
+		}
+	}
+	firstDue, err := time.Parse("2006-01-02", firstDueDate)
+	if err != nil {
+		return nil, &errors.ValidationError{
+			Field:   "firstDueDate",
+			Message: "first due date must be in YYYY-MM-DD format",
+			Value:   firstDueDate,
+		}
+	}
+
+	currency := models.DefaultCurrency
+	if configured := s.client.Currency(); configured != nil {
+		currency = *configured
+	}
+
+	// Split in whole rounding increments so Create's rounding leaves every installment unchanged
+	increment := currency.RoundingIncrement
+	if increment < 1 {
+		increment = 1
+	}
+	totalSteps := models.Money(total).MinorUnits(currency) / increment
+	installmentSteps := totalSteps / int64(installments)
+	if installmentSteps <= 0 {
+		return nil, &errors.ValidationError{
+			Field:   "installments",
+			Message: fmt.Sprintf("%d installments would be smaller than the currency's precision", installments),
+			Value:   strconv.Itoa(installments),
+		}
+	}
+
+	results := make([]models.RepaymentResponse, 0, installments)
+	for i := 0; i < installments; i++ {
+		steps := installmentSteps
+		if i == installments-1 {
+			steps = totalSteps - installmentSteps*int64(installments-1)
+		}
+
+		reference := fmt.Sprintf("SCHED-%s-%s-%d", employeeID, firstDue.Format("20060102"), i+1)
+		req := models.CreateRepaymentRequest{
+			Amount:                         models.MoneyFromMinorUnits(steps*increment, currency).Float64(),
+			ClientRepaymentReferenceNumber: reference,
+			EmployeeID:                     employeeID,
+			Description:                    fmt.Sprintf("Installment %d of %d", i+1, installments),
+			DueDate:                        addMonthsClamped(firstDue, i).Format("2006-01-02"),
+			IdempotencyKey:                 repaymentIdempotencyKeyPrefix + reference,
+		}
+
+		result, err := s.Create(ctx, req)
+		if err != nil {
+			return results, fmt.Errorf("failed to create installment %d of %d: %w", i+1, installments, err)
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// addMonthsClamped adds months to t, clamping the day to the end of shorter months so that an
+// installment due on the 31st falls on the 30th or 28th rather than spilling into the next month
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// Cancel cancels a pending repayment that was created in error and returns the updated
+// repayment. A repayment that has already completed is rejected with a 409 APIError.
+func (s *RepaymentService) Cancel(ctx context.Context, repaymentID string, reason string) (*models.RepaymentResponse, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, &errors.ValidationError{
+			Field:   "reason",
+			Message: "a cancellation reason is required",
+		}
+	}
+
+	endpoint := fmt.Sprintf("/repayments/%s/cancel", repaymentID)
+
+	var result models.RepaymentResponse
+	err := s.client.POST(ctx, endpoint, models.CancelRepaymentRequest{Reason: reason}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel repayment %s: %w", repaymentID, err)
+	}
+
 	return &result, nil
 }
 
@@ -70,14 +239,55 @@ func (s *RepaymentService) GetOutstandingBalance(ctx context.Context, opts *mode
 		return nil, fmt.Errorf("failed to get outstanding balance: %w", err)
 	}
 
+	s.mergeDuplicateBalances(&result)
+
 	return &result, nil
 }
 
+// mergeDuplicateBalances merges duplicate employee records in the response and
+// records a warning for each affected employee
+func (s *RepaymentService) mergeDuplicateBalances(result *models.OutstandingBalanceListResponse) {
+	counts := make(map[string]int)
+	for _, balance := range result.Results {
+		if balance.EmployeeID != "" {
+			counts[balance.EmployeeID]++
+		}
+	}
+
+	merged := models.DeduplicateBalances(result.Results)
+	if len(merged) == len(result.Results) {
+		return
+	}
+
+	for _, balance := range merged {
+		if count := counts[balance.EmployeeID]; count > 1 && balance.EmployeeID != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("employee %s appeared %d times in outstanding balance results; records were merged", balance.EmployeeID, count))
+		}
+	}
+
+	result.Results = merged
+}
+
 // GetEmployeeOutstandingBalance retrieves outstanding balance for a specific employee
 func (s *RepaymentService) GetEmployeeOutstandingBalance(ctx context.Context, employeeID string) (*models.OutstandingBalance, error) {
+	balance, err := s.findEmployeeBalance(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if balance == nil {
+		return nil, fmt.Errorf("no outstanding balance found for employee %s", employeeID)
+	}
+
+	return balance, nil
+}
+
+// findEmployeeBalance returns the outstanding balance record of an employee, or nil if the API
+// returns none
+func (s *RepaymentService) findEmployeeBalance(ctx context.Context, employeeID string) (*models.OutstandingBalance, error) {
+	// Request more than one record so duplicates can be merged
 	opts := &models.OutstandingBalanceListOptions{
 		EmployeeID: employeeID,
-		Limit:      1,
+		Limit:      10,
 	}
 
 	result, err := s.GetOutstandingBalance(ctx, opts)
@@ -85,19 +295,50 @@ func (s *RepaymentService) GetEmployeeOutstandingBalance(ctx context.Context, em
 		return nil, fmt.Errorf("failed to get employee outstanding balance: %w", err)
 	}
 
+	for i := range result.Results {
+		if result.Results[i].EmployeeID == employeeID {
+			return &result.Results[i], nil
+		}
+	}
+
 	if len(result.Results) == 0 {
-		return nil, fmt.Errorf("no outstanding balance found for employee %s", employeeID)
+		return nil, nil
 	}
 
 	return &result.Results[0], nil
 }
 
+// GetNextInstallment returns the next due date and amount for an employee, derived from their
+// outstanding schedule. It returns errors.ErrFullyPaid if nothing is outstanding, including when
+// the API has no balance record for the employee.
+func (s *RepaymentService) GetNextInstallment(ctx context.Context, employeeID string) (*models.Installment, error) {
+	balance, err := s.findEmployeeBalance(ctx, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next installment: %w", err)
+	}
+	if balance == nil {
+		return nil, errors.ErrFullyPaid
+	}
+
+	installment, err := balance.NextInstallment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next installment: %w", err)
+	}
+	if installment == nil {
+		return nil, errors.ErrFullyPaid
+	}
+
+	return installment, nil
+}
+
 // ListRepayments retrieves a paginated list of repayments
 func (s *RepaymentService) ListRepayments(ctx context.Context, opts *models.RepaymentListOptions) (*models.RepaymentListResponse, error) {
 	query := url.Values{}
 	
 	if opts != nil {
-		if opts.Page > 0 {
+		if opts.Cursor != "" {
+			query.Set("cursor", opts.Cursor)
+		} else if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
 		}
 		if opts.Limit > 0 {
@@ -124,6 +365,9 @@ func (s *RepaymentService) ListRepayments(ctx context.Context, opts *models.Repa
 		if opts.MaxAmount > 0 {
 			query.Set("maxAmount", strconv.FormatFloat(opts.MaxAmount, 'f', 2, 64))
 		}
+		if opts.PaymentMethod != "" {
+			query.Set("paymentMethod", opts.PaymentMethod)
+		}
 	}
 
 	var result models.RepaymentListResponse
@@ -161,37 +405,142 @@ func (s *RepaymentService) GetRepaymentByReference(ctx context.Context, referenc
 	}
 
 	if len(result.Results) == 0 {
-		return nil, fmt.Errorf("repayment with reference %s not found", referenceNumber)
+		return nil, fmt.Errorf("repayment with reference %s: %w", referenceNumber, errors.ErrNotFound)
 	}
 
 	return &result.Results[0], nil
 }
 
+// GetByReferences resolves many client reference numbers at once, returning the found repayments
+// keyed by reference. The API has no batch lookup, so references are de-duplicated and fetched
+// concurrently, bounded by lookupConcurrency and the client's rate limiter. If any reference is
+// missing or fails, including references skipped because ctx was cancelled, the found repayments
+// are returned along with an *errors.LookupError.
+func (s *RepaymentService) GetByReferences(ctx context.Context, refs []string) (map[string]models.Repayment, error) {
+	seen := make(map[string]bool, len(refs))
+	var unique []string
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		unique = append(unique, ref)
+	}
+
+	outcomes, _ := collectBatch(ctx, len(unique), BatchOpts{Concurrency: lookupConcurrency}, func(ctx context.Context, index int) (*models.Repayment, error) {
+		result, err := s.ListRepayments(ctx, &models.RepaymentListOptions{
+			ClientRepaymentReferenceNumber: unique[index],
+			Limit:                          1,
+		})
+		if err != nil || len(result.Results) == 0 {
+			return nil, err
+		}
+		return &result.Results[0], nil
+	})
+
+	repayments := make(map[string]models.Repayment, len(unique))
+	lookupErr := errors.NewLookupError(len(unique))
+	for i, outcome := range outcomes {
+		ref := unique[i]
+		switch {
+		case !outcome.Done:
+			lookupErr.Errors[ref] = ctx.Err()
+		case outcome.Err != nil:
+			lookupErr.Errors[ref] = outcome.Err
+		case outcome.Value == nil:
+			lookupErr.NotFound = append(lookupErr.NotFound, ref)
+		default:
+			repayments[ref] = *outcome.Value
+		}
+	}
+
+	if lookupErr.HasErrors() {
+		return repayments, lookupErr
+	}
+
+	return repayments, nil
+}
+
 // GetEmployeeRepayments retrieves all repayments for a specific employee
 func (s *RepaymentService) GetEmployeeRepayments(ctx context.Context, employeeID string) ([]models.Repayment, error) {
+	allRepayments, err := s.listAllRepayments(ctx, models.RepaymentListOptions{
+		EmployeeID: employeeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee repayments: %w", err)
+	}
+
+	return allRepayments, nil
+}
+
+// listAllRepayments pages through all repayments matching the given filters. Cursor-based
+// iteration is preferred when the server returns a nextCursor, otherwise offset paging is used,
+// concurrently when WithPageConcurrency is set.
+func (s *RepaymentService) listAllRepayments(ctx context.Context, filters models.RepaymentListOptions) ([]models.Repayment, error) {
 	var allRepayments []models.Repayment
 	page := 1
 	limit := 100
+	cursor := ""
+
+	if s.pageConcurrency > 1 {
+		opts := filters
+		opts.Page = 1
+		opts.Limit = limit
+		first, err := s.ListRepayments(ctx, &opts)
+		if err != nil {
+			return nil, fmt.Errorf("page 1: %w", err)
+		}
+
+		if first.NextCursor == "" {
+			return CollectAll(ctx, func(ctx context.Context, page int) ([]models.Repayment, int, error) {
+				if page == 1 {
+					return first.Results, first.Total, nil
+				}
+				opts := filters
+				opts.Page = page
+				opts.Limit = limit
+				response, err := s.ListRepayments(ctx, &opts)
+				if err != nil {
+					return nil, 0, err
+				}
+				return response.Results, response.Total, nil
+			}, limit, s.pageConcurrency)
+		}
+
+		// A cursor can only be followed one page at a time
+		allRepayments = first.Results
+		cursor = first.NextCursor
+		page = 2
+	}
 
 	for {
-		opts := &models.RepaymentListOptions{
-			EmployeeID: employeeID,
-			Page:       page,
-			Limit:      limit,
+		opts := filters
+		opts.Limit = limit
+		if cursor != "" {
+			opts.Cursor = cursor
+		} else {
+			opts.Page = page
 		}
 
-		response, err := s.ListRepayments(ctx, opts)
+		response, err := s.ListRepayments(ctx, &opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get employee repayments page %d: %w", page, err)
+			return nil, fmt.Errorf("page %d: %w", page, err)
 		}
 
 		allRepayments = append(allRepayments, response.Results...)
+		page++
 
-		// Check if we have more pages
-		if len(response.Results) < limit {
+		// Follow the server cursor when provided
+		if response.NextCursor != "" {
+			cursor = response.NextCursor
+			continue
+		}
+
+		// Once in cursor mode an empty cursor marks the end of the stream
+		if cursor != "" || len(response.Results) < limit {
 			break
 		}
-		page++
 	}
 
 	return allRepayments, nil
@@ -214,30 +563,24 @@ func (s *RepaymentService) GetOverdueBalances(ctx context.Context) ([]models.Out
 
 // GetRepaymentsByDateRange retrieves repayments within a date range
 func (s *RepaymentService) GetRepaymentsByDateRange(ctx context.Context, startDate, endDate string) ([]models.Repayment, error) {
-	var allRepayments []models.Repayment
-	page := 1
-	limit := 100
-
-	for {
-		opts := &models.RepaymentListOptions{
-			StartDate: startDate,
-			EndDate:   endDate,
-			Page:      page,
-			Limit:     limit,
-		}
-
-		response, err := s.ListRepayments(ctx, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get repayments for date range %s to %s page %d: %w", startDate, endDate, page, err)
-		}
+	allRepayments, err := s.listAllRepayments(ctx, models.RepaymentListOptions{
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repayments for date range %s to %s: %w", startDate, endDate, err)
+	}
 
-		allRepayments = append(allRepayments, response.Results...)
+	return allRepayments, nil
+}
 
-		// Check if we have more pages
-		if len(response.Results) < limit {
-			break
-		}
-		page++
+// GetRepaymentsByPaymentMethod retrieves all repayments made with the given payment method
+func (s *RepaymentService) GetRepaymentsByPaymentMethod(ctx context.Context, paymentMethod string) ([]models.Repayment, error) {
+	allRepayments, err := s.listAllRepayments(ctx, models.RepaymentListOptions{
+		PaymentMethod: paymentMethod,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repayments with payment method %s: %w", paymentMethod, err)
 	}
 
 	return allRepayments, nil
@@ -312,6 +655,77 @@ func (s *RepaymentService) ValidateRepayment(req models.CreateRepaymentRequest)
 	return nil
 }
 
+// GetCollectionRate computes how the advances due between from and to were repaid, combining
+// employer transactions, repayments and outstanding balances
+func (s *RepaymentService) GetCollectionRate(ctx context.Context, from, to time.Time) (*models.CollectionRate, error) {
+	if to.Before(from) {
+		return nil, &errors.ValidationError{
+			Field:   "to",
+			Message: "End of period must not be before its start",
+		}
+	}
+
+	advances, err := NewTransactionService(s.client).GetAllEmployerTransactions(ctx, &models.EmployerTransactionListOptions{
+		Type: "advance",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advances for collection rate: %w", err)
+	}
+
+	// Repayments can't predate the advances they settle
+	filters := models.RepaymentListOptions{}
+	for _, advance := range advances {
+		if len(advance.RequestedAt) >= len(models.DateLayout) {
+			requested := advance.RequestedAt[:len(models.DateLayout)]
+			if filters.StartDate == "" || requested < filters.StartDate {
+				filters.StartDate = requested
+			}
+		}
+	}
+
+	repayments, err := s.listAllRepayments(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repayments for collection rate: %w", err)
+	}
+
+	balances, err := s.listAllOutstandingBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outstanding balances for collection rate: %w", err)
+	}
+
+	currency := models.DefaultCurrency
+	if configured := s.client.Currency(); configured != nil {
+		currency = *configured
+	}
+
+	return models.ComputeCollectionRate(from, to, advances, repayments, balances, currency)
+}
+
+// listAllOutstandingBalances pages through every outstanding balance. Duplicates are merged
+// within each page, so a page may come back short; the reported total decides whether more
+// follow, and records split across pages are merged at the end.
+func (s *RepaymentService) listAllOutstandingBalances(ctx context.Context) ([]models.OutstandingBalance, error) {
+	limit := 100
+	balances, err := CollectUntil(ctx, func(ctx context.Context, page int) ([]models.OutstandingBalance, bool, error) {
+		response, err := s.GetOutstandingBalance(ctx, &models.OutstandingBalanceListOptions{
+			Page:  page,
+			Limit: limit,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		if response.Total > 0 {
+			return response.Results, page*limit < response.Total, nil
+		}
+		return response.Results, len(response.Results) == limit, nil
+	}, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.DeduplicateBalances(balances), nil
+}
+
 // GetOutstandingBalanceSummary returns summary statistics for outstanding balances
 func (s *RepaymentService) GetOutstandingBalanceSummary(ctx context.Context) (*models.OutstandingBalanceSummary, error) {
 	result, err := s.GetOutstandingBalance(ctx, &models.OutstandingBalanceListOptions{
@@ -322,4 +736,58 @@ func (s *RepaymentService) GetOutstandingBalanceSummary(ctx context.Context) (*m
 	}
 
 	return &result.Summary, nil
+}
+
+// GetOutstandingBalanceSummaryByDepartment returns outstanding balance summary statistics for each
+// department, keyed by department name; employees without a department are grouped under "".
+// Every page of balances is fetched and duplicate employee records merged before aggregating,
+// with amounts summed in the client currency's minor units (AED when unset).
+func (s *RepaymentService) GetOutstandingBalanceSummaryByDepartment(ctx context.Context) (map[string]models.OutstandingBalanceSummary, error) {
+	currency := models.DefaultCurrency
+	if configured := s.client.Currency(); configured != nil {
+		currency = *configured
+	}
+
+	type departmentTotals struct {
+		employees   int
+		withOverdue int
+		outstanding int64
+		overdue     int64
+	}
+	totals := make(map[string]*departmentTotals)
+
+	balances, err := s.listAllOutstandingBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outstanding balance summary by department: %w", err)
+	}
+
+	for _, balance := range balances {
+		department := totals[balance.Department]
+		if department == nil {
+			department = &departmentTotals{}
+			totals[balance.Department] = department
+		}
+		department.employees++
+		department.outstanding += models.Money(balance.TotalOutstanding).MinorUnits(currency)
+		department.overdue += models.Money(balance.OverdueAmount).MinorUnits(currency)
+		if balance.OverdueAmount > 0 {
+			department.withOverdue++
+		}
+	}
+
+	summaries := make(map[string]models.OutstandingBalanceSummary, len(totals))
+	for name, department := range totals {
+		outstanding := models.MoneyFromMinorUnits(department.outstanding, currency)
+		average := models.Money(outstanding.Float64() / float64(department.employees)).RoundTo(currency)
+
+		summaries[name] = models.OutstandingBalanceSummary{
+			TotalEmployees:       department.employees,
+			TotalOutstanding:     models.Amount(outstanding),
+			TotalOverdue:         models.Amount(models.MoneyFromMinorUnits(department.overdue, currency)),
+			AverageOutstanding:   models.Amount(average),
+			EmployeesWithOverdue: department.withOverdue,
+		}
+	}
+
+	return summaries, nil
 }
\ No newline at end of file
@@ -0,0 +1,821 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestGetEmployeeRepaymentsFollowsCursor(t *testing.T) {
+	var cursors []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		if r.URL.Query().Get("page") != "" && cursor != "" {
+			t.Error("Expected page to be omitted when a cursor is sent")
+		}
+
+		switch cursor {
+		case "":
+			writeData(w, models.RepaymentListResponse{
+				Results:    []models.Repayment{{ID: "r1"}, {ID: "r2"}},
+				NextCursor: "c2",
+			})
+		case "c2":
+			writeData(w, models.RepaymentListResponse{
+				Results:    []models.Repayment{{ID: "r3"}},
+				NextCursor: "c3",
+			})
+		case "c3":
+			writeData(w, models.RepaymentListResponse{
+				Results: []models.Repayment{{ID: "r4"}},
+			})
+		default:
+			t.Errorf("Unexpected cursor %q", cursor)
+		}
+	})
+
+	repayments, err := NewRepaymentService(c).GetEmployeeRepayments(context.Background(), "emp-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(repayments) != 4 {
+		t.Fatalf("Expected 4 repayments, got %d", len(repayments))
+	}
+	for i, repayment := range repayments {
+		if expected := fmt.Sprintf("r%d", i+1); repayment.ID != expected {
+			t.Errorf("Expected repayment %d to be %s, got %s", i, expected, repayment.ID)
+		}
+	}
+	if len(cursors) != 3 || cursors[1] != "c2" || cursors[2] != "c3" {
+		t.Errorf("Expected cursors [\"\" c2 c3], got %q", cursors)
+	}
+}
+
+func TestGetEmployeeRepaymentsWithPageConcurrencyFollowsCursor(t *testing.T) {
+	var mu sync.Mutex
+	var cursors []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		mu.Lock()
+		cursors = append(cursors, cursor)
+		mu.Unlock()
+
+		switch cursor {
+		case "":
+			writeData(w, models.RepaymentListResponse{
+				Total:      300,
+				Results:    []models.Repayment{{ID: "r1"}, {ID: "r2"}},
+				NextCursor: "c2",
+			})
+		case "c2":
+			writeData(w, models.RepaymentListResponse{
+				Results: []models.Repayment{{ID: "r3"}},
+			})
+		default:
+			t.Errorf("Unexpected cursor %q", cursor)
+		}
+	})
+
+	repayments, err := NewRepaymentService(c).WithPageConcurrency(4).GetEmployeeRepayments(context.Background(), "emp-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(repayments) != 3 || repayments[2].ID != "r3" {
+		t.Errorf("Expected r1 to r3 in order, got %+v", repayments)
+	}
+	if len(cursors) != 2 || cursors[1] != "c2" {
+		t.Errorf("Expected the cursor to be followed after the first page, got %q", cursors)
+	}
+}
+
+func TestGetRepaymentsByDateRangeFallsBackToOffsetPaging(t *testing.T) {
+	var pages []int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, page)
+
+		if r.URL.Query().Get("startDate") != "2024-01-01" || r.URL.Query().Get("endDate") != "2024-01-31" {
+			t.Errorf("Expected date range filters, got %s", r.URL.RawQuery)
+		}
+
+		// Two full pages followed by a partial page
+		count := 100
+		if page == 3 {
+			count = 5
+		}
+		results := make([]models.Repayment, count)
+		for i := range results {
+			results[i].ID = fmt.Sprintf("p%d-%d", page, i)
+		}
+		writeData(w, models.RepaymentListResponse{Results: results})
+	})
+
+	repayments, err := NewRepaymentService(c).GetRepaymentsByDateRange(context.Background(), "2024-01-01", "2024-01-31")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(repayments) != 205 {
+		t.Errorf("Expected 205 repayments, got %d", len(repayments))
+	}
+	if len(pages) != 3 || pages[0] != 1 || pages[2] != 3 {
+		t.Errorf("Expected pages [1 2 3], got %v", pages)
+	}
+}
+
+func TestGetEmployeeOutstandingBalanceMergesDuplicates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.OutstandingBalanceListResponse{
+			Total: 2,
+			Results: []models.OutstandingBalance{
+				{EmployeeID: "emp-1", TotalOutstanding: 120.5, DaysPastDue: 2},
+				{EmployeeID: "emp-1", TotalOutstanding: 79.5, DaysPastDue: 7},
+			},
+		})
+	})
+	service := NewRepaymentService(c)
+
+	balance, err := service.GetEmployeeOutstandingBalance(context.Background(), "emp-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if balance.TotalOutstanding != 200 {
+		t.Errorf("Expected merged total 200, got %v", balance.TotalOutstanding)
+	}
+	if balance.DaysPastDue != 7 {
+		t.Errorf("Expected max days past due 7, got %d", balance.DaysPastDue)
+	}
+
+	result, err := service.GetOutstandingBalance(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Results) != 1 {
+		t.Errorf("Expected 1 merged record, got %d", len(result.Results))
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("Expected a duplication warning, got %v", result.Warnings)
+	}
+}
+
+func TestGetByReferences(t *testing.T) {
+	var mu sync.Mutex
+	requested := make(map[string]int)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ref := r.URL.Query().Get("clientRepaymentReferenceNumber")
+		mu.Lock()
+		requested[ref]++
+		mu.Unlock()
+
+		switch ref {
+		case "REF-1", "REF-2":
+			writeData(w, models.RepaymentListResponse{
+				Total:   1,
+				Results: []models.Repayment{{ID: "id-" + ref, ClientRepaymentReferenceNumber: ref}},
+			})
+		case "REF-BROKEN":
+			writeError(w, http.StatusInternalServerError, "boom")
+		default:
+			writeData(w, models.RepaymentListResponse{})
+		}
+	})
+
+	refs := []string{"REF-1", "REF-2", "REF-1", " REF-2 ", "REF-MISSING", "REF-BROKEN", ""}
+	repayments, err := NewRepaymentService(c).GetByReferences(context.Background(), refs)
+
+	var lookupErr *errors.LookupError
+	if !stderrors.As(err, &lookupErr) {
+		t.Fatalf("Expected a LookupError, got %v", err)
+	}
+	if lookupErr.Total != 4 {
+		t.Errorf("Expected 4 unique references, got %d", lookupErr.Total)
+	}
+	if len(lookupErr.NotFound) != 1 || lookupErr.NotFound[0] != "REF-MISSING" {
+		t.Errorf("Expected REF-MISSING to be not found, got %v", lookupErr.NotFound)
+	}
+	if _, ok := lookupErr.Errors["REF-BROKEN"]; !ok || len(lookupErr.Errors) != 1 {
+		t.Errorf("Expected only REF-BROKEN to fail, got %v", lookupErr.Errors)
+	}
+
+	if len(repayments) != 2 || repayments["REF-1"].ID != "id-REF-1" || repayments["REF-2"].ID != "id-REF-2" {
+		t.Errorf("Unexpected repayments: %+v", repayments)
+	}
+	for ref, count := range requested {
+		if count != 1 {
+			t.Errorf("Expected %q to be requested once, got %d", ref, count)
+		}
+	}
+}
+
+func TestGetNextInstallment(t *testing.T) {
+	balances := map[string]models.OutstandingBalance{
+		"emp-1": {EmployeeID: "emp-1", TotalOutstanding: 450, NextDueDate: "2024-03-01",
+			TransactionHistory: []models.OutstandingTransaction{
+				{ID: "tx-1", Type: "advance", DueDate: "2024-04-01", RemainingAmount: 200},
+				{ID: "tx-2", Type: "advance", DueDate: "2024-03-01", RemainingAmount: 150},
+				{ID: "tx-3", Type: "fee", DueDate: "2024-03-01", RemainingAmount: 100},
+				{ID: "tx-4", Type: "advance", DueDate: "2024-02-01", RemainingAmount: 0},
+			}},
+		"emp-2": {EmployeeID: "emp-2", TotalOutstanding: 300, NextDueDate: "2024-05-15"},
+		"emp-3": {EmployeeID: "emp-3", TotalOutstanding: 0},
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		balance, ok := balances[r.URL.Query().Get("employeeId")]
+		if !ok {
+			writeData(w, models.OutstandingBalanceListResponse{Results: []models.OutstandingBalance{}})
+			return
+		}
+		writeData(w, models.OutstandingBalanceListResponse{Total: 1, Results: []models.OutstandingBalance{balance}})
+	})
+	service := NewRepaymentService(c)
+
+	installment, err := service.GetNextInstallment(context.Background(), "emp-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !installment.DueDate.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected earliest unpaid due date 2024-03-01, got %v", installment.DueDate)
+	}
+	if installment.Amount != 250 {
+		t.Errorf("Expected installment amount 250, got %v", installment.Amount)
+	}
+	if len(installment.TransactionIDs) != 2 || installment.RemainingBalance != 450 {
+		t.Errorf("Unexpected installment %+v", installment)
+	}
+
+	installment, err = service.GetNextInstallment(context.Background(), "emp-2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if installment.Amount != 300 || installment.DueDate.Format(models.DateLayout) != "2024-05-15" {
+		t.Errorf("Expected the next due date to apply to the full balance, got %+v", installment)
+	}
+
+	if _, err := service.GetNextInstallment(context.Background(), "emp-3"); !stderrors.Is(err, errors.ErrFullyPaid) {
+		t.Errorf("Expected ErrFullyPaid, got %v", err)
+	}
+
+	// The API returns no record at all for an employee with nothing outstanding
+	if _, err := service.GetNextInstallment(context.Background(), "emp-none"); !stderrors.Is(err, errors.ErrFullyPaid) {
+		t.Errorf("Expected ErrFullyPaid without a balance record, got %v", err)
+	}
+	if _, err := service.GetEmployeeOutstandingBalance(context.Background(), "emp-none"); err == nil || stderrors.Is(err, errors.ErrFullyPaid) {
+		t.Errorf("Expected the plain lookup to still report the missing record, got %v", err)
+	}
+}
+
+func TestCreateRepaymentSendsIdempotencyKeyOnEveryRetry(t *testing.T) {
+	var keys []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 3 {
+			writeError(w, http.StatusServiceUnavailable, "temporarily unavailable")
+			return
+		}
+		writeData(w, models.RepaymentResponse{Status: "success"})
+	})
+	c.SetRetryPolicy(2, time.Millisecond)
+
+	_, err := NewRepaymentService(c).Create(context.Background(), models.CreateRepaymentRequest{
+		Amount:                         250,
+		ClientRepaymentReferenceNumber: "REF-1",
+		EmployeeID:                     "emp-1",
+		IdempotencyKey:                 "repay-REF-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(keys))
+	}
+	for i, key := range keys {
+		if key != "repay-REF-1" {
+			t.Errorf("Attempt %d: expected Idempotency-Key repay-REF-1, got %q", i+1, key)
+		}
+	}
+}
+
+func TestListRepaymentsSerializesPaymentMethod(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		writeData(w, models.RepaymentListResponse{})
+	})
+
+	_, err := NewRepaymentService(c).ListRepayments(context.Background(), &models.RepaymentListOptions{
+		Status:        "completed",
+		PaymentMethod: "salary_deduction",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if query != "paymentMethod=salary_deduction&status=completed" {
+		t.Errorf("Unexpected query %q", query)
+	}
+}
+
+func TestGetRepaymentsByPaymentMethod(t *testing.T) {
+	var methods []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.URL.Query().Get("paymentMethod"))
+		if r.URL.Query().Get("page") == "1" {
+			results := make([]models.Repayment, 100)
+			writeData(w, models.RepaymentListResponse{Total: 101, Results: results})
+			return
+		}
+		writeData(w, models.RepaymentListResponse{Total: 101, Results: []models.Repayment{{ID: "r-101"}}})
+	})
+
+	repayments, err := NewRepaymentService(c).GetRepaymentsByPaymentMethod(context.Background(), "bank_transfer")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(repayments) != 101 {
+		t.Errorf("Expected 101 repayments across pages, got %d", len(repayments))
+	}
+	if len(methods) != 2 || methods[0] != "bank_transfer" || methods[1] != "bank_transfer" {
+		t.Errorf("Expected the filter on every page, got %v", methods)
+	}
+}
+
+func TestGetCollectionRate(t *testing.T) {
+	var repaymentStart string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transactions/employer":
+			if r.URL.Query().Get("type") != "advance" {
+				t.Errorf("Expected only advances to be listed, got %q", r.URL.RawQuery)
+			}
+			writeData(w, models.EmployerTransactionResponse{Results: []models.EmployerTransaction{
+				{ID: "a1", Amount: 1000, Status: "completed", RequestedAt: "2024-04-20T10:00:00Z", DueDate: "2024-05-15"},
+				{ID: "a2", Amount: 500, Status: "completed", RequestedAt: "2024-04-25T10:00:00Z", DueDate: "2024-05-25"},
+			}})
+		case "/repayments":
+			repaymentStart = r.URL.Query().Get("startDate")
+			writeData(w, models.RepaymentListResponse{Results: []models.Repayment{
+				{TransactionID: "a1", Amount: 1000, Status: "completed", ProcessedAt: time.Date(2024, 5, 15, 8, 0, 0, 0, time.UTC)},
+				{TransactionID: "a2", Amount: 100, Status: "completed", ProcessedAt: time.Date(2024, 5, 28, 8, 0, 0, 0, time.UTC)},
+			}})
+		case "/repayments/outstanding":
+			writeData(w, models.OutstandingBalanceListResponse{Results: []models.OutstandingBalance{{
+				EmployeeID:         "e2",
+				TransactionHistory: []models.OutstandingTransaction{{ID: "a2", Type: "advance", RemainingAmount: 400}},
+			}}})
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	rate, err := NewRepaymentService(c).GetCollectionRate(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if repaymentStart != "2024-04-20" {
+		t.Errorf("Expected repayments from the earliest advance, got %q", repaymentStart)
+	}
+	if rate.DueMinor != 150000 || rate.OnTimeMinor != 100000 || rate.LateMinor != 10000 || rate.OutstandingMinor != 40000 {
+		t.Errorf("Unexpected collection rate %+v", rate)
+	}
+	if rate.Currency != "AED" {
+		t.Errorf("Expected the default currency, got %q", rate.Currency)
+	}
+}
+
+func TestGetCollectionRatePagesOutstandingBalances(t *testing.T) {
+	// 250 balances, of which only the last reports what remains of the advance
+	balances := make([]models.OutstandingBalance, 250)
+	for i := range balances {
+		balances[i] = models.OutstandingBalance{EmployeeID: fmt.Sprintf("e%d", i)}
+	}
+	balances[249].TransactionHistory = []models.OutstandingTransaction{{ID: "a1", Type: "advance", RemainingAmount: 400}}
+
+	var pages []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transactions/employer":
+			writeData(w, models.EmployerTransactionResponse{Results: []models.EmployerTransaction{
+				{ID: "a1", Amount: 1000, Status: "completed", RequestedAt: "2024-04-20T10:00:00Z", DueDate: "2024-05-15"},
+			}})
+		case "/repayments":
+			writeData(w, models.RepaymentListResponse{})
+		case "/repayments/outstanding":
+			// The server caps pages at 100 results whatever limit is asked for
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page < 1 {
+				page = 1
+			}
+			pages = append(pages, r.URL.Query().Get("page"))
+			start, end := (page-1)*100, page*100
+			if end > len(balances) {
+				end = len(balances)
+			}
+			writeData(w, models.OutstandingBalanceListResponse{Total: len(balances), Results: balances[start:end]})
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	rate, err := NewRepaymentService(c).GetCollectionRate(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(pages, ",") != "1,2,3" {
+		t.Errorf("Expected outstanding balances to be fetched page by page, got pages %v", pages)
+	}
+	if rate.DueMinor != 100000 || rate.LateMinor != 60000 || rate.OutstandingMinor != 40000 {
+		t.Errorf("Expected the balance on the last page to be used, got %+v", rate)
+	}
+}
+
+func TestCreateRepaymentEmitsEvent(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.RepaymentResponse{Repayment: models.Repayment{ID: "rep-1", Amount: 250}})
+	})
+
+	var events []client.LifecycleEvent
+	c.SetEventSink(client.EventSinkFunc(func(ctx context.Context, event client.LifecycleEvent) error {
+		events = append(events, event)
+		panic("sink bug")
+	}))
+
+	_, err := NewRepaymentService(c).Create(context.Background(), models.CreateRepaymentRequest{
+		Amount:                         250,
+		ClientRepaymentReferenceNumber: "REF-1",
+		EmployeeID:                     "emp-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected a panicking sink not to fail the call, got %v", err)
+	}
+	if len(events) != 1 || events[0].Type != client.EventRepaymentCreated || events[0].ResourceID != "rep-1" || events[0].Amount != 250 {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+}
+
+func TestCreateIdempotentReturnsExistingRepayment(t *testing.T) {
+	var posts int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+			writeData(w, models.RepaymentResponse{Status: "success"})
+			return
+		}
+		if ref := r.URL.Query().Get("clientRepaymentReferenceNumber"); ref != "REF-1" {
+			t.Errorf("Expected a lookup of REF-1, got %q", ref)
+		}
+		writeData(w, models.RepaymentListResponse{Total: 1, Results: []models.Repayment{
+			{ID: "rep-1", Amount: 250, ClientRepaymentReferenceNumber: "REF-1", Status: "completed"},
+		}})
+	})
+
+	result, err := NewRepaymentService(c).CreateIdempotent(context.Background(), models.CreateRepaymentRequest{
+		Amount:                         250,
+		ClientRepaymentReferenceNumber: "REF-1",
+		EmployeeID:                     "emp-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if posts != 0 {
+		t.Errorf("Expected no repayment to be created, got %d POSTs", posts)
+	}
+	if result.Repayment.ID != "rep-1" || result.Status != "completed" {
+		t.Errorf("Expected the existing repayment, got %+v", result)
+	}
+}
+
+func TestCreateIdempotentCreatesWithDerivedKey(t *testing.T) {
+	var keys []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			writeData(w, models.RepaymentResponse{Repayment: models.Repayment{ID: "rep-2"}, Status: "success"})
+			return
+		}
+		writeData(w, models.RepaymentListResponse{})
+	})
+	service := NewRepaymentService(c)
+
+	result, err := service.CreateIdempotent(context.Background(), models.CreateRepaymentRequest{
+		Amount:                         250,
+		ClientRepaymentReferenceNumber: "REF-2",
+		EmployeeID:                     "emp-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Repayment.ID != "rep-2" {
+		t.Errorf("Expected the created repayment, got %+v", result)
+	}
+	if len(keys) != 1 || keys[0] != "repayment-REF-2" {
+		t.Errorf("Expected Idempotency-Key derived from the reference, got %v", keys)
+	}
+
+	var validationErr *errors.ValidationError
+	if _, err := service.CreateIdempotent(context.Background(), models.CreateRepaymentRequest{Amount: 250}); !stderrors.As(err, &validationErr) {
+		t.Errorf("Expected a ValidationError without a reference number, got %v", err)
+	}
+}
+
+func TestCreateIdempotentPropagatesLookupFailure(t *testing.T) {
+	var posts int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+		}
+		writeError(w, http.StatusForbidden, "Forbidden")
+	})
+
+	_, err := NewRepaymentService(c).CreateIdempotent(context.Background(), models.CreateRepaymentRequest{
+		Amount:                         250,
+		ClientRepaymentReferenceNumber: "REF-3",
+		EmployeeID:                     "emp-1",
+	})
+	if err == nil || stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected the lookup error, got %v", err)
+	}
+	if posts != 0 {
+		t.Errorf("Expected no repayment to be created when the lookup fails, got %d POSTs", posts)
+	}
+}
+
+func TestCreateScheduleInstallmentsSumToTotal(t *testing.T) {
+	var mu sync.Mutex
+	var requests []models.CreateRepaymentRequest
+	var keys []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateRepaymentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		requests = append(requests, req)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+		writeData(w, models.RepaymentResponse{Repayment: models.Repayment{
+			ID:                             "rep-" + req.ClientRepaymentReferenceNumber,
+			Amount:                         models.Amount(req.Amount),
+			ClientRepaymentReferenceNumber: req.ClientRepaymentReferenceNumber,
+		}})
+	})
+
+	results, err := NewRepaymentService(c).CreateSchedule(context.Background(), "emp-1", 1000, 3, "2024-01-31")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 3 || len(requests) != 3 {
+		t.Fatalf("Expected 3 installments, got %d results from %d requests", len(results), len(requests))
+	}
+
+	expectedAmounts := []float64{333.33, 333.33, 333.34}
+	expectedDueDates := []string{"2024-01-31", "2024-02-29", "2024-03-31"}
+	var totalFils int64
+	references := make(map[string]bool)
+	for i, req := range requests {
+		if req.Amount != expectedAmounts[i] {
+			t.Errorf("Installment %d: expected amount %v, got %v", i+1, expectedAmounts[i], req.Amount)
+		}
+		if req.DueDate != expectedDueDates[i] {
+			t.Errorf("Installment %d: expected due date %s, got %s", i+1, expectedDueDates[i], req.DueDate)
+		}
+		if req.EmployeeID != "emp-1" {
+			t.Errorf("Installment %d: expected employee emp-1, got %q", i+1, req.EmployeeID)
+		}
+		if keys[i] != "repayment-"+req.ClientRepaymentReferenceNumber {
+			t.Errorf("Installment %d: expected an Idempotency-Key derived from the reference, got %q", i+1, keys[i])
+		}
+		references[req.ClientRepaymentReferenceNumber] = true
+		totalFils += models.Money(req.Amount).MinorUnits(models.CurrencyAED)
+	}
+	if totalFils != 100000 {
+		t.Errorf("Expected installments to sum to exactly 1000.00, got %d fils", totalFils)
+	}
+	if len(references) != 3 {
+		t.Errorf("Expected a unique reference per installment, got %v", references)
+	}
+}
+
+func TestCreateScheduleHonoursRoundingIncrement(t *testing.T) {
+	var mu sync.Mutex
+	var amounts []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			writeData(w, map[string]interface{}{"token": "test-token"})
+			return
+		}
+		var req models.CreateRepaymentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		amounts = append(amounts, req.Amount)
+		mu.Unlock()
+		writeData(w, models.RepaymentResponse{Repayment: models.Repayment{ID: "rep-" + req.ClientRepaymentReferenceNumber}})
+	}))
+	defer server.Close()
+
+	currency := models.CurrencyAED
+	currency.RoundingIncrement = 25
+	c := client.New(client.NewConfig(server.URL, "test", "pass").SetCurrency(currency))
+
+	if _, err := NewRepaymentService(c).CreateSchedule(context.Background(), "emp-1", 100, 3, "2024-01-31"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []float64{33.25, 33.25, 33.5}
+	if len(amounts) != len(expected) {
+		t.Fatalf("Expected %d installments, got %v", len(expected), amounts)
+	}
+	var totalFils int64
+	for i, amount := range amounts {
+		if amount != expected[i] {
+			t.Errorf("Installment %d: expected amount %v, got %v", i+1, expected[i], amount)
+		}
+		totalFils += models.Money(amount).MinorUnits(currency)
+	}
+	if totalFils != 10000 {
+		t.Errorf("Expected the posted installments to sum to exactly 100.00, got %d fils", totalFils)
+	}
+}
+
+func TestCreateScheduleValidation(t *testing.T) {
+	called := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	service := NewRepaymentService(c)
+
+	tests := []struct {
+		name         string
+		total        float64
+		installments int
+		firstDueDate string
+		field        string
+	}{
+		{"zero total", 0, 3, "2024-01-31", "total"},
+		{"negative total", -100, 3, "2024-01-31", "total"},
+		{"zero installments", 1000, 0, "2024-01-31", "installments"},
+		{"installments below precision", 0.02, 3, "2024-01-31", "installments"},
+		{"bad due date", 1000, 3, "31/01/2024", "firstDueDate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateSchedule(context.Background(), "emp-1", tt.total, tt.installments, tt.firstDueDate)
+			var validationErr *errors.ValidationError
+			if !stderrors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("Expected a %s ValidationError, got %v", tt.field, err)
+			}
+		})
+	}
+	if called {
+		t.Error("Expected no request to be sent")
+	}
+}
+
+func TestCancelRepayment(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody models.CancelRepaymentRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		writeData(w, models.RepaymentResponse{Repayment: models.Repayment{ID: "rep-1", Status: "cancelled"}, Status: "success"})
+	})
+
+	result, err := NewRepaymentService(c).Cancel(context.Background(), "rep-1", "created in error")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/repayments/rep-1/cancel" {
+		t.Errorf("Expected POST /repayments/rep-1/cancel, got %s %s", gotMethod, gotPath)
+	}
+	if gotBody.Reason != "created in error" {
+		t.Errorf("Expected reason to be sent, got %q", gotBody.Reason)
+	}
+	if result.Repayment.Status != "cancelled" {
+		t.Errorf("Expected a cancelled repayment, got %+v", result.Repayment)
+	}
+}
+
+func TestCancelRepaymentRequiresReason(t *testing.T) {
+	called := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	_, err := NewRepaymentService(c).Cancel(context.Background(), "rep-1", "")
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || validationErr.Field != "reason" {
+		t.Fatalf("Expected a reason ValidationError, got %v", err)
+	}
+	if called {
+		t.Error("Expected no request to be sent")
+	}
+}
+
+func TestCancelCompletedRepaymentConflicts(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusConflict, "Repayment is already completed")
+	})
+
+	_, err := NewRepaymentService(c).Cancel(context.Background(), "rep-1", "created in error")
+	if !stderrors.Is(err, errors.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) || apiErr.Message != "Repayment is already completed" {
+		t.Errorf("Expected the server message to be preserved, got %v", err)
+	}
+}
+
+func TestGetOutstandingBalanceSummaryByDepartment(t *testing.T) {
+	balances := []models.OutstandingBalance{
+		{EmployeeID: "e1", Department: "Engineering", TotalOutstanding: 1000.10, OverdueAmount: 200},
+		{EmployeeID: "e2", Department: "Engineering", TotalOutstanding: 500.20},
+		{EmployeeID: "e3", Department: "Engineering", TotalOutstanding: 250},
+		{EmployeeID: "e4", Department: "Sales", TotalOutstanding: 300, OverdueAmount: 300},
+		{EmployeeID: "e5", Department: "Sales", TotalOutstanding: 100, OverdueAmount: 50.25},
+		{EmployeeID: "e6", Department: "Operations", TotalOutstanding: 75.50},
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.OutstandingBalanceListResponse{Total: len(balances), Results: balances})
+	})
+
+	summaries, err := NewRepaymentService(c).GetOutstandingBalanceSummaryByDepartment(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]models.OutstandingBalanceSummary{
+		"Engineering": {TotalEmployees: 3, TotalOutstanding: 1750.30, TotalOverdue: 200, AverageOutstanding: 583.43, EmployeesWithOverdue: 1},
+		"Sales":       {TotalEmployees: 2, TotalOutstanding: 400, TotalOverdue: 350.25, AverageOutstanding: 200, EmployeesWithOverdue: 2},
+		"Operations":  {TotalEmployees: 1, TotalOutstanding: 75.50, TotalOverdue: 0, AverageOutstanding: 75.50, EmployeesWithOverdue: 0},
+	}
+	if len(summaries) != len(expected) {
+		t.Fatalf("Expected %d departments, got %v", len(expected), summaries)
+	}
+	for department, want := range expected {
+		if got := summaries[department]; got != want {
+			t.Errorf("%s: expected %+v, got %+v", department, want, got)
+		}
+	}
+}
+
+func TestGetOutstandingBalanceSummaryByDepartmentMergesDuplicatesAcrossPages(t *testing.T) {
+	// The first page holds a duplicate pair, so it comes back one record short of the limit,
+	// and e1 appears again on the last page
+	balances := make([]models.OutstandingBalance, 0, 230)
+	balances = append(balances,
+		models.OutstandingBalance{EmployeeID: "e1", Department: "Engineering", TotalOutstanding: 100},
+		models.OutstandingBalance{EmployeeID: "e1", Department: "Engineering", TotalOutstanding: 50, OverdueAmount: 50},
+	)
+	for i := 2; i < 229; i++ {
+		balances = append(balances, models.OutstandingBalance{EmployeeID: fmt.Sprintf("s%d", i), Department: "Sales", TotalOutstanding: 10})
+	}
+	balances = append(balances, models.OutstandingBalance{EmployeeID: "e1", Department: "Engineering", TotalOutstanding: 25})
+
+	var pages []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		pages = append(pages, r.URL.Query().Get("page"))
+		start, end := (page-1)*limit, page*limit
+		if end > len(balances) {
+			end = len(balances)
+		}
+		writeData(w, models.OutstandingBalanceListResponse{Total: len(balances), Results: balances[start:end]})
+	})
+
+	summaries, err := NewRepaymentService(c).GetOutstandingBalanceSummaryByDepartment(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(pages, ",") != "1,2,3" {
+		t.Errorf("Expected every page to be fetched, got pages %v", pages)
+	}
+	if got := summaries["Engineering"]; got.TotalEmployees != 1 || got.TotalOutstanding != 175 || got.TotalOverdue != 50 {
+		t.Errorf("Expected e1 counted once with its amounts merged, got %+v", got)
+	}
+	if got := summaries["Sales"]; got.TotalEmployees != 227 || got.TotalOutstanding != 2270 {
+		t.Errorf("Expected every Sales employee on later pages, got %+v", got)
+	}
+}
@@ -4,34 +4,80 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
 	"abhi-go-sdk/models"
 )
 
 // TransactionService handles transaction-related API operations
 type TransactionService struct {
-	client *client.Client
+	client           *client.Client
+	validateDueDates bool
+	pageConcurrency  int
+	now              func() time.Time // Reference time for payroll periods, replaced in tests
 }
 
 // NewTransactionService creates a new transaction service
 func NewTransactionService(client *client.Client) *TransactionService {
 	return &TransactionService{
 		client: client,
+		now:    time.Now,
 	}
 }
 
+// EnableDueDateValidation enables client-side validation of transaction due dates
+// against the employee's current payroll period
+func (s *TransactionService) EnableDueDateValidation() *TransactionService {
+	s.validateDueDates = true
+	return s
+}
+
+// DisableDueDateValidation disables client-side due date validation
+func (s *TransactionService) DisableDueDateValidation() *TransactionService {
+	s.validateDueDates = false
+	return s
+}
+
+// WithPageConcurrency makes GetAllEmployerTransactions fetch pages with up to workers in flight; see CollectAll.
+func (s *TransactionService) WithPageConcurrency(workers int) *TransactionService {
+	s.pageConcurrency = workers
+	return s
+}
+
 // Employee Transaction Methods
 
 // CreateEmployeeTransaction creates a new transaction for an employee
 func (s *TransactionService) CreateEmployeeTransaction(ctx context.Context, req models.TransactionRequest) (*models.Transaction, error) {
+	if s.validateDueDates && req.DueDate != "" {
+		if err := s.validateDueDate(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if currency := s.client.Currency(); currency != nil {
+		req.Amount = models.Money(req.Amount).RoundTo(*currency).Float64()
+	}
+
 	var result models.Transaction
-	err := s.client.POST(ctx, "/transactions/employee", req, &result)
+	err := s.client.POSTIdempotent(ctx, "/transactions/employee", req, &result, req.IdempotencyKey)
 	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok && apiErr.IsInsufficientBalance() {
+			err = errors.NewInsufficientBalanceError(apiErr)
+		}
 		return nil, fmt.Errorf("failed to create employee transaction: %w", err)
 	}
 
+	s.client.EmitEvent(ctx, client.LifecycleEvent{
+		Type:       client.EventTransactionCreated,
+		ResourceID: result.ID,
+		Amount:     req.Amount,
+	})
+
 	return &result, nil
 }
 
@@ -58,6 +104,12 @@ func (s *TransactionService) GetEmployeeTransactionHistory(ctx context.Context,
 		if opts.EndDate != "" {
 			query.Set("endDate", opts.EndDate)
 		}
+		if opts.MinAmount > 0 {
+			query.Set("minAmount", strconv.FormatFloat(opts.MinAmount, 'f', 2, 64))
+		}
+		if opts.MaxAmount > 0 {
+			query.Set("maxAmount", strconv.FormatFloat(opts.MaxAmount, 'f', 2, 64))
+		}
 	}
 
 	endpoint := fmt.Sprintf("/transactions/employee/%s/history", employeeID)
@@ -103,6 +155,19 @@ func (s *TransactionService) ValidateEmployeeTransaction(ctx context.Context, re
 	return &result, nil
 }
 
+// GetEmployeeTransactionByID retrieves a single employee transaction with its amount, fees and due date
+func (s *TransactionService) GetEmployeeTransactionByID(ctx context.Context, transactionID string) (*models.Transaction, error) {
+	endpoint := fmt.Sprintf("/transactions/employee/%s", transactionID)
+
+	var result models.Transaction
+	err := s.client.GET(ctx, endpoint, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee transaction %s: %w", transactionID, err)
+	}
+
+	return &result, nil
+}
+
 // GetEmployeeTransactionStatus retrieves the status of a specific transaction
 func (s *TransactionService) GetEmployeeTransactionStatus(ctx context.Context, transactionID string) (*models.TransactionStatusResponse, error) {
 	endpoint := fmt.Sprintf("/transactions/employee/%s/status", transactionID)
@@ -116,6 +181,94 @@ func (s *TransactionService) GetEmployeeTransactionStatus(ctx context.Context, t
 	return &result, nil
 }
 
+// WaitForStatus polls a transaction's status until it is one of target, ignoring case, with polls
+// starting pollInterval apart (2s if zero) and backing off by default. See WaitForStatusWithOptions.
+func (s *TransactionService) WaitForStatus(ctx context.Context, transactionID string, target []string, pollInterval time.Duration) (*models.TransactionStatusResponse, error) {
+	return s.WaitForStatusWithOptions(ctx, transactionID, target, PollOptions{Interval: pollInterval})
+}
+
+// WaitForStatusWithOptions polls a transaction's status until it is one of target, ignoring
+// case, spacing polls as described by opts; each poll goes through the client's rate limiter like
+// any other request. Waiting stops with an error wrapping errors.ErrTerminalStatus if the
+// transaction reaches a terminal status that isn't a target, or with the context's error once it's
+// done or opts.Timeout elapses. On error the last status seen, if any, is returned alongside it.
+func (s *TransactionService) WaitForStatusWithOptions(ctx context.Context, transactionID string, target []string, opts PollOptions) (*models.TransactionStatusResponse, error) {
+	if len(target) == 0 {
+		return nil, &errors.ValidationError{
+			Field:   "target",
+			Message: "at least one target status is required",
+		}
+	}
+
+	wanted := make(map[string]bool, len(target))
+	for _, status := range target {
+		wanted[strings.ToLower(status)] = true
+	}
+
+	var last *models.TransactionStatusResponse
+	err := Poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		status, err := s.GetEmployeeTransactionStatus(ctx, transactionID)
+		if err != nil {
+			return false, err
+		}
+		last = status
+
+		if wanted[strings.ToLower(status.Status)] {
+			return true, nil
+		}
+		if models.IsTerminalTransactionStatus(status.Status) {
+			return false, fmt.Errorf("transaction %s ended with status %s: %w", transactionID, status.Status, errors.ErrTerminalStatus)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return last, fmt.Errorf("failed to wait for transaction %s status: %w", transactionID, err)
+	}
+
+	return last, nil
+}
+
+// CancelEmployeeTransaction cancels a transaction that hasn't been disbursed yet and returns its
+// updated status. A transaction that has already been processed is rejected with a 409 APIError.
+func (s *TransactionService) CancelEmployeeTransaction(ctx context.Context, transactionID string, reason string) (*models.TransactionStatusResponse, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, &errors.ValidationError{
+			Field:   "reason",
+			Message: "a cancellation reason is required",
+		}
+	}
+
+	endpoint := fmt.Sprintf("/transactions/employee/%s/cancel", transactionID)
+
+	var result models.TransactionStatusResponse
+	err := s.client.POST(ctx, endpoint, models.CancelTransactionRequest{Reason: reason}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel transaction %s: %w", transactionID, err)
+	}
+
+	return &result, nil
+}
+
+// GetStatusHistory retrieves the status timeline of a transaction ordered from oldest to newest.
+// The status history endpoint is not part of the published Open API collection; deployments
+// that don't expose it respond with a 404 APIError.
+func (s *TransactionService) GetStatusHistory(ctx context.Context, transactionID string) ([]models.StatusEvent, error) {
+	endpoint := fmt.Sprintf("/transactions/%s/status-history", transactionID)
+
+	var result models.TransactionStatusHistoryResponse
+	err := s.client.GET(ctx, endpoint, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history for transaction %s: %w", transactionID, err)
+	}
+
+	events := result.Events
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	return events, nil
+}
+
 // Employer Transaction Methods
 
 // GetEmployerTransactions retrieves transactions from employer perspective
@@ -147,6 +300,12 @@ func (s *TransactionService) GetEmployerTransactions(ctx context.Context, opts *
 		if opts.Department != "" {
 			query.Set("department", opts.Department)
 		}
+		if opts.MinAmount > 0 {
+			query.Set("minAmount", strconv.FormatFloat(opts.MinAmount, 'f', 2, 64))
+		}
+		if opts.MaxAmount > 0 {
+			query.Set("maxAmount", strconv.FormatFloat(opts.MaxAmount, 'f', 2, 64))
+		}
 	}
 
 	var result models.EmployerTransactionResponse
@@ -171,6 +330,40 @@ func (s *TransactionService) GetEmployerTransactionStatus(ctx context.Context, t
 	return &result, nil
 }
 
+// ApproveTransaction approves a pending transaction and returns its updated status
+func (s *TransactionService) ApproveTransaction(ctx context.Context, transactionID string) (*models.TransactionStatusResponse, error) {
+	endpoint := fmt.Sprintf("/transactions/employer/%s/approve", transactionID)
+
+	var result models.TransactionStatusResponse
+	err := s.client.POST(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve transaction %s: %w", transactionID, err)
+	}
+
+	return &result, nil
+}
+
+// RejectTransaction rejects a pending transaction with the reason shown to the employee and
+// returns its updated status
+func (s *TransactionService) RejectTransaction(ctx context.Context, transactionID string, reason string) (*models.TransactionStatusResponse, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, &errors.ValidationError{
+			Field:   "reason",
+			Message: "a rejection reason is required",
+		}
+	}
+
+	endpoint := fmt.Sprintf("/transactions/employer/%s/reject", transactionID)
+
+	var result models.TransactionStatusResponse
+	err := s.client.POST(ctx, endpoint, models.RejectTransactionRequest{Reason: reason}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject transaction %s: %w", transactionID, err)
+	}
+
+	return &result, nil
+}
+
 // ValidateQuestions retrieves validation questions for a transaction
 func (s *TransactionService) ValidateQuestions(ctx context.Context, req models.ValidationQuestionsRequest) (*models.ValidationQuestionsResponse, error) {
 	var result models.ValidationQuestionsResponse
@@ -193,6 +386,40 @@ func (s *TransactionService) SubmitValidationAnswers(ctx context.Context, req mo
 	return &result, nil
 }
 
+// validateDueDate ensures the due date falls on or before the end of the employee's current payroll period
+func (s *TransactionService) validateDueDate(ctx context.Context, req models.TransactionRequest) error {
+	dueDate, err := time.ParseInLocation(models.DateLayout, req.DueDate, time.Local)
+	if err != nil {
+		return &errors.ValidationError{
+			Field:   "dueDate",
+			Message: "due date must be in YYYY-MM-DD format",
+			Value:   req.DueDate,
+		}
+	}
+
+	employee, err := NewEmployeeService(s.client).GetByID(ctx, req.EmployeeID)
+	if err != nil {
+		return fmt.Errorf("failed to validate due date: %w", err)
+	}
+
+	_, periodEnd := employee.PayrollCycle().CurrentPeriod(s.now())
+	if dueDate.After(periodEnd) {
+		return &errors.ValidationError{
+			Field:   "dueDate",
+			Message: fmt.Sprintf("due date must be on or before the end of the current payroll period (%s)", periodEnd.Format(models.DateLayout)),
+			Value:   req.DueDate,
+		}
+	}
+
+	return nil
+}
+
+// SuggestDueDate returns the last day of the employee's current payroll period in YYYY-MM-DD format
+func (s *TransactionService) SuggestDueDate(employee models.Employee) string {
+	_, periodEnd := employee.PayrollCycle().CurrentPeriod(s.now())
+	return periodEnd.Format(models.DateLayout)
+}
+
 // Convenience Methods
 
 // GetAllEmployerTransactions retrieves all transactions with pagination handling
@@ -205,6 +432,23 @@ func (s *TransactionService) GetAllEmployerTransactions(ctx context.Context, opt
 		opts = &models.EmployerTransactionListOptions{}
 	}
 
+	if s.pageConcurrency > 1 {
+		transactions, err := CollectAll(ctx, func(ctx context.Context, page int) ([]models.EmployerTransaction, int, error) {
+			pageOpts := *opts
+			pageOpts.Page = page
+			pageOpts.Limit = limit
+			response, err := s.GetEmployerTransactions(ctx, &pageOpts)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Results, response.Total, nil
+		}, limit, s.pageConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		return transactions, nil
+	}
+
 	for {
 		opts.Page = page
 		opts.Limit = limit
@@ -226,6 +470,72 @@ func (s *TransactionService) GetAllEmployerTransactions(ctx context.Context, opt
 	return allTransactions, nil
 }
 
+// GetSummary computes the count and total amount of the employer transactions matching opts,
+// broken down by type and by status. There is no server-side summary endpoint, so the pages are
+// fetched and aggregated one at a time without holding every transaction in memory. Amounts are
+// summed in the client currency's minor units (AED when unset) to avoid accumulating float error.
+func (s *TransactionService) GetSummary(ctx context.Context, opts models.EmployerTransactionListOptions) (*models.TransactionSummary, error) {
+	currency := models.DefaultCurrency
+	if configured := s.client.Currency(); configured != nil {
+		currency = *configured
+	}
+
+	var total int64
+	count := 0
+	byType := map[string]int64{}
+	byStatus := map[string]int64{}
+	typeCounts := map[string]int{}
+	statusCounts := map[string]int{}
+
+	limit := 100
+	for page := 1; ; page++ {
+		opts.Page = page
+		opts.Limit = limit
+
+		response, err := s.GetEmployerTransactions(ctx, &opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize transactions page %d: %w", page, err)
+		}
+
+		for _, transaction := range response.Results {
+			minor := models.Money(transaction.Amount).MinorUnits(currency)
+			total += minor
+			count++
+			byType[transaction.Type] += minor
+			typeCounts[transaction.Type]++
+			byStatus[transaction.Status] += minor
+			statusCounts[transaction.Status]++
+		}
+
+		if len(response.Results) < limit {
+			break
+		}
+	}
+
+	summary := &models.TransactionSummary{
+		TransactionTotals: models.TransactionTotals{
+			Count:  count,
+			Amount: models.Amount(models.MoneyFromMinorUnits(total, currency)),
+		},
+		ByType:   make(map[string]models.TransactionTotals, len(byType)),
+		ByStatus: make(map[string]models.TransactionTotals, len(byStatus)),
+	}
+	for key, minor := range byType {
+		summary.ByType[key] = models.TransactionTotals{
+			Count:  typeCounts[key],
+			Amount: models.Amount(models.MoneyFromMinorUnits(minor, currency)),
+		}
+	}
+	for key, minor := range byStatus {
+		summary.ByStatus[key] = models.TransactionTotals{
+			Count:  statusCounts[key],
+			Amount: models.Amount(models.MoneyFromMinorUnits(minor, currency)),
+		}
+	}
+
+	return summary, nil
+}
+
 // GetTransactionsByEmployee retrieves all transactions for a specific employee
 func (s *TransactionService) GetTransactionsByEmployee(ctx context.Context, employeeID string) ([]models.Transaction, error) {
 	opts := &models.TransactionListOptions{
@@ -270,6 +580,43 @@ func (s *TransactionService) GetTransactionsByDateRange(ctx context.Context, sta
 	return result, nil
 }
 
+// FindTransactionsOver returns up to maxItems transactions of at least minAmount matching opts,
+// in the order the API lists them. Pages are only fetched until maxItems are found, so asking
+// for the first few large transactions doesn't enumerate the whole history.
+func (s *TransactionService) FindTransactionsOver(ctx context.Context, minAmount float64, opts *models.EmployerTransactionListOptions, maxItems int) ([]models.EmployerTransaction, error) {
+	filters := models.EmployerTransactionListOptions{}
+	if opts != nil {
+		filters = *opts
+	}
+	limit := 100
+
+	fetchPage := func(ctx context.Context, page int) ([]models.EmployerTransaction, bool, error) {
+		pageOpts := filters
+		pageOpts.Page = page
+		pageOpts.Limit = limit
+
+		response, err := s.GetEmployerTransactions(ctx, &pageOpts)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var matches []models.EmployerTransaction
+		for _, transaction := range response.Results {
+			if transaction.Amount >= minAmount {
+				matches = append(matches, transaction)
+			}
+		}
+		return matches, len(response.Results) == limit, nil
+	}
+
+	result, err := CollectUntil(ctx, fetchPage, nil, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions over %.2f: %w", minAmount, err)
+	}
+
+	return result, nil
+}
+
 // CreateAdvanceTransaction creates an advance transaction for an employee
 func (s *TransactionService) CreateAdvanceTransaction(ctx context.Context, employeeID string, amount float64, description string) (*models.Transaction, error) {
 	req := models.TransactionRequest{
@@ -292,4 +639,107 @@ func (s *TransactionService) CreateRepaymentTransaction(ctx context.Context, emp
 	}
 
 	return s.CreateEmployeeTransaction(ctx, req)
-}
\ No newline at end of file
+}
+
+// Batch Methods
+
+// TransactionBatchResult holds the outcome of a single item in a transaction batch
+type TransactionBatchResult struct {
+	Index       int
+	Request     models.TransactionRequest
+	Transaction *models.Transaction
+	Err         error
+}
+
+// CreateAdvancesBatch creates advance transactions for many employees with bounded concurrency
+// and an optional stagger between requests. Each item's IdempotencyKey is forwarded so retried
+// batches don't create duplicate advances. Individual failures don't stop the batch; they are
+// reported per item and aggregated into an *errors.BatchError. If ctx is cancelled, the items
+// completed so far keep their results and the rest report the context error.
+func (s *TransactionService) CreateAdvancesBatch(ctx context.Context, items []models.TransactionRequest, opts BatchOpts) ([]TransactionBatchResult, error) {
+	advances := make([]models.TransactionRequest, len(items))
+	for i, item := range items {
+		item.Type = "advance"
+		advances[i] = item
+	}
+
+	return s.createBatch(ctx, advances, opts)
+}
+
+// createBatch creates a transaction for each request as they are, reporting the outcome per item
+// and aggregating failures into an *errors.BatchError
+func (s *TransactionService) createBatch(ctx context.Context, items []models.TransactionRequest, opts BatchOpts) ([]TransactionBatchResult, error) {
+	results := make([]TransactionBatchResult, len(items))
+	for i, item := range items {
+		results[i] = TransactionBatchResult{Index: i, Request: item}
+	}
+
+	outcomes, ctxErr := collectBatch(ctx, len(items), opts, func(ctx context.Context, index int) (*models.Transaction, error) {
+		return s.CreateEmployeeTransaction(ctx, results[index].Request)
+	})
+
+	batchErr := errors.NewBatchError(len(items))
+	for i, outcome := range outcomes {
+		results[i].Transaction = outcome.Value
+		results[i].Err = outcome.Err
+		if !outcome.Done {
+			results[i].Err = ctxErr
+		}
+		if results[i].Err != nil {
+			batchErr.Add(i, results[i].Err)
+		}
+	}
+
+	if batchErr.HasErrors() {
+		return results, batchErr
+	}
+
+	return results, nil
+}
+
+// CreateBulkTransactions creates a transaction for each request, returning the created
+// transactions and per-item errors aligned to reqs. There is no batch endpoint, so the requests
+// are sent individually with bounded concurrency, each passing through the client's rate
+// limiter. Every request is validated before any is sent; invalid items fail without a request
+// while the rest proceed. Valid requests go through the same batching as CreateAdvancesBatch,
+// keeping their type and IdempotencyKey. The returned error is an *errors.BatchError if any item
+// failed.
+func (s *TransactionService) CreateBulkTransactions(ctx context.Context, reqs []models.TransactionRequest) ([]models.Transaction, []error, error) {
+	transactions := make([]models.Transaction, len(reqs))
+	errs := make([]error, len(reqs))
+
+	var pending []int
+	var valid []models.TransactionRequest
+	for i, req := range reqs {
+		if err := s.client.ValidateStruct(req); err != nil {
+			errs[i] = err
+			continue
+		}
+		pending = append(pending, i)
+		valid = append(valid, req)
+	}
+
+	// Per-item failures are collected below along with the validation errors
+	results, _ := s.createBatch(ctx, valid, BatchOpts{Concurrency: bulkWriteConcurrency})
+	for i, result := range results {
+		index := pending[i]
+		if result.Err != nil {
+			errs[index] = result.Err
+			continue
+		}
+		transactions[index] = *result.Transaction
+	}
+
+	batchErr := errors.NewBatchError(len(reqs))
+	for i, err := range errs {
+		if err != nil {
+			batchErr.Add(i, err)
+		}
+	}
+
+	if batchErr.HasErrors() {
+		return transactions, errs, batchErr
+	}
+
+	return transactions, errs, nil
+}
@@ -0,0 +1,774 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestCreateAdvanceTransactionInsufficientBalance(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "Insufficient balance for this advance",
+			Code:       "INSUFFICIENT_BALANCE",
+			Data: map[string]interface{}{
+				"requestedAmount": 1500,
+				"availableAmount": "820.50",
+			},
+		})
+	})
+
+	_, err := NewTransactionService(c).CreateAdvanceTransaction(context.Background(), "emp-1", 1500, "advance")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if !stderrors.Is(err, errors.ErrInsufficientBalance) {
+		t.Errorf("Expected errors.Is to match ErrInsufficientBalance, got %v", err)
+	}
+
+	var balanceErr *errors.InsufficientBalanceError
+	if !stderrors.As(err, &balanceErr) {
+		t.Fatalf("Expected an InsufficientBalanceError, got %T", err)
+	}
+	if balanceErr.RequestedAmount != 1500 {
+		t.Errorf("Expected requested amount 1500, got %v", balanceErr.RequestedAmount)
+	}
+	if balanceErr.AvailableAmount != 820.50 {
+		t.Errorf("Expected available amount 820.50, got %v", balanceErr.AvailableAmount)
+	}
+
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected the underlying APIError to remain reachable, got %v", apiErr)
+	}
+}
+
+func TestCreateAdvanceTransactionOtherErrorsUntyped(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusBadRequest, "Employee is inactive")
+	})
+
+	_, err := NewTransactionService(c).CreateAdvanceTransaction(context.Background(), "emp-1", 100, "advance")
+	if stderrors.Is(err, errors.ErrInsufficientBalance) {
+		t.Errorf("Expected unrelated errors not to match ErrInsufficientBalance, got %v", err)
+	}
+}
+
+func TestCreateEmployeeTransactionSendsIdempotencyKeyOnEveryRetry(t *testing.T) {
+	var keys []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 2 {
+			writeError(w, http.StatusBadGateway, "bad gateway")
+			return
+		}
+		writeData(w, models.Transaction{ID: "txn-1"})
+	})
+	c.SetRetryPolicy(1, time.Millisecond)
+
+	_, err := NewTransactionService(c).CreateEmployeeTransaction(context.Background(), models.TransactionRequest{
+		EmployeeID:     "emp-1",
+		Amount:         100,
+		Type:           "advance",
+		IdempotencyKey: "advance-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "advance-1" || keys[1] != "advance-1" {
+		t.Errorf("Expected the same key on both attempts, got %v", keys)
+	}
+}
+
+func TestCreateEmployeeTransactionEmitsEvent(t *testing.T) {
+	fail := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			writeError(w, http.StatusBadRequest, "invalid amount")
+			return
+		}
+		writeData(w, models.Transaction{ID: "txn-1", Amount: 150})
+	})
+
+	var events []client.LifecycleEvent
+	c.SetEventSink(client.EventSinkFunc(func(ctx context.Context, event client.LifecycleEvent) error {
+		events = append(events, event)
+		return stderrors.New("bus unavailable")
+	}))
+
+	service := NewTransactionService(c)
+	req := models.TransactionRequest{EmployeeID: "emp-1", Amount: 150, Type: "advance"}
+	if _, err := service.CreateEmployeeTransaction(context.Background(), req); err != nil {
+		t.Fatalf("Expected a failing sink not to fail the call, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Type != client.EventTransactionCreated || event.ResourceID != "txn-1" || event.Amount != 150 || event.Timestamp.IsZero() {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+
+	fail = true
+	if _, err := service.CreateEmployeeTransaction(context.Background(), req); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected no event for a failed call, got %d events", len(events))
+	}
+}
+
+func TestCreateEmployeeTransactionErrorsRecoverableWithAs(t *testing.T) {
+	valid := models.TransactionRequest{EmployeeID: "e1", Amount: 500, Type: "advance"}
+
+	tests := []struct {
+		name    string
+		login   http.HandlerFunc
+		handler http.HandlerFunc
+		req     models.TransactionRequest
+		target  interface{}
+	}{
+		{
+			name: "api error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				writeError(w, http.StatusNotFound, "Employee not found")
+			},
+			req:    valid,
+			target: new(*errors.APIError),
+		},
+		{
+			name: "truncated response body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "100")
+				w.Write([]byte(`{"statusCode":`))
+			},
+			req:    valid,
+			target: new(*errors.NetworkError),
+		},
+		{
+			name: "malformed response body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"statusCode":200,"data":`))
+			},
+			req:    valid,
+			target: new(*errors.SDKError),
+		},
+		{
+			name:   "invalid request",
+			req:    models.TransactionRequest{EmployeeID: "e1", Type: "advance"},
+			target: new(*errors.ValidationError),
+		},
+		{
+			name: "login rejected",
+			login: func(w http.ResponseWriter, r *http.Request) {
+				writeError(w, http.StatusUnauthorized, "Invalid credentials")
+			},
+			req:    valid,
+			target: new(*errors.AuthenticationError),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/auth/login" {
+					if test.login != nil {
+						test.login(w, r)
+						return
+					}
+					writeData(w, map[string]interface{}{"token": "test-token"})
+					return
+				}
+				test.handler(w, r)
+			}))
+			defer server.Close()
+
+			service := NewTransactionService(client.New(client.NewConfig(server.URL, "test", "pass")))
+			_, err := service.CreateEmployeeTransaction(context.Background(), test.req)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !stderrors.As(err, test.target) {
+				t.Errorf("Expected %T to be recoverable from %v", test.target, err)
+			}
+		})
+	}
+}
+
+func TestCreateEmployeeTransactionDecodeFailure(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The ID is a number, so the transaction can't be decoded
+		writeData(w, map[string]interface{}{"id": 42, "amount": 500})
+	})
+
+	_, err := NewTransactionService(c).CreateEmployeeTransaction(context.Background(),
+		models.TransactionRequest{EmployeeID: "e1", Amount: 500, Type: "advance"})
+
+	var sdkErr *errors.SDKError
+	if !stderrors.As(err, &sdkErr) {
+		t.Fatalf("Expected an SDKError, got %T: %v", err, err)
+	}
+	if sdkErr.Kind != errors.KindDecoding {
+		t.Errorf("Expected a decoding error, got %q", sdkErr.Kind)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if !stderrors.As(err, &typeErr) {
+		t.Errorf("Expected the decoder's error to be kept, got %v", err)
+	}
+}
+
+func TestCancelEmployeeTransaction(t *testing.T) {
+	var gotPath, gotMethod string
+	var gotBody models.CancelTransactionRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotMethod = r.URL.Path, r.Method
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		writeData(w, models.TransactionStatusResponse{TransactionID: "txn-1", Status: "cancelled"})
+	})
+
+	status, err := NewTransactionService(c).CancelEmployeeTransaction(context.Background(), "txn-1", "changed my mind")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/transactions/employee/txn-1/cancel" {
+		t.Errorf("Expected POST /transactions/employee/txn-1/cancel, got %s %s", gotMethod, gotPath)
+	}
+	if gotBody.Reason != "changed my mind" {
+		t.Errorf("Expected reason to be sent, got %q", gotBody.Reason)
+	}
+	if status.TransactionID != "txn-1" || status.Status != "cancelled" {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+}
+
+func TestCancelEmployeeTransactionErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		sentinel error
+	}{
+		{"already processed", http.StatusConflict, errors.ErrConflict},
+		{"not found", http.StatusNotFound, errors.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeError(w, tt.status, tt.name)
+			})
+
+			_, err := NewTransactionService(c).CancelEmployeeTransaction(context.Background(), "txn-1", "duplicate request")
+			if !stderrors.Is(err, tt.sentinel) {
+				t.Errorf("Expected %v, got %v", tt.sentinel, err)
+			}
+		})
+	}
+}
+
+func TestCancelEmployeeTransactionRequiresReason(t *testing.T) {
+	called := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	_, err := NewTransactionService(c).CancelEmployeeTransaction(context.Background(), "txn-1", "  ")
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || validationErr.Field != "reason" {
+		t.Fatalf("Expected a reason ValidationError, got %v", err)
+	}
+	if called {
+		t.Error("Expected no request to be sent")
+	}
+}
+
+func TestApproveTransaction(t *testing.T) {
+	var gotPath, gotMethod string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotMethod = r.URL.Path, r.Method
+		writeData(w, models.TransactionStatusResponse{TransactionID: "txn-1", Status: "approved"})
+	})
+
+	status, err := NewTransactionService(c).ApproveTransaction(context.Background(), "txn-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/transactions/employer/txn-1/approve" {
+		t.Errorf("Expected POST /transactions/employer/txn-1/approve, got %s %s", gotMethod, gotPath)
+	}
+	if status.Status != "approved" {
+		t.Errorf("Expected status approved, got %q", status.Status)
+	}
+}
+
+func TestRejectTransaction(t *testing.T) {
+	var gotPath string
+	var gotBody models.RejectTransactionRequest
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		writeData(w, models.TransactionStatusResponse{TransactionID: "txn-1", Status: "rejected", Message: gotBody.Reason})
+	})
+
+	status, err := NewTransactionService(c).RejectTransaction(context.Background(), "txn-1", "exceeds budget")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotPath != "/transactions/employer/txn-1/reject" {
+		t.Errorf("Expected /transactions/employer/txn-1/reject, got %s", gotPath)
+	}
+	if gotBody.Reason != "exceeds budget" {
+		t.Errorf("Expected reason to be sent, got %q", gotBody.Reason)
+	}
+	if status.Status != "rejected" {
+		t.Errorf("Expected status rejected, got %q", status.Status)
+	}
+}
+
+func TestRejectTransactionRequiresReason(t *testing.T) {
+	called := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	_, err := NewTransactionService(c).RejectTransaction(context.Background(), "txn-1", "")
+	var validationErr *errors.ValidationError
+	if !stderrors.As(err, &validationErr) || validationErr.Field != "reason" {
+		t.Fatalf("Expected a reason ValidationError, got %v", err)
+	}
+	if called {
+		t.Error("Expected no request to be sent")
+	}
+}
+
+func TestGetEmployeeTransactionByID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions/employee/txn-1" {
+			writeError(w, http.StatusNotFound, "Transaction not found")
+			return
+		}
+		writeData(w, models.Transaction{ID: "txn-1", Amount: 500, ProcessingFee: 15})
+	})
+	service := NewTransactionService(c)
+
+	txn, err := service.GetEmployeeTransactionByID(context.Background(), "txn-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if txn.ID != "txn-1" || txn.Amount != 500 || txn.ProcessingFee != 15 {
+		t.Errorf("Unexpected transaction: %+v", txn)
+	}
+
+	_, err = service.GetEmployeeTransactionByID(context.Background(), "txn-missing")
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetStatusHistory(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions/txn-1/status-history" {
+			writeError(w, http.StatusNotFound, "Transaction not found")
+			return
+		}
+		// Events arrive out of order; the two at the same instant keep their relative order
+		writeData(w, models.TransactionStatusHistoryResponse{
+			TransactionID: "txn-1",
+			Events: []models.StatusEvent{
+				{Status: "completed", Timestamp: base.Add(3 * time.Hour)},
+				{Status: "pending", Timestamp: base},
+				{Status: "approved", Timestamp: base.Add(time.Hour), Actor: "manager"},
+				{Status: "processing", Timestamp: base.Add(time.Hour), Actor: "system"},
+			},
+		})
+	})
+	service := NewTransactionService(c)
+
+	events, err := service.GetStatusHistory(context.Background(), "txn-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var statuses []string
+	for _, event := range events {
+		statuses = append(statuses, event.Status)
+	}
+	if strings.Join(statuses, ",") != "pending,approved,processing,completed" {
+		t.Errorf("Expected the events oldest first, got %v", statuses)
+	}
+
+	_, err = service.GetStatusHistory(context.Background(), "txn-missing")
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("Expected a not found APIError, got %T: %v", err, err)
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	// 100 approved advances of 10.10 and one pending repayment of 250.55, spread over two pages
+	var dataset []models.EmployerTransaction
+	for i := 0; i < 100; i++ {
+		dataset = append(dataset, models.EmployerTransaction{Amount: 10.10, Type: "advance", Status: "approved"})
+	}
+	dataset = append(dataset, models.EmployerTransaction{Amount: 250.55, Type: "repayment", Status: "pending"})
+
+	var startDates []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		startDates = append(startDates, query.Get("startDate"))
+		start := 0
+		if query.Get("page") == "2" {
+			start = 100
+		}
+		end := start + 100
+		if end > len(dataset) {
+			end = len(dataset)
+		}
+		writeData(w, models.EmployerTransactionResponse{Total: len(dataset), Results: dataset[start:end]})
+	})
+
+	summary, err := NewTransactionService(c).GetSummary(context.Background(), models.EmployerTransactionListOptions{StartDate: "2024-01-01"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(startDates) != 2 || startDates[0] != "2024-01-01" || startDates[1] != "2024-01-01" {
+		t.Errorf("Expected two pages filtered by start date, got %v", startDates)
+	}
+	if summary.Count != 101 || summary.Amount != 1260.55 {
+		t.Errorf("Expected 101 transactions totalling 1260.55, got %d totalling %v", summary.Count, summary.Amount)
+	}
+
+	expectedByType := map[string]models.TransactionTotals{
+		"advance":   {Count: 100, Amount: 1010},
+		"repayment": {Count: 1, Amount: 250.55},
+	}
+	expectedByStatus := map[string]models.TransactionTotals{
+		"approved": {Count: 100, Amount: 1010},
+		"pending":  {Count: 1, Amount: 250.55},
+	}
+	for key, expected := range expectedByType {
+		if got := summary.ByType[key]; got != expected {
+			t.Errorf("Expected %s totals %+v, got %+v", key, expected, got)
+		}
+	}
+	for key, expected := range expectedByStatus {
+		if got := summary.ByStatus[key]; got != expected {
+			t.Errorf("Expected %s totals %+v, got %+v", key, expected, got)
+		}
+	}
+	if len(summary.ByType) != 2 || len(summary.ByStatus) != 2 {
+		t.Errorf("Expected two types and two statuses, got %v and %v", summary.ByType, summary.ByStatus)
+	}
+}
+
+func TestGetSummaryPropagatesErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusForbidden, "Forbidden")
+	})
+
+	if _, err := NewTransactionService(c).GetSummary(context.Background(), models.EmployerTransactionListOptions{}); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
+
+func TestTransactionListingAmountFilters(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		writeData(w, map[string]interface{}{"total": 0, "results": []interface{}{}})
+	})
+	service := NewTransactionService(c)
+
+	if _, err := service.GetEmployerTransactions(context.Background(), &models.EmployerTransactionListOptions{MinAmount: 500, MaxAmount: 1250.75}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if query.Get("minAmount") != "500.00" || query.Get("maxAmount") != "1250.75" {
+		t.Errorf("Expected minAmount=500.00 and maxAmount=1250.75, got %v", query)
+	}
+
+	if _, err := service.GetEmployerTransactions(context.Background(), &models.EmployerTransactionListOptions{Status: "approved"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if query.Has("minAmount") || query.Has("maxAmount") {
+		t.Errorf("Expected no amount bounds when unset, got %v", query)
+	}
+
+	if _, err := service.GetEmployeeTransactionHistory(context.Background(), "emp-1", &models.TransactionListOptions{MaxAmount: 300}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if query.Has("minAmount") || query.Get("maxAmount") != "300.00" {
+		t.Errorf("Expected only maxAmount=300.00, got %v", query)
+	}
+}
+
+func TestCreateBulkTransactions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req models.TransactionRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.EmployeeID == "emp-inactive" {
+			writeError(w, http.StatusBadRequest, "Employee is inactive")
+			return
+		}
+		writeData(w, models.Transaction{ID: "txn-" + req.EmployeeID, EmployeeID: req.EmployeeID, Amount: models.Amount(req.Amount)})
+	})
+	service := NewTransactionService(c)
+
+	t.Run("all succeed", func(t *testing.T) {
+		reqs := []models.TransactionRequest{
+			{EmployeeID: "emp-1", Amount: 100, Type: "advance"},
+			{EmployeeID: "emp-2", Amount: 200, Type: "advance"},
+			{EmployeeID: "emp-3", Amount: 300, Type: "repayment"},
+		}
+
+		transactions, errs, err := service.CreateBulkTransactions(context.Background(), reqs)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(transactions) != 3 || len(errs) != 3 {
+			t.Fatalf("Expected results aligned to 3 inputs, got %d and %d", len(transactions), len(errs))
+		}
+		for i, req := range reqs {
+			if errs[i] != nil || transactions[i].ID != "txn-"+req.EmployeeID || transactions[i].Amount.Float64() != req.Amount {
+				t.Errorf("Item %d: unexpected result %+v, %v", i, transactions[i], errs[i])
+			}
+		}
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		reqs := []models.TransactionRequest{
+			{EmployeeID: "emp-1", Amount: 100, Type: "advance"},
+			{EmployeeID: "emp-inactive", Amount: 100, Type: "advance"},
+			{EmployeeID: "emp-3", Amount: 100, Type: "advance"},
+		}
+
+		transactions, errs, err := service.CreateBulkTransactions(context.Background(), reqs)
+		var batchErr *errors.BatchError
+		if !stderrors.As(err, &batchErr) || len(batchErr.Errors) != 1 || batchErr.Errors[1] == nil {
+			t.Fatalf("Expected a BatchError for item 1, got %v", err)
+		}
+		if errs[0] != nil || errs[2] != nil || transactions[0].ID != "txn-emp-1" || transactions[2].ID != "txn-emp-3" {
+			t.Errorf("Expected items 0 and 2 to succeed, got %+v, %v", transactions, errs)
+		}
+		var apiErr *errors.APIError
+		if !stderrors.As(errs[1], &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected item 1 to fail with a 400 APIError, got %v", errs[1])
+		}
+		if transactions[1].ID != "" {
+			t.Errorf("Expected no transaction for the failed item, got %+v", transactions[1])
+		}
+	})
+}
+
+func TestCreateBulkTransactionsValidatesEachRequest(t *testing.T) {
+	var sent []string
+	var mu sync.Mutex
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req models.TransactionRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		sent = append(sent, req.EmployeeID)
+		mu.Unlock()
+		writeData(w, models.Transaction{ID: "txn-" + req.EmployeeID})
+	})
+
+	reqs := []models.TransactionRequest{
+		{EmployeeID: "", Amount: 100, Type: "advance"},
+		{EmployeeID: "emp-2", Amount: 0, Type: "advance"},
+		{EmployeeID: "emp-3", Amount: 100, Type: "bonus"},
+		{EmployeeID: "emp-4", Amount: 100, Type: "advance"},
+	}
+
+	transactions, errs, err := NewTransactionService(c).CreateBulkTransactions(context.Background(), reqs)
+	var batchErr *errors.BatchError
+	if !stderrors.As(err, &batchErr) || len(batchErr.Errors) != 3 {
+		t.Fatalf("Expected a BatchError with 3 failures, got %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		var validationErr *errors.ValidationError
+		if !stderrors.As(errs[i], &validationErr) {
+			t.Errorf("Item %d: expected a ValidationError, got %v", i, errs[i])
+		}
+	}
+	if errs[3] != nil || transactions[3].ID != "txn-emp-4" {
+		t.Errorf("Expected the valid item to be created, got %+v, %v", transactions[3], errs[3])
+	}
+	if len(sent) != 1 || sent[0] != "emp-4" {
+		t.Errorf("Expected only the valid request to be sent, got %v", sent)
+	}
+}
+
+func TestWaitForStatusCompletesAfterTwoPolls(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transactions/employee/txn-1/status" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		mu.Lock()
+		polls++
+		status := "processing"
+		if polls > 2 {
+			status = "completed"
+		}
+		mu.Unlock()
+		writeData(w, models.TransactionStatusResponse{TransactionID: "txn-1", Status: status})
+	})
+
+	status, err := NewTransactionService(c).WaitForStatus(context.Background(), "txn-1", []string{"completed"}, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status.Status != "completed" {
+		t.Errorf("Expected status completed, got %s", status.Status)
+	}
+	if polls != 3 {
+		t.Errorf("Expected the status to be polled 3 times, got %d", polls)
+	}
+}
+
+func TestWaitForStatusContextTimeout(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.TransactionStatusResponse{TransactionID: "txn-1", Status: "processing"})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	status, err := NewTransactionService(c).WaitForStatus(ctx, "txn-1", []string{"completed"}, 5*time.Millisecond)
+	if !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if status == nil || status.Status != "processing" {
+		t.Errorf("Expected the last status seen to be returned, got %+v", status)
+	}
+}
+
+func TestWaitForStatusWithOptionsHonoursTimeout(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		mu.Unlock()
+		writeData(w, models.TransactionStatusResponse{TransactionID: "txn-1", Status: "processing"})
+	})
+
+	opts := PollOptions{Interval: 5 * time.Millisecond, Multiplier: 1, Jitter: -1, Timeout: 50 * time.Millisecond}
+	status, err := NewTransactionService(c).WaitForStatusWithOptions(context.Background(), "txn-1", []string{"completed"}, opts)
+	if !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the poll timeout to stop waiting, got %v", err)
+	}
+	if status == nil || status.Status != "processing" {
+		t.Errorf("Expected the last status seen to be returned, got %+v", status)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if polls < 2 {
+		t.Errorf("Expected polls at a fixed interval until the timeout, got %d", polls)
+	}
+}
+
+func TestWaitForStatusStopsAtTerminalStatus(t *testing.T) {
+	polls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		writeData(w, models.TransactionStatusResponse{TransactionID: "txn-1", Status: "Rejected"})
+	})
+
+	status, err := NewTransactionService(c).WaitForStatus(context.Background(), "txn-1", []string{"completed"}, time.Millisecond)
+	if !stderrors.Is(err, errors.ErrTerminalStatus) {
+		t.Fatalf("Expected ErrTerminalStatus, got %v", err)
+	}
+	if status == nil || status.Status != "Rejected" {
+		t.Errorf("Expected the terminal status to be returned, got %+v", status)
+	}
+	if polls != 1 {
+		t.Errorf("Expected a single poll, got %d", polls)
+	}
+
+	if _, err := NewTransactionService(c).WaitForStatus(context.Background(), "txn-1", nil, time.Millisecond); err == nil {
+		t.Error("Expected a validation error without target statuses")
+	}
+}
+
+func TestValidateDueDate(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, models.Employee{ID: "emp-1", PayrollStartDay: 25})
+	})
+	service := NewTransactionService(c)
+	// The current period runs from 2024-12-25 to 2025-01-24
+	service.now = func() time.Time { return time.Date(2024, 12, 30, 15, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		dueDate string
+		wantErr bool
+	}{
+		{dueDate: "2024-12-30"},
+		{dueDate: "2025-01-10"},
+		{dueDate: "2025-01-24"},
+		{dueDate: "2025-01-25", wantErr: true},
+		{dueDate: "2025-02-24", wantErr: true},
+		{dueDate: "24/01/2025", wantErr: true},
+		{dueDate: "2025-01-24T00:00:00Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := service.validateDueDate(context.Background(), models.TransactionRequest{EmployeeID: "emp-1", DueDate: tt.dueDate})
+		if !tt.wantErr {
+			if err != nil {
+				t.Errorf("%s: expected no error, got %v", tt.dueDate, err)
+			}
+			continue
+		}
+
+		var validationErr *errors.ValidationError
+		if !stderrors.As(err, &validationErr) || validationErr.Field != "dueDate" {
+			t.Errorf("%s: expected a dueDate validation error, got %v", tt.dueDate, err)
+		}
+	}
+}
+
+func TestSuggestDueDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		startDay int
+		now      time.Time
+		want     string
+	}{
+		{name: "unset start day", startDay: 0, now: time.Date(2024, 4, 15, 9, 0, 0, 0, time.Local), want: "2024-04-30"},
+		{name: "mid-month start day", startDay: 25, now: time.Date(2024, 5, 15, 9, 0, 0, 0, time.Local), want: "2024-05-24"},
+		{name: "31st in February", startDay: 31, now: time.Date(2024, 2, 10, 9, 0, 0, 0, time.Local), want: "2024-02-28"},
+		{name: "31st in 30-day month", startDay: 31, now: time.Date(2024, 4, 30, 9, 0, 0, 0, time.Local), want: "2024-05-30"},
+		{name: "December into January", startDay: 25, now: time.Date(2024, 12, 30, 9, 0, 0, 0, time.Local), want: "2025-01-24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewTransactionService(nil)
+			service.now = func() time.Time { return tt.now }
+
+			if got := service.SuggestDueDate(models.Employee{PayrollStartDay: tt.startDay}); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
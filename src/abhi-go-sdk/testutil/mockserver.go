@@ -0,0 +1,256 @@
+// Package testutil provides an in-process stand-in for the Abhi API, so code built on the SDK
+// can be tested without network access or hand-written envelopes.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	abhi "abhi-go-sdk"
+	"abhi-go-sdk/client"
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+// Credentials the SDK returned by MockServer.SDK logs in with
+const (
+	MockUsername = "mock-user"
+	MockPassword = "mock-password"
+)
+
+// MockTokenLifetime is how long tokens issued by the mock login stay valid
+const MockTokenLifetime = 24 * time.Hour
+
+// Handler answers a mocked API request. The returned data is wrapped in the standard API
+// response envelope. An *errors.APIError is sent as an error response with its status code;
+// any other error is sent as a 500.
+type Handler func(r *http.Request) (data interface{}, err error)
+
+// RecordedRequest is an API request received by a MockServer
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// MockServer is a test HTTP server that speaks the Abhi API. Logins and token refreshes are
+// answered with a valid JWT; other requests are dispatched to the registered handlers, and
+// requests without one get a 404. Close the server when the test ends.
+type MockServer struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	routes   []route
+	requests []RecordedRequest
+}
+
+// route is a registered handler and the method and path pattern it answers
+type route struct {
+	method  string
+	pattern string
+	handler Handler
+}
+
+// NewMockServer starts a mock Abhi API server
+func NewMockServer() *MockServer {
+	m := &MockServer{}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	return m
+}
+
+// Config returns an SDK configuration pointed at the server with the mock credentials
+func (m *MockServer) Config() *client.Config {
+	return client.NewConfig(m.URL, MockUsername, MockPassword)
+}
+
+// SDK returns an SDK instance configured to use the server
+func (m *MockServer) SDK() *abhi.SDK {
+	return abhi.New(m.Config())
+}
+
+// Handle registers handler for requests with the given method and path. Path segments written
+// as {name} match any single segment, e.g. "/employees/{id}". A later registration for the same
+// method and pattern replaces the earlier one.
+func (m *MockServer) Handle(method, pattern string, handler Handler) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, existing := range m.routes {
+		if existing.method == method && existing.pattern == pattern {
+			m.routes[i].handler = handler
+			return
+		}
+	}
+	m.routes = append(m.routes, route{method: method, pattern: pattern, handler: handler})
+}
+
+// HandleJSON registers a handler that receives the request body decoded into Req. A body that
+// doesn't decode is answered with a 400 without calling fn.
+func HandleJSON[Req any](m *MockServer, method, pattern string, fn func(req Req, r *http.Request) (interface{}, error)) {
+	m.Handle(method, pattern, func(r *http.Request) (interface{}, error) {
+		var req Req
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.NewAPIError(http.StatusBadRequest, "invalid request body", err.Error(), r.URL.Path)
+		}
+		return fn(req, r)
+	})
+}
+
+// Respond registers a handler that always returns data
+func (m *MockServer) Respond(method, pattern string, data interface{}) {
+	m.Handle(method, pattern, func(r *http.Request) (interface{}, error) {
+		return data, nil
+	})
+}
+
+// RespondError registers a handler that always fails with the given status and message
+func (m *MockServer) RespondError(method, pattern string, statusCode int, message string) {
+	m.Handle(method, pattern, func(r *http.Request) (interface{}, error) {
+		return nil, errors.NewAPIError(statusCode, message, "", r.URL.Path)
+	})
+}
+
+// Requests returns the API requests received so far, in order, excluding logins and refreshes
+func (m *MockServer) Requests() []RecordedRequest {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]RecordedRequest(nil), m.requests...)
+}
+
+// serveHTTP answers auth requests and dispatches the rest to the registered handlers
+func (m *MockServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && (r.URL.Path == "/auth/login" || r.URL.Path == "/auth/refresh") {
+		writeData(w, map[string]interface{}{"token": issueToken()})
+		return
+	}
+
+	body, _ := readBody(r)
+	m.mutex.Lock()
+	m.requests = append(m.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	handler := m.lookup(r.Method, r.URL.Path)
+	m.mutex.Unlock()
+
+	if handler == nil {
+		writeError(w, errors.NewAPIError(http.StatusNotFound, fmt.Sprintf("no mock handler for %s %s", r.Method, r.URL.Path), "", r.URL.Path))
+		return
+	}
+
+	data, err := handler(r)
+	if err != nil {
+		var apiErr *errors.APIError
+		if !stderrors.As(err, &apiErr) {
+			apiErr = errors.NewAPIError(http.StatusInternalServerError, err.Error(), "", r.URL.Path)
+		}
+		writeError(w, apiErr)
+		return
+	}
+	writeData(w, data)
+}
+
+// lookup returns the handler registered for method and path; the caller must hold the mutex
+func (m *MockServer) lookup(method, path string) Handler {
+	for _, route := range m.routes {
+		if route.method == method && matchPath(route.pattern, path) {
+			return route.handler
+		}
+	}
+	return nil
+}
+
+// matchPath reports whether path matches pattern, where {name} segments match any one segment
+func matchPath(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// PathParam returns the segment of r's path matched by the {name} segment of pattern, or "" if
+// the pattern has no such segment
+func PathParam(r *http.Request, pattern, name string) string {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	for i, segment := range patternSegments {
+		if segment == "{"+name+"}" && i < len(pathSegments) {
+			return pathSegments[i]
+		}
+	}
+	return ""
+}
+
+// issueToken returns a JWT that expires after MockTokenLifetime. It is signed with a fixed test
+// key, as the SDK only reads its expiry.
+func issueToken() string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": MockUsername,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(MockTokenLifetime).Unix(),
+	})
+	signed, _ := token.SignedString([]byte("abhi-mock-server"))
+	return signed
+}
+
+// readBody reads and restores the request body so handlers can read it again
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// writeData writes data wrapped in the standard API response envelope
+func writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.APIResponse{
+		StatusCode: http.StatusOK,
+		Message:    "Success",
+		Data:       data,
+	})
+}
+
+// writeError writes apiErr as an API error response
+func writeError(w http.ResponseWriter, apiErr *errors.APIError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.StatusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		StatusCode:       apiErr.StatusCode,
+		Message:          apiErr.Message,
+		Code:             models.ErrorCode(apiErr.Code),
+		Details:          apiErr.Details,
+		Data:             apiErr.Data,
+		ValidationErrors: apiErr.ValidationErrors,
+	})
+}
@@ -0,0 +1,96 @@
+package testutil
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"abhi-go-sdk/errors"
+	"abhi-go-sdk/models"
+)
+
+func TestMockServerEmployeeListStub(t *testing.T) {
+	server := NewMockServer()
+	defer server.Close()
+
+	server.Respond(http.MethodGet, "/employees", models.EmployeeListResponse{
+		Total: 2,
+		Results: []models.Employee{
+			{ID: "emp-1", FirstName: "Aisha", LastName: "Khan"},
+			{ID: "emp-2", FirstName: "Omar", LastName: "Saleh"},
+		},
+	})
+
+	sdk := server.SDK()
+	employees, err := sdk.Employee.List(context.Background(), &models.EmployeeListOptions{Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if employees.Total != 2 || len(employees.Results) != 2 || employees.Results[1].ID != "emp-2" {
+		t.Errorf("Unexpected employees: %+v", employees)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Method != http.MethodGet || requests[0].Path != "/employees" {
+		t.Fatalf("Expected one recorded GET /employees, got %+v", requests)
+	}
+	if requests[0].Header.Get("Authorization") == "" {
+		t.Error("Expected the request to carry the mock login token")
+	}
+}
+
+func TestMockServerPathParamsAndErrors(t *testing.T) {
+	server := NewMockServer()
+	defer server.Close()
+
+	server.Handle(http.MethodGet, "/employees/{id}", func(r *http.Request) (interface{}, error) {
+		id := PathParam(r, "/employees/{id}", "id")
+		if id != "emp-1" {
+			return nil, errors.NewAPIError(http.StatusNotFound, "Employee not found", "", r.URL.Path)
+		}
+		return models.Employee{ID: id, FirstName: "Aisha"}, nil
+	})
+	sdk := server.SDK()
+
+	employee, err := sdk.Employee.GetByID(context.Background(), "emp-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if employee.ID != "emp-1" || employee.FirstName != "Aisha" {
+		t.Errorf("Unexpected employee: %+v", employee)
+	}
+
+	if _, err := sdk.Employee.GetByID(context.Background(), "emp-missing"); !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := sdk.Organization.GetByID(context.Background(), "org-1"); !stderrors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Expected unregistered endpoints to return ErrNotFound, got %v", err)
+	}
+}
+
+func TestMockServerHandleJSON(t *testing.T) {
+	server := NewMockServer()
+	defer server.Close()
+
+	HandleJSON(server, http.MethodPost, "/transactions/employee", func(req models.TransactionRequest, r *http.Request) (interface{}, error) {
+		if req.Amount > 1000 {
+			return nil, &errors.APIError{StatusCode: http.StatusBadRequest, Message: "Insufficient balance", Code: "INSUFFICIENT_BALANCE"}
+		}
+		return models.Transaction{ID: "txn-1", EmployeeID: req.EmployeeID, Amount: models.Amount(req.Amount)}, nil
+	})
+	sdk := server.SDK()
+
+	txn, err := sdk.Transaction.CreateAdvanceTransaction(context.Background(), "emp-1", 500, "advance")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if txn.ID != "txn-1" || txn.EmployeeID != "emp-1" || txn.Amount != 500 {
+		t.Errorf("Unexpected transaction: %+v", txn)
+	}
+
+	if _, err := sdk.Transaction.CreateAdvanceTransaction(context.Background(), "emp-1", 5000, "advance"); !stderrors.Is(err, errors.ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance, got %v", err)
+	}
+}